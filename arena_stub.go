@@ -12,6 +12,10 @@ type Arena struct {
 	growthFactor    float64
 	growthBytes     int
 	growthThreshold float64
+	nodePadding     int
+	alignment       int
+	hugePages       bool
+	fixed           bool
 }
 
 // ArenaOption configures an Arena.
@@ -44,6 +48,23 @@ func WithGrowthThreshold(threshold float64) ArenaOption {
 	}
 }
 
+// WithPadding sets the minimum number of bytes left unused between two
+// nodes handed out by the arena.
+func WithPadding(bytes int) ArenaOption {
+	return func(a *Arena) {
+		if bytes > 0 {
+			a.nodePadding = bytes
+		}
+	}
+}
+
+// WithFixedSize forbids the arena from growing beyond its initial size.
+func WithFixedSize() ArenaOption {
+	return func(a *Arena) {
+		a.fixed = true
+	}
+}
+
 // NewArena creates a minimal Arena instance. The real allocation behavior is
 // intentionally omitted; this is a shim to provide the configuration API
 // used elsewhere in the codebase.
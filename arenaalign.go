@@ -0,0 +1,103 @@
+package skiplist
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// hugePageSize is the size of a transparent huge page on x86-64 and most
+// arm64 Linux systems. WithArenaHugePages only advises chunks at least this
+// large, smaller ones cannot be backed by a huge page anyway.
+const hugePageSize = 2 << 20
+
+// minAlignedChunk is the size in bytes from which the Go allocator places a
+// chunk at a page boundary. Smaller chunks of blocks holding pointers may
+// start 8 bytes past a 16-byte boundary, behind the allocator's type header,
+// which no whole number of blocks can make up for.
+const minAlignedChunk = 32<<10 + 1
+
+// WithArenaAlignment places every node allocated from the arena at an address
+// that is a multiple of bytes, which must be a power of two, e.g. 64 to start
+// each node on a cache line. Nodes are spaced by a multiple of the alignment,
+// so a node whose size is not one occupies the next multiple, as with
+// WithNodePadding, and every chunk is at least 32 KiB so that the Go
+// allocator places it at a page boundary; the nodes of a smaller chunk that
+// is all that is left of a WithFixedArena budget may be unaligned. Invalid
+// values are ignored.
+// This option is only effective when used with WithArena.
+// WithArenaAlignment จัดตำแหน่งโหนดใน Arena ให้อยู่บน address ที่เป็นผลคูณของ bytes
+func WithArenaAlignment[K any, V any](bytes int) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		if bytes > 0 && bytes&(bytes-1) == 0 {
+			sl.arenaAlignment = bytes
+		}
+	}
+}
+
+// WithArenaHugePages asks the kernel to back arena chunks of 2 MiB or more
+// with transparent huge pages (madvise MADV_HUGEPAGE), reducing TLB misses
+// when walking multi-GB arenas. It is a hint: it is a no-op on systems other
+// than Linux, and the kernel may ignore it, e.g. when transparent huge pages
+// are disabled.
+// This option is only effective when used with WithArena.
+// WithArenaHugePages ขอให้ kernel ใช้ huge page กับ chunk ขนาดใหญ่ของ Arena (Linux เท่านั้น)
+func WithArenaHugePages[K any, V any]() Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.arenaHugePages = true
+	}
+}
+
+// WithAlignment sets the alignment in bytes of the nodes handed out by the
+// arena.
+func WithAlignment(bytes int) ArenaOption {
+	return func(a *Arena) {
+		if bytes > 0 && bytes&(bytes-1) == 0 {
+			a.alignment = bytes
+		}
+	}
+}
+
+// WithHugePages advises the large chunks of the arena to use transparent
+// huge pages.
+func WithHugePages() ArenaOption {
+	return func(a *Arena) {
+		a.hugePages = true
+	}
+}
+
+// alignedStride returns the smallest multiple of stride, in slots of
+// blockSize bytes, that is also a multiple of align bytes.
+func alignedStride(stride, blockSize, align int) int {
+	step := align >> min(bits.TrailingZeros(uint(blockSize)), bits.TrailingZeros(uint(align)))
+	return (stride + step - 1) / step * step
+}
+
+// alignChunk returns the size blocks of chunk starting at its first block
+// aligned to align bytes. chunk holds the size blocks plus the slack of
+// alignedStride(1, sizeof(T), align)-1 blocks that the aligned start may
+// need.
+func alignChunk[T any](chunk []T, size, align int) []T {
+	var zero T
+	blockSize := uintptr(unsafe.Sizeof(zero))
+	base := uintptr(unsafe.Pointer(unsafe.SliceData(chunk)))
+	for off := 0; off < len(chunk)-size+1; off++ {
+		if (base+uintptr(off)*blockSize)%uintptr(align) == 0 {
+			return chunk[off : off+size]
+		}
+	}
+	// The Go allocator aligns chunks to at least 8 bytes and large ones to
+	// pages, so this is only reached for block sizes and alignments no
+	// offset can reconcile; the nodes are then left unaligned.
+	return chunk[:size]
+}
+
+// chunkBytes returns the memory of chunk as a byte slice.
+func chunkBytes[T any](chunk []T) []byte {
+	var zero T
+	return unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(chunk))), len(chunk)*int(unsafe.Sizeof(zero)))
+}
+
+// uintptrOf returns the address of the first byte of b.
+func uintptrOf(b []byte) uintptr {
+	return uintptr(unsafe.Pointer(unsafe.SliceData(b)))
+}
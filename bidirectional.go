@@ -0,0 +1,115 @@
+package skiplist
+
+// WithBidirectionalLevels keeps a backward pointer at every level of every
+// node, not only at level 0. Iterator.Skip can then move an iterator k entries
+// backwards in O(log k) by climbing the backward chains of the upper levels,
+// instead of descending from the header for every backward skip. This makes
+// reverse paging over a large range (e.g. "the 100 entries before this one")
+// independent of the list size.
+//
+// The extra pointers cost one slice per node and a few pointer writes per
+// insert and delete.
+// WithBidirectionalLevels เก็บตัวชี้ย้อนกลับในทุกชั้นของโหนด เพื่อให้ข้ามย้อนกลับได้ใน O(log k)
+func WithBidirectionalLevels[K any, V any]() Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.backLinks = true
+	}
+}
+
+// sizeBack sizes the backward pointers of n to the level of n, reusing the
+// backing array left by a pool allocator when it is large enough.
+func (n *node[K, V]) sizeBack() {
+	if level := len(n.forward); cap(n.back) < level {
+		n.back = make([]*node[K, V], level)
+	} else {
+		n.back = n.back[:level]
+	}
+}
+
+// linkBack sets the backward pointers of n, just linked by insert after the
+// nodes of update, and of its successors. The caller must hold the write lock.
+func (sl *SkipList[K, V]) linkBack(n *node[K, V], update []INode[K, V]) {
+	n.sizeBack()
+	for i := range n.forward {
+		n.back[i] = update[i].(*node[K, V])
+		if next := n.forward[i]; next != nil {
+			next.back[i] = n
+		}
+	}
+}
+
+// unlinkBack points the successors of n, about to be unlinked, at the
+// predecessors of n. The caller must hold the write lock.
+func unlinkBack[K any, V any](n *node[K, V]) {
+	for i, next := range n.forward {
+		if next != nil {
+			next.back[i] = n.back[i]
+		}
+	}
+}
+
+// skipForward returns the node k positions after n, which may be the header,
+// or nil if the list ends first. At every step it takes the highest link of
+// the current node that does not overshoot, so the cost is O(log k).
+// The caller must hold a lock.
+func (sl *SkipList[K, V]) skipForward(n *node[K, V], k int) *node[K, V] {
+	if sl.noRanks {
+		for ; k > 0 && n != nil; k-- {
+			n = n.forward[0]
+		}
+		return n
+	}
+	for k > 0 {
+		moved := false
+		for i := min(len(n.forward)-1, sl.level); i >= 0; i-- {
+			if next := n.forward[i]; next != nil && n.span[i] <= k {
+				k -= n.span[i]
+				n = next
+				moved = true
+				break
+			}
+		}
+		if !moved {
+			return nil
+		}
+	}
+	return n
+}
+
+// skipBackward returns the node k positions before n, or nil if the list
+// starts first. With WithBidirectionalLevels it climbs the backward chains in
+// O(log k); otherwise the target is found by rank in O(log n).
+// The caller must hold a lock.
+func (sl *SkipList[K, V]) skipBackward(n *node[K, V], k int) *node[K, V] {
+	if sl.noRanks {
+		for ; k > 0 && n != nil; k-- {
+			if n = n.backward; n == sl.header {
+				return nil
+			}
+		}
+		return n
+	}
+	if !sl.backLinks {
+		pos := sl.rank(n.key, false) - k
+		if pos < 0 {
+			return nil
+		}
+		return sl.getByRank(pos)
+	}
+	for k > 0 {
+		moved := false
+		for i := len(n.forward) - 1; i >= 0; i-- {
+			// The distance from a predecessor to n is the span of its link.
+			if prev := n.back[i]; prev != sl.header && prev.span[i] <= k {
+				k -= prev.span[i]
+				n = prev
+				moved = true
+				break
+			}
+		}
+		if !moved {
+			return nil
+		}
+	}
+	return n
+}
@@ -0,0 +1,113 @@
+package skiplist
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+// nextN returns the key reached by k > 0 calls to Next, and whether the last
+// one succeeded.
+func nextN(it *Iterator[int, int], k int) (int, bool) {
+	ok := true
+	for i := 0; i < k && ok; i++ {
+		ok = it.Next()
+	}
+	if !ok {
+		return 0, false
+	}
+	return it.Key(), true
+}
+
+func TestIterator_Skip(t *testing.T) {
+	for _, back := range []bool{false, true} {
+		for _, setup := range getTestSetups[int, int]() {
+			name := setup.name
+			var opts []Option[int, int]
+			if back {
+				name += "/Bidirectional"
+				opts = append(opts, WithBidirectionalLevels[int, int]())
+			}
+			t.Run(name, func(t *testing.T) {
+				sl := setup.constructor(nil, opts...)
+				for i := 0; i < 1000; i++ {
+					sl.Insert(i*2, i)
+				}
+				r := rand.New(rand.NewPCG(7, 8))
+				iterOpts := [][]IteratorOption[int, int]{
+					nil,
+					{WithReverse[int, int]()},
+					{WithEnd[int, int](1500)},
+					{WithReverse[int, int](), WithEnd[int, int](1501)},
+				}
+				for _, io := range iterOpts {
+					for trial := 0; trial < 50; trial++ {
+						first, k := r.IntN(5), 1+r.IntN(1200)
+						want := sl.NewIterator(io...)
+						got := sl.NewIterator(io...)
+						for i := 0; i < first; i++ {
+							want.Next()
+						}
+						got.Skip(first)
+						wantKey, wantOK := nextN(want, k)
+						gotOK := got.Skip(k)
+						if gotOK != wantOK || (gotOK && got.Key() != wantKey) {
+							t.Fatalf("Skip(%d) then Skip(%d) with %d options = %v, want key %d, %v", first, k, len(io), gotOK, wantKey, wantOK)
+						}
+					}
+				}
+				if sl.NewIterator().Skip(0) {
+					t.Error("Skip(0) on a fresh iterator should return false")
+				}
+			})
+		}
+	}
+}
+
+func TestBidirectionalLevels_Invariants(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(9, 10))
+			sl := setup.constructor(nil, WithBidirectionalLevels[int, int]())
+			for step := 0; step < 3000; step++ {
+				switch op := r.IntN(10); {
+				case op < 6:
+					sl.Insert(r.IntN(1000), step)
+				case op == 6:
+					sl.PopMin()
+				case op == 7:
+					sl.PopMax()
+				default:
+					sl.Delete(r.IntN(1000))
+				}
+				if step%100 == 0 {
+					if err := sl.CheckSpans(); err != nil {
+						t.Fatalf("step %d: %v", step, err)
+					}
+				}
+			}
+
+			i := 2000
+			if _, err := sl.BulkLoad(func() (int, int, bool) {
+				i++
+				return i, i, i < 2500
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if err := sl.CheckSpans(); err != nil {
+				t.Fatalf("after BulkLoad: %v", err)
+			}
+			if err := sl.MigrateAllocator(); err != nil {
+				t.Fatal(err)
+			}
+			if err := sl.CheckSpans(); err != nil {
+				t.Fatalf("after MigrateAllocator: %v", err)
+			}
+
+			sl.header.forward[0].forward[0].back[0] = sl.header
+			if err := sl.CheckSpans(); !errors.Is(err, ErrCorrupt) {
+				t.Errorf("CheckSpans with a broken backward pointer returned %v, want ErrCorrupt", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package skiplist
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewBytes(t *testing.T) {
+	setups := map[string][]Option[[]byte, int]{
+		"WithPool":  nil,
+		"WithArena": {WithArena[[]byte, int](1024)},
+	}
+	for name, opts := range setups {
+		t.Run(name, func(t *testing.T) {
+			sl := NewBytes(opts...)
+			for i := 0; i < 1000; i += 2 {
+				sl.Insert([]byte(fmt.Sprintf("user/profile/%06d", i)), i)
+			}
+
+			for i := 0; i < 1000; i++ {
+				n, ok := sl.Search([]byte(fmt.Sprintf("user/profile/%06d", i)))
+				if ok != (i%2 == 0) || ok && n.Value() != i {
+					t.Fatalf("Search(%d) = %v, %v", i, n, ok)
+				}
+			}
+			if n, ok := sl.Seek([]byte("user/profile/000501")); !ok || n.Value() != 502 {
+				t.Errorf("Seek() = %v, %v, want 502", n, ok)
+			}
+			if _, ok := sl.Seek([]byte("user/z")); ok {
+				t.Error("Seek() past the last key should fail")
+			}
+			if n, ok := sl.Seek(nil); !ok || n.Value() != 0 {
+				t.Errorf("Seek(nil) = %v, %v, want 0", n, ok)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package skiplist
+
+import "errors"
+
+// ErrLoaderPanicked is returned to the callers waiting for a load shared with
+// a call whose loader panicked.
+var ErrLoaderPanicked = errors.New("skiplist: cache loader panicked")
+
+// CacheBackend is the slower store a Cache sits in front of, e.g. a database
+// or a remote service. Its methods may be called concurrently.
+// CacheBackend คือแหล่งข้อมูลที่ช้ากว่าซึ่ง Cache ทำหน้าที่เป็น tier ในหน่วยความจำให้
+type CacheBackend[K any, V any] interface {
+	// Load returns the value of key, and false if the backend has no value
+	// for it.
+	Load(key K) (V, bool, error)
+	// Store writes the value of key.
+	Store(key K, value V) error
+	// Delete removes key.
+	Delete(key K) error
+}
+
+// Cache uses a skiplist as the ordered in-memory tier in front of a
+// CacheBackend: reads are served from the skiplist and fall through to the
+// backend on a miss (read-through), and writes go to the backend before the
+// skiplist (write-through). Concurrent misses for the same key share a
+// single load.
+//
+// The skiplist stays usable directly, e.g. for ordered scans of the cached
+// entries; entries evicted from it with Invalidate or Delete are simply
+// loaded again on the next miss.
+//
+// Cache ใช้ skiplist เป็น tier ในหน่วยความจำที่เรียงลำดับ หน้า CacheBackend
+// การอ่านที่ไม่พบจะโหลดจาก backend และการเขียนจะเขียนลง backend ก่อนเสมอ
+type Cache[K any, V any] struct {
+	sl      *SkipList[K, V]
+	backend CacheBackend[K, V]
+
+	mu syncMutex
+	// inflight holds the loads in progress, keyed like sl.
+	inflight *SkipList[K, *loadCall[V]]
+}
+
+// loadCall is a load shared by the concurrent misses of one key.
+type loadCall[V any] struct {
+	done  chan struct{}
+	value V
+	found bool
+	err   error
+	// stale is set when the key is written or deleted during the load, so
+	// that the loaded value is not cached over the newer state.
+	stale bool
+}
+
+// NewCache creates a Cache storing its entries in sl. backend may be nil if
+// only GetOrLoad is used.
+// NewCache สร้าง Cache ที่เก็บข้อมูลไว้ใน sl
+func NewCache[K any, V any](sl *SkipList[K, V], backend CacheBackend[K, V]) *Cache[K, V] {
+	return &Cache[K, V]{
+		sl:       sl,
+		backend:  backend,
+		inflight: NewWithComparator[K, *loadCall[V]](sl.compare),
+	}
+}
+
+// Get returns the value of key from the skiplist, loading it from the backend
+// on a miss. A value found in the backend is cached; a key missing from the
+// backend is not.
+// Get คืนค่า value ของ key จาก skiplist หรือโหลดจาก backend หากไม่พบ
+func (c *Cache[K, V]) Get(key K) (V, bool, error) {
+	return c.load(key, c.backend.Load)
+}
+
+// GetOrLoad returns the value of key from the skiplist or, on a miss, the
+// value returned by loader, which is then cached. Concurrent calls for the
+// same key wait for a single call of loader and share its result, including
+// its error. A failed load caches nothing.
+// GetOrLoad คืนค่า value ของ key จาก skiplist หรือเรียก loader เมื่อไม่พบ
+// การเรียกพร้อมกันสำหรับ key เดียวกันจะรอผลจาก loader เพียงครั้งเดียว
+func (c *Cache[K, V]) GetOrLoad(key K, loader func(key K) (V, error)) (V, error) {
+	v, _, err := c.load(key, func(key K) (V, bool, error) {
+		v, err := loader(key)
+		return v, err == nil, err
+	})
+	return v, err
+}
+
+// Set writes value to the backend, then caches it.
+// Set เขียน value ลง backend แล้วจึงเก็บไว้ใน skiplist
+func (c *Cache[K, V]) Set(key K, value V) error {
+	if err := c.backend.Store(key, value); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.markStale(key)
+	c.sl.Insert(key, value)
+	return nil
+}
+
+// Delete removes key from the backend, then from the skiplist.
+// Delete ลบ key ออกจาก backend แล้วจึงลบออกจาก skiplist
+func (c *Cache[K, V]) Delete(key K) error {
+	if err := c.backend.Delete(key); err != nil {
+		return err
+	}
+	c.Invalidate(key)
+	return nil
+}
+
+// Invalidate evicts key from the skiplist without touching the backend.
+// Invalidate ลบ key ออกจาก skiplist โดยไม่แก้ไข backend
+func (c *Cache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.markStale(key)
+	c.sl.Delete(key)
+}
+
+// load implements Get and GetOrLoad with fn as the loader.
+func (c *Cache[K, V]) load(key K, fn func(K) (V, bool, error)) (V, bool, error) {
+	if n, ok := c.sl.Search(key); ok {
+		return n.Value(), true, nil
+	}
+
+	c.mu.Lock()
+	if n, ok := c.inflight.Search(key); ok {
+		call := n.Value()
+		c.mu.Unlock()
+		<-call.done
+		return call.value, call.found, call.err
+	}
+	// The key may have been cached since the first lookup.
+	if n, ok := c.sl.Search(key); ok {
+		c.mu.Unlock()
+		return n.Value(), true, nil
+	}
+	// The error is replaced by the result of fn unless fn panics.
+	call := &loadCall[V]{done: make(chan struct{}), err: ErrLoaderPanicked}
+	c.inflight.Insert(key, call)
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		if call.found && call.err == nil && !call.stale {
+			c.sl.Insert(key, call.value)
+		}
+		c.inflight.Delete(key)
+		c.mu.Unlock()
+		close(call.done)
+	}()
+	call.value, call.found, call.err = fn(key)
+	return call.value, call.found, call.err
+}
+
+// markStale flags the load of key in progress, if any. The caller must hold
+// c.mu.
+func (c *Cache[K, V]) markStale(key K) {
+	if n, ok := c.inflight.Search(key); ok {
+		n.Value().stale = true
+	}
+}
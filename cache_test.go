@@ -0,0 +1,168 @@
+package skiplist
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// mapBackend is a CacheBackend counting its loads.
+type mapBackend struct {
+	mu    sync.Mutex
+	data  map[int]string
+	loads atomic.Int32
+}
+
+func (b *mapBackend) Load(key int) (string, bool, error) {
+	b.loads.Add(1)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.data[key]
+	return v, ok, nil
+}
+
+func (b *mapBackend) Store(key int, value string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data[key] = value
+	return nil
+}
+
+func (b *mapBackend) Delete(key int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.data, key)
+	return nil
+}
+
+func TestCache_ReadWriteThrough(t *testing.T) {
+	backend := &mapBackend{data: map[int]string{1: "one", 2: "two"}}
+	sl := New[int, string]()
+	c := NewCache(sl, backend)
+
+	if v, ok, err := c.Get(1); err != nil || !ok || v != "one" {
+		t.Fatalf("Get(1) = %q, %v, %v", v, ok, err)
+	}
+	if v, ok, _ := c.Get(1); !ok || v != "one" || backend.loads.Load() != 1 {
+		t.Errorf("second Get(1) = %q, %v after %d loads, want a cache hit", v, ok, backend.loads.Load())
+	}
+	if _, ok, err := c.Get(3); ok || err != nil {
+		t.Errorf("Get of a missing key = %v, %v", ok, err)
+	}
+	if sl.Len() != 1 {
+		t.Errorf("Len() = %d, want 1: misses must not be cached", sl.Len())
+	}
+
+	if err := c.Set(3, "three"); err != nil {
+		t.Fatal(err)
+	}
+	if backend.data[3] != "three" {
+		t.Error("Set did not write through")
+	}
+	if n, ok := sl.Search(3); !ok || n.Value() != "three" {
+		t.Error("Set did not cache the value")
+	}
+
+	if err := c.Delete(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := backend.data[1]; ok {
+		t.Error("Delete did not write through")
+	}
+	if _, ok, _ := c.Get(1); ok {
+		t.Error("Get after Delete found the key")
+	}
+
+	c.Invalidate(3)
+	if _, ok := sl.Search(3); ok {
+		t.Error("Invalidate left the key cached")
+	}
+	if v, ok, _ := c.Get(3); !ok || v != "three" {
+		t.Error("Get after Invalidate did not reload from the backend")
+	}
+}
+
+func TestCache_GetOrLoadDeduplicates(t *testing.T) {
+	c := NewCache[int, int](New[int, int](), nil)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func(k int) (int, error) {
+		calls.Add(1)
+		<-release
+		return k * 10, nil
+	}
+
+	const n = 16
+	var wg sync.WaitGroup
+	results := make([]int, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := c.GetOrLoad(7, loader)
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = v
+		}(i)
+	}
+	waitFor(t, "the first load", func() bool { return calls.Load() == 1 })
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("loader ran %d times for concurrent misses, want 1", calls.Load())
+	}
+	for i, v := range results {
+		if v != 70 {
+			t.Errorf("caller %d got %d, want 70", i, v)
+		}
+	}
+}
+
+func TestCache_LoadErrors(t *testing.T) {
+	c := NewCache[int, int](New[int, int](), nil)
+	errLoad := errors.New("backend down")
+	if _, err := c.GetOrLoad(1, func(int) (int, error) { return 0, errLoad }); !errors.Is(err, errLoad) {
+		t.Fatalf("GetOrLoad returned %v, want the loader error", err)
+	}
+	if v, err := c.GetOrLoad(1, func(int) (int, error) { return 5, nil }); err != nil || v != 5 {
+		t.Errorf("GetOrLoad after a failed load = %d, %v, want 5", v, err)
+	}
+
+	func() {
+		defer func() { recover() }()
+		c.GetOrLoad(2, func(int) (int, error) { panic("boom") })
+	}()
+	if v, err := c.GetOrLoad(2, func(int) (int, error) { return 9, nil }); err != nil || v != 9 {
+		t.Errorf("GetOrLoad after a panicking load = %d, %v, want 9", v, err)
+	}
+}
+
+func TestCache_WriteDuringLoad(t *testing.T) {
+	backend := &mapBackend{data: map[int]string{1: "old"}}
+	c := NewCache(New[int, string](), backend)
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan string)
+	go func() {
+		v, _ := c.GetOrLoad(1, func(k int) (string, error) {
+			v, _, _ := backend.Load(k)
+			close(started)
+			<-release
+			return v, nil
+		})
+		done <- v
+	}()
+	<-started
+	if err := c.Set(1, "new"); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	if v := <-done; v != "old" {
+		t.Errorf("load returned %q, want the value it read", v)
+	}
+	if v, _, _ := c.Get(1); v != "new" {
+		t.Errorf("Get after a write during a load = %q, want new", v)
+	}
+}
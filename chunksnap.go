@@ -0,0 +1,508 @@
+package skiplist
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"math/bits"
+	"reflect"
+	"slices"
+	"unsafe"
+)
+
+// ErrChunkSnapshot is returned by SaveChunks when the list does not use an
+// arena allocator or when K or V holds pointers, and by Load and
+// OpenReadOnly when a chunk snapshot was written for other key or value
+// types.
+var ErrChunkSnapshot = errors.New("skiplist: chunk snapshots need an arena allocator and key and value types without pointers")
+
+// Chunk snapshot layout, laid out so that it can be memory mapped (see
+// OpenReadOnly), in native byte order:
+//
+//	header  64 bytes: magic, uint32 0x01020304, uint32 order width (4 or 8),
+//	        uint64 sizeof(K), uint64 sizeof(V), uint64 count, zeros,
+//	        uint32 CRC-32C of the preceding header bytes
+//	keys    count keys in memory layout
+//	values  count values in memory layout
+//	order   count entry numbers of order width, in key order
+//	trailer uint32 CRC-32C of everything between the header and the trailer
+//
+// Each region starts at a multiple of chunkAlign bytes, the gaps being zero.
+// The entries are saved, and numbered, in the memory order of the arena
+// chunks; the order region is the relink table giving their key order.
+const (
+	chunkMagic       = "SLCHNK\x00\x02"
+	chunkHeaderSize  = 64
+	chunkAlign       = 64
+	chunkByteOrder   = 0x01020304
+	chunkTrailerSize = 4
+)
+
+// chunkSection is the number of entries buffered between two writes and
+// read at a time.
+const chunkSection = 16 << 10
+
+// chunkLayout gives the offsets of the regions of a chunk snapshot.
+type chunkLayout struct {
+	count               uint64
+	width               int // size of an order entry
+	keySize, valueSize  uint64
+	keys, values, order uint64
+	trailer             uint64
+}
+
+// newChunkLayout returns the layout of a chunk snapshot of count entries of
+// K and V.
+func newChunkLayout[K any, V any](count uint64) chunkLayout {
+	var key K
+	var value V
+	l := chunkLayout{count: count, width: 8, keySize: uint64(unsafe.Sizeof(key)), valueSize: uint64(unsafe.Sizeof(value))}
+	if count <= math.MaxUint32 {
+		l.width = 4
+	}
+	l.place()
+	return l
+}
+
+// place computes the offsets of the regions, and reports whether the
+// snapshot is small enough to be addressed with an int.
+func (l *chunkLayout) place() bool {
+	end := uint64(chunkHeaderSize)
+	ok := true
+	region := func(size uint64) uint64 {
+		hi, lo := bits.Mul64(l.count, size)
+		off := (end + chunkAlign - 1) / chunkAlign * chunkAlign
+		if hi != 0 || lo > math.MaxInt-off-2*chunkAlign {
+			ok = false
+			return off
+		}
+		end = off + lo
+		return off
+	}
+	l.keys = region(l.keySize)
+	l.values = region(l.valueSize)
+	l.order = region(uint64(l.width))
+	l.trailer = region(0)
+	return ok
+}
+
+// size returns the size in bytes of the snapshot.
+func (l chunkLayout) size() uint64 {
+	return l.trailer + chunkTrailerSize
+}
+
+// header returns the header of a snapshot with layout l.
+func (l chunkLayout) header() []byte {
+	hdr := make([]byte, chunkHeaderSize)
+	copy(hdr, chunkMagic)
+	binary.NativeEndian.PutUint32(hdr[8:], chunkByteOrder)
+	binary.NativeEndian.PutUint32(hdr[12:], uint32(l.width))
+	binary.NativeEndian.PutUint64(hdr[16:], l.keySize)
+	binary.NativeEndian.PutUint64(hdr[24:], l.valueSize)
+	binary.NativeEndian.PutUint64(hdr[32:], l.count)
+	binary.NativeEndian.PutUint32(hdr[chunkHeaderSize-4:], crc32.Checksum(hdr[:chunkHeaderSize-4], castagnoli))
+	return hdr
+}
+
+// parseChunkHeader returns the layout of the snapshot of K and V with the
+// given header.
+func parseChunkHeader[K any, V any](hdr []byte) (chunkLayout, error) {
+	if len(hdr) < chunkHeaderSize || string(hdr[:len(chunkMagic)]) != chunkMagic {
+		return chunkLayout{}, fmt.Errorf("%w: not a chunk snapshot", ErrCorrupt)
+	}
+	if binary.NativeEndian.Uint32(hdr[8:]) != chunkByteOrder {
+		return chunkLayout{}, fmt.Errorf("%w: snapshot written in another byte order", ErrChunkSnapshot)
+	}
+	if crc32.Checksum(hdr[:chunkHeaderSize-4], castagnoli) != binary.NativeEndian.Uint32(hdr[chunkHeaderSize-4:]) {
+		return chunkLayout{}, fmt.Errorf("%w: chunk snapshot header checksum mismatch", ErrCorrupt)
+	}
+	l := chunkLayout{
+		count:     binary.NativeEndian.Uint64(hdr[32:]),
+		width:     int(binary.NativeEndian.Uint32(hdr[12:])),
+		keySize:   binary.NativeEndian.Uint64(hdr[16:]),
+		valueSize: binary.NativeEndian.Uint64(hdr[24:]),
+	}
+	want := newChunkLayout[K, V](0)
+	if l.keySize != want.keySize || l.valueSize != want.valueSize {
+		return chunkLayout{}, fmt.Errorf("%w: snapshot written for %d-byte keys and %d-byte values", ErrChunkSnapshot, l.keySize, l.valueSize)
+	}
+	if (l.width != 4 && l.width != 8) || !l.place() {
+		return chunkLayout{}, fmt.Errorf("%w: malformed chunk snapshot header", ErrCorrupt)
+	}
+	return l, nil
+}
+
+// chunkWriter writes a chunk snapshot, checksumming everything after the
+// header.
+type chunkWriter struct {
+	w   *bufio.Writer
+	off uint64
+	sum uint32
+	err error
+}
+
+func (cw *chunkWriter) write(p []byte) {
+	if cw.err != nil {
+		return
+	}
+	if cw.off >= chunkHeaderSize {
+		cw.sum = crc32.Update(cw.sum, castagnoli, p)
+	}
+	_, cw.err = cw.w.Write(p)
+	cw.off += uint64(len(p))
+}
+
+// seek pads the snapshot with zeros up to off, the start of a region.
+func (cw *chunkWriter) seek(off uint64) {
+	var zeros [chunkAlign]byte
+	cw.write(zeros[:off-cw.off])
+}
+
+// SaveChunks writes a snapshot of the skiplist to w by copying the keys and
+// values of the arena chunks as they lie in memory, along with a relink
+// table giving their key order, instead of encoding entry by entry with a
+// codec. The chunks are read sequentially rather than by following the
+// links of the list, which makes saving a multi-GB list several times
+// faster than Save. The snapshot is checksummed, is restored by
+// Load or opened in place by OpenReadOnly, and can only be read with the
+// same K and V on a platform with the same byte order.
+// SaveChunks returns ErrChunkSnapshot unless the list uses WithArena and K
+// and V hold no pointers (no strings, slices, maps, interfaces or pointers).
+// SaveChunks เขียน snapshot โดยคัดลอก key และ value จาก chunk ของ Arena ตรงๆ แทนการเข้ารหัสทีละรายการ
+func (sl *SkipList[K, V]) SaveChunks(w io.Writer) error {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	arena, ok := sl.allocator.(*arenaAllocator[K, V])
+	if !ok || !plainData[K]() || !plainData[V]() {
+		return ErrChunkSnapshot
+	}
+
+	// The entries are numbered in the order they are saved. A first pass
+	// records which slots of each chunk hold an entry, so that the number of
+	// the entry of a node can be derived from its address; the next ones
+	// save the keys, noting the number of their successor, and the values.
+	// All read the chunks sequentially instead of following the links of
+	// the list.
+	var chunks []savedChunk
+	saved := 0
+	for _, slab := range arena.slabs {
+		slab.eachChunk(func(first unsafe.Pointer, slotSize, stride, slots int) {
+			c := savedChunk{
+				first:    first,
+				start:    uintptr(first),
+				end:      uintptr(first) + uintptr(slots*slotSize),
+				slotSize: slotSize,
+				index:    saved,
+				live:     make([]uint64, (slots+63)/64),
+				before:   make([]int, (slots+63)/64),
+			}
+			for i := 0; i < slots; i += stride {
+				if (*node[K, V])(unsafe.Add(first, i*slotSize)).gen != 0 {
+					c.live[i/64] |= 1 << (i % 64)
+				}
+			}
+			for i := range c.live {
+				if i > 0 {
+					c.before[i] = c.before[i-1] + bits.OnesCount64(c.live[i-1])
+				}
+				saved += bits.OnesCount64(c.live[i])
+			}
+			chunks = append(chunks, c)
+		})
+	}
+	if saved != sl.length {
+		return fmt.Errorf("%w: arena holds %d entries but the list %d", ErrCorrupt, saved, sl.length)
+	}
+	sorted := slices.Clone(chunks)
+	slices.SortFunc(sorted, func(a, b savedChunk) int {
+		if a.start < b.start {
+			return -1
+		}
+		return 1
+	})
+	indexOf := func(n *node[K, V]) int {
+		if n == nil {
+			return sl.length
+		}
+		return chunkIndexOf(sorted, uintptr(unsafe.Pointer(n)))
+	}
+	eachEntry := func(f func(n *node[K, V])) {
+		for _, c := range chunks {
+			for word, live := range c.live {
+				for ; live != 0; live &= live - 1 {
+					slot := word*64 + bits.TrailingZeros64(live)
+					f((*node[K, V])(unsafe.Add(c.first, slot*c.slotSize)))
+				}
+			}
+		}
+	}
+
+	l := newChunkLayout[K, V](uint64(sl.length))
+	cw := &chunkWriter{w: bufio.NewWriterSize(w, frameBlockSize)}
+	cw.write(l.header())
+
+	cw.seek(l.keys)
+	next := make([]int, 0, sl.length)
+	keys := make([]K, 0, chunkSection)
+	eachEntry(func(n *node[K, V]) {
+		keys = append(keys, n.key)
+		next = append(next, indexOf(n.forward[0]))
+		if len(keys) == cap(keys) {
+			cw.write(chunkBytes(keys))
+			keys = keys[:0]
+		}
+	})
+	cw.write(chunkBytes(keys))
+
+	cw.seek(l.values)
+	values := make([]V, 0, chunkSection)
+	eachEntry(func(n *node[K, V]) {
+		values = append(values, n.value)
+		if len(values) == cap(values) {
+			cw.write(chunkBytes(values))
+			values = values[:0]
+		}
+	})
+	cw.write(chunkBytes(values))
+
+	cw.seek(l.order)
+	buf := make([]byte, 0, chunkSection*l.width)
+	for _, i := range sl.keyOrder(next, indexOf) {
+		if l.width == 4 {
+			buf = binary.NativeEndian.AppendUint32(buf, uint32(i))
+		} else {
+			buf = binary.NativeEndian.AppendUint64(buf, uint64(i))
+		}
+		if len(buf) == cap(buf) {
+			cw.write(buf)
+			buf = buf[:0]
+		}
+	}
+	cw.write(buf)
+
+	cw.seek(l.trailer)
+	cw.write(binary.NativeEndian.AppendUint32(nil, cw.sum))
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
+
+// keyOrder returns the numbers of the saved entries in key order, given the
+// number of the successor of each and the number of the entry of a node.
+// Following the
+// successors one after the other would wait on a cache miss per entry, so
+// the chain is cut at the nodes of an upper level, whose ranks are known,
+// and the pieces are followed in turn, overlapping their misses. The
+// caller must hold a lock.
+func (sl *SkipList[K, V]) keyOrder(next []int, indexOf func(*node[K, V]) int) []int {
+	type piece struct{ pos, end, i int }
+	pieces := []piece{{i: indexOf(sl.header.forward[0])}}
+	if level := min(sl.level, 3); level > 0 && !sl.noRanks {
+		rank := -1
+		for x := sl.header; x.forward[level] != nil; x = x.forward[level] {
+			rank += x.span[level]
+			if rank > 0 {
+				pieces = append(pieces, piece{pos: rank, i: indexOf(x.forward[level])})
+			}
+		}
+	}
+	for k := range pieces {
+		pieces[k].end = sl.length
+		if k+1 < len(pieces) {
+			pieces[k].end = pieces[k+1].pos
+		}
+	}
+	if sl.length == 0 {
+		pieces = nil
+	}
+
+	order := make([]int, sl.length)
+	for len(pieces) > 0 {
+		group := pieces[:min(len(pieces), 32)]
+		pieces = pieces[len(group):]
+		for len(group) > 0 {
+			for k := 0; k < len(group); {
+				p := &group[k]
+				order[p.pos] = p.i
+				if p.pos++; p.pos == p.end {
+					group[k] = group[len(group)-1]
+					group = group[:len(group)-1]
+					continue
+				}
+				p.i = next[p.i]
+				k++
+			}
+		}
+	}
+	return order
+}
+
+// savedChunk locates the entries of an arena chunk in a chunk snapshot.
+type savedChunk struct {
+	first      unsafe.Pointer // first slot
+	start, end uintptr        // addresses of the first slot and past the last one
+	slotSize   int
+	index      int      // number of the first entry saved from the chunk
+	live       []uint64 // bitmap of the slots holding an entry
+	before     []int    // entries in the chunk before each bitmap word
+}
+
+// chunkIndexOf returns the number of the saved entry of the node at addr,
+// which must be a node of an entry in one of chunks, sorted by address.
+func chunkIndexOf(chunks []savedChunk, addr uintptr) int {
+	lo, hi := 0, len(chunks)
+	for lo < hi {
+		if m := int(uint(lo+hi) >> 1); chunks[m].end <= addr {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	c := &chunks[lo]
+	slot := int(addr-c.start) / c.slotSize
+	word, bit := slot/64, uint(slot%64)
+	return c.index + c.before[word] + bits.OnesCount64(c.live[word]&(1<<bit-1))
+}
+
+// loadChunks bulk loads a chunk snapshot from r, positioned at its start.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) loadChunks(r io.Reader) error {
+	if !plainData[K]() || !plainData[V]() {
+		return ErrChunkSnapshot
+	}
+	hdr := make([]byte, chunkHeaderSize)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return truncated(err)
+	}
+	l, err := parseChunkHeader[K, V](hdr)
+	if err != nil {
+		return err
+	}
+
+	// The regions are read a section at a time, so that a damaged count
+	// fails at the end of the input rather than with a huge allocation.
+	off := uint64(chunkHeaderSize)
+	var sum uint32
+	read := func(p []byte) error {
+		if _, err := io.ReadFull(r, p); err != nil {
+			return truncated(err)
+		}
+		sum = crc32.Update(sum, castagnoli, p)
+		off += uint64(len(p))
+		return nil
+	}
+	seek := func(to uint64) error {
+		var gap [chunkAlign]byte
+		return read(gap[:to-off])
+	}
+	if err := seek(l.keys); err != nil {
+		return err
+	}
+	keys, err := readChunkRegion[K](read, l.count)
+	if err != nil {
+		return err
+	}
+	if err := seek(l.values); err != nil {
+		return err
+	}
+	values, err := readChunkRegion[V](read, l.count)
+	if err != nil {
+		return err
+	}
+	if err := seek(l.order); err != nil {
+		return err
+	}
+	var order []uint64
+	if l.width == 4 {
+		narrow, err := readChunkRegion[uint32](read, l.count)
+		if err != nil {
+			return err
+		}
+		order = make([]uint64, len(narrow))
+		for i, v := range narrow {
+			order[i] = uint64(v)
+		}
+	} else if order, err = readChunkRegion[uint64](read, l.count); err != nil {
+		return err
+	}
+	if err := seek(l.trailer); err != nil {
+		return err
+	}
+	trailer := make([]byte, chunkTrailerSize)
+	if _, err := io.ReadFull(r, trailer); err != nil {
+		return truncated(err)
+	}
+	if binary.NativeEndian.Uint32(trailer) != sum {
+		return fmt.Errorf("%w: chunk snapshot checksum mismatch", ErrCorrupt)
+	}
+
+	var relinkErr error
+	_, err = sl.bulkAppend(func() (K, V, bool) {
+		var key K
+		var value V
+		if len(order) == 0 {
+			return key, value, false
+		}
+		i := order[0]
+		if i >= l.count {
+			relinkErr = fmt.Errorf("%w: chunk snapshot relinks entry %d of %d", ErrCorrupt, i, l.count)
+			return key, value, false
+		}
+		order = order[1:]
+		return keys[i], values[i], true
+	})
+	if relinkErr != nil {
+		return relinkErr
+	}
+	if errors.Is(err, ErrUnsorted) {
+		return fmt.Errorf("%w: chunk snapshot relinks entries out of order", ErrCorrupt)
+	}
+	return err
+}
+
+// readChunkRegion reads count values of T with read.
+func readChunkRegion[T any](read func([]byte) error, count uint64) ([]T, error) {
+	var out []T
+	for uint64(len(out)) < count {
+		n := int(min(count-uint64(len(out)), chunkSection))
+		out = slices.Grow(out, n)[:len(out)+n]
+		if err := read(chunkBytes(out[len(out)-n:])); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// plainData reports whether values of type T hold no pointers, so that their
+// memory can be copied to a snapshot and back.
+func plainData[T any]() bool {
+	return !holdsPointers(reflect.TypeFor[T]())
+}
+
+func holdsPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return t.Len() > 0 && holdsPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if holdsPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	default:
+		// Pointers, uintptrs, strings, slices, maps, channels, functions
+		// and interfaces.
+		return true
+	}
+}
@@ -0,0 +1,98 @@
+package skiplist
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+type chunkPoint struct {
+	X, Y float64
+	Tag  [3]byte
+}
+
+func TestSaveChunks(t *testing.T) {
+	for _, opts := range map[string][]Option[int64, chunkPoint]{
+		"Arena":          {WithArena[int64, chunkPoint](1 << 10)},
+		"ArenaAlignment": {WithArena[int64, chunkPoint](1 << 10), WithArenaAlignment[int64, chunkPoint](64)},
+	} {
+		sl := New[int64, chunkPoint](opts...)
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 20000; i++ {
+			k := r.Int63n(50000)
+			sl.Insert(k, chunkPoint{X: float64(k), Y: -float64(i), Tag: [3]byte{byte(k), 1, 2}})
+		}
+		// Deleted entries leave dead blocks in the chunks.
+		sl.DeleteRange(1000, 2000)
+		for k := int64(0); k < 50000; k += 7 {
+			sl.Delete(k)
+		}
+
+		var buf bytes.Buffer
+		if err := sl.SaveChunks(&buf); err != nil {
+			t.Fatal(err)
+		}
+		restored := New[int64, chunkPoint]()
+		if err := restored.Load(bytes.NewReader(buf.Bytes())); err != nil {
+			t.Fatal(err)
+		}
+		if restored.Len() != sl.Len() {
+			t.Fatalf("restored %d entries, want %d", restored.Len(), sl.Len())
+		}
+		it, rit := sl.NewIterator(), restored.NewIterator()
+		for it.Next() {
+			if !rit.Next() || rit.Key() != it.Key() || rit.Value() != it.Value() {
+				t.Fatalf("restored entry %v = %v, want %v = %v", rit.Key(), rit.Value(), it.Key(), it.Value())
+			}
+		}
+
+		// Damaged and mismatched snapshots are rejected, leaving the list empty.
+		data := bytes.Clone(buf.Bytes())
+		data[len(data)/2] ^= 0xff
+		if err := restored.Load(bytes.NewReader(data)); !errors.Is(err, ErrCorrupt) || !restored.IsEmpty() {
+			t.Errorf("Load of a damaged snapshot = %v, Len() = %d", err, restored.Len())
+		}
+		other := New[int64, int64]()
+		if err := other.Load(bytes.NewReader(buf.Bytes())); !errors.Is(err, ErrChunkSnapshot) {
+			t.Errorf("Load with another value type = %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	empty := New[int, int](WithArena[int, int](1 << 10))
+	if err := empty.SaveChunks(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := New[int, int]().Load(&buf); err != nil {
+		t.Errorf("Load of an empty chunk snapshot = %v", err)
+	}
+	if err := New[int, int]().SaveChunks(&buf); !errors.Is(err, ErrChunkSnapshot) {
+		t.Errorf("SaveChunks without an arena = %v", err)
+	}
+	if err := New[string, int](WithArena[string, int](1 << 10)).SaveChunks(&buf); !errors.Is(err, ErrChunkSnapshot) {
+		t.Errorf("SaveChunks with string keys = %v", err)
+	}
+}
+
+func BenchmarkSaveChunks(b *testing.B) {
+	sl := New[int, int](WithArena[int, int](1 << 20))
+	for i := 0; i < 1_000_000; i++ {
+		sl.Insert(rand.Int(), i)
+	}
+	for name, save := range map[string]func(*bytes.Buffer) error{
+		"Save":       func(w *bytes.Buffer) error { return sl.Save(w) },
+		"SaveChunks": func(w *bytes.Buffer) error { return sl.SaveChunks(w) },
+	} {
+		b.Run(name, func(b *testing.B) {
+			var buf bytes.Buffer
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				if err := save(&buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.SetBytes(int64(buf.Len()))
+		})
+	}
+}
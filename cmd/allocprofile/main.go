@@ -0,0 +1,173 @@
+// Command allocprofile compares the memory use of the pool and arena
+// allocators over time. It runs the same churn workload (random inserts and
+// deletes over a fixed key space, from the same seed and at the same rate)
+// under each allocator in turn, samples the RSS and Go heap of the process
+// every -interval, and writes the samples as a time-series CSV:
+//
+//	allocator,elapsed_s,ops,len,rss_bytes,heap_alloc_bytes,heap_inuse_bytes,heap_sys_bytes,num_gc,gc_pause_ns
+//
+// Usage:
+//
+//	allocprofile [-duration 30s] [-interval 1s] [-keys 1000000] [-rate 200000] [-arena 1048576] [-allocators pool,arena] [-out profile.csv]
+//
+// Each allocator runs in a child process of its own, so that the memory
+// retained by one run does not show in the RSS of the next. rss_bytes is
+// empty on platforms without /proc/self/statm. ops lags behind the target
+// rate when the list cannot keep up with it.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/INLOpen/skiplist"
+)
+
+// settings are the flags passed on to the child processes.
+type settings struct {
+	duration time.Duration
+	interval time.Duration
+	keys     int
+	rate     int
+	arena    int
+	seed     uint64
+}
+
+func main() {
+	var s settings
+	flag.DurationVar(&s.duration, "duration", 30*time.Second, "how long each allocator runs")
+	flag.DurationVar(&s.interval, "interval", time.Second, "delay between two samples")
+	flag.IntVar(&s.keys, "keys", 1_000_000, "size of the key space; the list holds about half of it")
+	flag.IntVar(&s.rate, "rate", 200_000, "target operations per second")
+	flag.IntVar(&s.arena, "arena", 1<<20, "initial arena size in bytes")
+	flag.Uint64Var(&s.seed, "seed", 1, "seed of the workload")
+	allocators := flag.String("allocators", "pool,arena", "comma-separated allocators to compare: pool and/or arena")
+	out := flag.String("out", "-", "output CSV file (- for stdout)")
+	child := flag.String("child", "", "internal: run the workload under one allocator")
+	flag.Parse()
+
+	if s.duration <= 0 || s.interval <= 0 || s.keys <= 0 || s.rate <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *child != "" {
+		if err := run(*child, s, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	w := os.Stdout
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	self, err := os.Executable()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintln(w, "allocator,elapsed_s,ops,len,rss_bytes,heap_alloc_bytes,heap_inuse_bytes,heap_sys_bytes,num_gc,gc_pause_ns")
+	for _, alloc := range strings.Split(*allocators, ",") {
+		fmt.Fprintf(os.Stderr, "profiling %s allocator for %v...\n", alloc, s.duration)
+		cmd := exec.Command(self,
+			"-child", alloc,
+			"-duration", s.duration.String(),
+			"-interval", s.interval.String(),
+			"-keys", strconv.Itoa(s.keys),
+			"-rate", strconv.Itoa(s.rate),
+			"-arena", strconv.Itoa(s.arena),
+			"-seed", strconv.FormatUint(s.seed, 10),
+		)
+		cmd.Stdout, cmd.Stderr = w, os.Stderr
+		if err := cmd.Run(); err != nil {
+			log.Fatalf("%s: %v", alloc, err)
+		}
+	}
+}
+
+// run runs the churn workload under the allocator named alloc and writes
+// one CSV row per sample to w.
+func run(alloc string, s settings, w io.Writer) error {
+	var sl *skiplist.SkipList[int, int]
+	switch alloc {
+	case "pool":
+		sl = skiplist.New[int, int]()
+	case "arena":
+		sl = skiplist.New[int, int](skiplist.WithArena[int, int](s.arena))
+	default:
+		return fmt.Errorf("unknown allocator %q (want pool or arena)", alloc)
+	}
+
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+	r := rand.New(rand.NewPCG(s.seed, s.seed))
+	// The operations are issued in batches every tick to hold the target
+	// rate, so that both allocators see the same sequence at the same pace.
+	const tick = 10 * time.Millisecond
+	batch := max(1, s.rate*int(tick)/int(time.Second))
+	ops := 0
+	start := time.Now()
+	nextSample := s.interval
+	for elapsed := time.Duration(0); elapsed < s.duration; elapsed = time.Since(start) {
+		for target := int(elapsed / tick * tick * time.Duration(s.rate) / time.Second); ops < target+batch; ops++ {
+			k := r.IntN(s.keys)
+			if r.IntN(2) == 0 {
+				sl.Insert(k, ops)
+			} else {
+				sl.Delete(k)
+			}
+		}
+		if elapsed >= nextSample {
+			sample(bw, alloc, elapsed, ops, sl.Len())
+			nextSample += s.interval
+		}
+		time.Sleep(tick - time.Since(start)%tick)
+	}
+	sample(bw, alloc, time.Since(start), ops, sl.Len())
+	runtime.KeepAlive(sl)
+	return nil
+}
+
+// sample writes one CSV row with the memory use of the process.
+func sample(w io.Writer, alloc string, elapsed time.Duration, ops, length int) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	rss := ""
+	if n, ok := residentBytes(); ok {
+		rss = strconv.FormatUint(n, 10)
+	}
+	fmt.Fprintf(w, "%s,%.1f,%d,%d,%s,%d,%d,%d,%d,%d\n", alloc, elapsed.Seconds(), ops, length, rss,
+		ms.HeapAlloc, ms.HeapInuse, ms.HeapSys, ms.NumGC, ms.PauseTotalNs)
+}
+
+// residentBytes returns the resident set size of the process, read from
+// /proc/self/statm.
+func residentBytes() (uint64, bool) {
+	b, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0, false
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) < 2 {
+		return 0, false
+	}
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return pages * uint64(os.Getpagesize()), true
+}
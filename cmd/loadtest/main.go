@@ -0,0 +1,205 @@
+// Command loadtest drives a SkipList[int, int] with concurrent random
+// traffic (Search, Insert, Delete and short RangeQuery scans) and reports
+// the throughput of each operation.
+//
+// Usage:
+//
+//	loadtest [-duration 10s] [-workers 8] [-keys 100000] [-reads 0.8] [-arena 0] [-chaos] [-chaos-interval 5ms] [-validate-every 1s]
+//
+// With -chaos, a soak mode runs alongside the traffic: one goroutine keeps
+// interleaving lifecycle operations at random (Clear, CompactLevels, a
+// SnapshotIterator walked to its end, and Rotate followed by a walk and a
+// Release of the frozen list), and another pauses the writers every
+// -validate-every to run Validate. It surfaces races between the lifecycle
+// of the list and normal traffic that unit tests do not reach. The command
+// exits with status 1 as soon as an invariant is broken: Validate fails, or
+// a snapshot or frozen list yields keys out of order.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/INLOpen/skiplist"
+)
+
+// Operations counted by the load tester.
+const (
+	opSearch = iota
+	opInsert
+	opDelete
+	opRange
+	opClear
+	opCompact
+	opSnapshot
+	opRotate
+	opValidate
+	numOps
+)
+
+var opNames = [numOps]string{"search", "insert", "delete", "range", "clear", "compact", "snapshot", "rotate", "validate"}
+
+func main() {
+	duration := flag.Duration("duration", 10*time.Second, "how long to run")
+	workers := flag.Int("workers", runtime.GOMAXPROCS(0), "number of traffic goroutines")
+	keys := flag.Int("keys", 100000, "size of the key space")
+	reads := flag.Float64("reads", 0.8, "fraction of the traffic that only reads")
+	arena := flag.Int("arena", 0, "initial arena size in bytes (0 for the pool allocator)")
+	chaos := flag.Bool("chaos", false, "interleave Clear, CompactLevels, snapshots and Rotate with the traffic and validate periodically")
+	chaosInterval := flag.Duration("chaos-interval", 5*time.Millisecond, "mean delay between two lifecycle operations in chaos mode")
+	validateEvery := flag.Duration("validate-every", time.Second, "delay between two Validate runs in chaos mode")
+	flag.Parse()
+
+	if *workers <= 0 || *keys <= 0 || *reads < 0 || *reads > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var opts []skiplist.Option[int, int]
+	if *arena > 0 {
+		opts = append(opts, skiplist.WithArena[int, int](*arena))
+	}
+	sl := skiplist.New[int, int](opts...)
+
+	var counts [numOps]atomic.Int64
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for w := 0; w < *workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			traffic(sl, *keys, *reads, &counts, stop)
+		}()
+	}
+	if *chaos {
+		// The writers are paused while Validate holds the read lock, so a
+		// violation cannot be hidden by a concurrent repair.
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			lifecycle(sl, *chaosInterval, &counts, stop)
+		}()
+		go func() {
+			defer wg.Done()
+			validate(sl, *validateEvery, &counts, stop)
+		}()
+	}
+
+	fmt.Printf("Running for %v with %d workers over %d keys (chaos: %v)\n", *duration, *workers, *keys, *chaos)
+	start := time.Now()
+	time.Sleep(*duration)
+	close(stop)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	if err := sl.Validate(); err != nil {
+		log.Fatalf("final validate: %v", err)
+	}
+	for op, name := range opNames {
+		if n := counts[op].Load(); n > 0 {
+			fmt.Printf("%-9s %12d ops %14.0f ops/s\n", name, n, float64(n)/elapsed.Seconds())
+		}
+	}
+	fmt.Printf("final length: %d\n", sl.Len())
+}
+
+// traffic runs random reads and writes until stop is closed.
+func traffic(sl *skiplist.SkipList[int, int], keys int, reads float64, counts *[numOps]atomic.Int64, stop <-chan struct{}) {
+	r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	for i := 0; ; i++ {
+		if i%256 == 0 {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+		}
+		k := r.IntN(keys)
+		switch p := r.Float64(); {
+		case p < reads*0.9:
+			sl.Search(k)
+			counts[opSearch].Add(1)
+		case p < reads:
+			sl.RangeQuery(k, k+100, func(int, int) bool { return true })
+			counts[opRange].Add(1)
+		case r.IntN(4) == 0:
+			sl.Delete(k)
+			counts[opDelete].Add(1)
+		default:
+			sl.Insert(k, i)
+			counts[opInsert].Add(1)
+		}
+	}
+}
+
+// lifecycle runs a random lifecycle operation about every interval until
+// stop is closed.
+func lifecycle(sl *skiplist.SkipList[int, int], interval time.Duration, counts *[numOps]atomic.Int64, stop <-chan struct{}) {
+	r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	for {
+		select {
+		case <-stop:
+			return
+		case <-time.After(time.Duration(r.Int64N(int64(2*interval) + 1))):
+		}
+		switch r.IntN(4) {
+		case 0:
+			sl.Clear()
+			counts[opClear].Add(1)
+		case 1:
+			sl.CompactLevels()
+			counts[opCompact].Add(1)
+		case 2:
+			it := sl.SnapshotIterator()
+			prev, first := 0, true
+			for it.Next() {
+				if !first && it.Key() <= prev {
+					log.Fatalf("snapshot yielded %d after %d", it.Key(), prev)
+				}
+				prev, first = it.Key(), false
+			}
+			it.Release()
+			counts[opSnapshot].Add(1)
+		case 3:
+			frozen := sl.Rotate()
+			prev, n := 0, 0
+			frozen.Range(func(k, _ int) bool {
+				if n > 0 && k <= prev {
+					log.Fatalf("frozen list yielded %d after %d", k, prev)
+				}
+				prev = k
+				n++
+				return true
+			})
+			if n != frozen.Len() {
+				log.Fatalf("frozen list yielded %d entries, Len() = %d", n, frozen.Len())
+			}
+			frozen.Release()
+			counts[opRotate].Add(1)
+		}
+	}
+}
+
+// validate runs Validate every interval until stop is closed.
+func validate(sl *skiplist.SkipList[int, int], interval time.Duration, counts *[numOps]atomic.Int64, stop <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+		if err := sl.Validate(); err != nil {
+			log.Fatalf("validate: %v", err)
+		}
+		counts[opValidate].Add(1)
+	}
+}
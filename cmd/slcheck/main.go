@@ -0,0 +1,191 @@
+// Command slcheck verifies a persisted skiplist before it is deployed: it
+// loads a snapshot (see SkipList.Save and SkipList.SaveChunks), applies the
+// deltas written by SkipList.SaveDelta in the order given, runs Validate and
+// CheckSpans on the result, and prints its Stats with a histogram of the
+// nodes per level.
+//
+// Usage:
+//
+//	slcheck [-codec gob|msgpack|protobuf] [-key string|int64|uint64|float64] [-value string|bytes|int64|float64] [-json] snapshot [delta ...]
+//
+// -key and -value must name the types the snapshot was written with; the
+// defaults match the snapshots of slconvert. The exit status is 0 if the
+// files are sound, 1 if a file is damaged or the loaded list is invalid,
+// and 2 on usage and I/O errors.
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/INLOpen/skiplist"
+)
+
+// config holds the command-line settings shared by every key and value type.
+type config struct {
+	codec  string
+	json   bool
+	files  []string
+	output io.Writer
+}
+
+// corruptError marks the failures reported with exit status 1.
+type corruptError struct{ err error }
+
+func (e corruptError) Error() string { return e.err.Error() }
+func (e corruptError) Unwrap() error { return e.err }
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.codec, "codec", "gob", "snapshot codec: gob, msgpack or protobuf")
+	keyType := flag.String("key", "string", "key type: string, int64, uint64 or float64")
+	valueType := flag.String("value", "string", "value type: string, bytes, int64 or float64")
+	flag.BoolVar(&cfg.json, "json", false, "print the report as JSON")
+	flag.Parse()
+
+	cfg.files = flag.Args()
+	cfg.output = os.Stdout
+	if len(cfg.files) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch *keyType {
+	case "string":
+		err = checkKey[string](cfg, *valueType)
+	case "int64":
+		err = checkKey[int64](cfg, *valueType)
+	case "uint64":
+		err = checkKey[uint64](cfg, *valueType)
+	case "float64":
+		err = checkKey[float64](cfg, *valueType)
+	default:
+		err = fmt.Errorf("unknown key type %q (want string, int64, uint64 or float64)", *keyType)
+	}
+	if err == nil {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "slcheck:", err)
+	var ce corruptError
+	if errors.As(err, &ce) {
+		os.Exit(1)
+	}
+	os.Exit(2)
+}
+
+// checkKey dispatches on the value type.
+func checkKey[K cmp.Ordered](cfg config, valueType string) error {
+	switch valueType {
+	case "string":
+		return check[K, string](cfg)
+	case "bytes":
+		return check[K, []byte](cfg)
+	case "int64":
+		return check[K, int64](cfg)
+	case "float64":
+		return check[K, float64](cfg)
+	}
+	return fmt.Errorf("unknown value type %q (want string, bytes, int64 or float64)", valueType)
+}
+
+// report is the output of slcheck.
+type report struct {
+	Files  []string       `json:"files"`
+	Stats  skiplist.Stats `json:"stats"`
+	Status string         `json:"status"`
+}
+
+// check loads the files into a SkipList[K, V], verifies it and prints the
+// report.
+func check[K cmp.Ordered, V any](cfg config) error {
+	var codec skiplist.Codec[K, V]
+	switch cfg.codec {
+	case "gob":
+		codec = skiplist.GobCodec[K, V]{}
+	case "msgpack":
+		codec = skiplist.MsgpackCodec[K, V]{}
+	case "protobuf":
+		codec = skiplist.ProtobufCodec[K, V]{}
+	default:
+		return fmt.Errorf("unknown codec %q (want gob, msgpack or protobuf)", cfg.codec)
+	}
+
+	sl := skiplist.New[K, V](skiplist.WithCodec(codec))
+	for i, name := range cfg.files {
+		load := sl.Load
+		if i > 0 {
+			load = sl.ApplyDelta
+		}
+		if err := loadFile(name, load); err != nil {
+			return err
+		}
+	}
+
+	rep := report{Files: cfg.files, Stats: sl.Stats(), Status: "ok"}
+	var invalid error
+	if err := sl.Validate(); err != nil {
+		invalid = corruptError{fmt.Errorf("validate: %w", err)}
+	} else if err := sl.CheckSpans(); err != nil {
+		invalid = corruptError{fmt.Errorf("check spans: %w", err)}
+	}
+	if invalid != nil {
+		rep.Status = invalid.Error()
+	}
+	if cfg.json {
+		enc := json.NewEncoder(cfg.output)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rep); err != nil {
+			return err
+		}
+	} else {
+		printReport(cfg.output, rep)
+	}
+	return invalid
+}
+
+// loadFile opens name and passes it to load, marking the errors caused by a
+// damaged file as corruption.
+func loadFile(name string, load func(io.Reader) error) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	err = load(bufio.NewReader(f))
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, skiplist.ErrCorrupt), errors.Is(err, skiplist.ErrUnsorted), errors.Is(err, io.ErrUnexpectedEOF):
+		return corruptError{fmt.Errorf("%s: %w", name, err)}
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
+
+// printReport writes rep as text, with one histogram bar per level.
+func printReport(w io.Writer, rep report) {
+	st := rep.Stats
+	fmt.Fprintf(w, "files:     %s\n", strings.Join(rep.Files, ", "))
+	fmt.Fprintf(w, "entries:   %d\n", st.Len)
+	fmt.Fprintf(w, "levels:    %d\n", st.Levels)
+	fmt.Fprintf(w, "version:   %d\n", st.Version)
+	fmt.Fprintf(w, "allocator: %s\n", st.Allocator)
+	fmt.Fprintln(w, "nodes per level:")
+	const width = 50
+	for i := len(st.LevelCounts) - 1; i >= 0; i-- {
+		n := st.LevelCounts[i]
+		bar := 0
+		if st.Len > 0 {
+			bar = (n*width + st.Len - 1) / st.Len
+		}
+		fmt.Fprintf(w, "  %2d %10d %s\n", i, n, strings.Repeat("#", bar))
+	}
+	fmt.Fprintf(w, "status:    %s\n", rep.Status)
+}
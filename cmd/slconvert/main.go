@@ -0,0 +1,218 @@
+// Command slconvert imports sorted CSV or NDJSON data into a serialized
+// skiplist snapshot (see SkipList.Save) using bulk load.
+//
+// Usage:
+//
+//	slconvert -in data.csv -out data.snap [-format csv|ndjson] [-codec gob|msgpack|protobuf] [-key 0] [-value 1] [-header]
+//
+// Keys and values are stored as strings. For CSV, -key and -value are column
+// indexes; for NDJSON they are field names. The input must be sorted by key in
+// strictly ascending order unless -sort is given.
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/INLOpen/skiplist"
+)
+
+type record struct {
+	key, value string
+}
+
+func main() {
+	in := flag.String("in", "-", "input file (- for stdin)")
+	out := flag.String("out", "", "output snapshot file (required)")
+	format := flag.String("format", "csv", "input format: csv or ndjson")
+	codecName := flag.String("codec", "gob", "snapshot codec: gob, msgpack or protobuf")
+	keyField := flag.String("key", "0", "key column index (csv) or field name (ndjson)")
+	valueField := flag.String("value", "1", "value column index (csv) or field name (ndjson)")
+	header := flag.Bool("header", false, "skip the first CSV record")
+	sortInput := flag.Bool("sort", false, "sort the input in memory instead of requiring sorted input")
+	flag.Parse()
+
+	if *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var codec skiplist.Codec[string, string]
+	switch *codecName {
+	case "gob":
+		codec = skiplist.GobCodec[string, string]{}
+	case "msgpack":
+		codec = skiplist.MsgpackCodec[string, string]{}
+	case "protobuf":
+		codec = skiplist.ProtobufCodec[string, string]{}
+	default:
+		log.Fatalf("unknown codec %q (want gob, msgpack or protobuf)", *codecName)
+	}
+
+	r := os.Stdin
+	if *in != "-" {
+		f, err := os.Open(*in)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var next func() (record, error)
+	switch *format {
+	case "csv":
+		var err error
+		next, err = csvReader(r, *keyField, *valueField, *header)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "ndjson":
+		next = ndjsonReader(r, *keyField, *valueField)
+	default:
+		log.Fatalf("unknown format %q (want csv or ndjson)", *format)
+	}
+
+	if *sortInput {
+		next = sorted(next)
+	}
+
+	sl := skiplist.New[string, string](skiplist.WithCodec(codec))
+	var readErr error
+	n, err := sl.BulkLoad(func() (string, string, bool) {
+		rec, err := next()
+		if err != nil {
+			if err != io.EOF {
+				readErr = err
+			}
+			return "", "", false
+		}
+		return rec.key, rec.value, true
+	})
+	if readErr != nil {
+		log.Fatal(readErr)
+	}
+	if errors.Is(err, skiplist.ErrUnsorted) {
+		log.Fatalf("input is not sorted by key after %d records (use -sort)", n)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := bufio.NewWriter(f)
+	if err := sl.Save(w); err != nil {
+		log.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("wrote %d entries to %s\n", n, *out)
+}
+
+// csvReader returns a function yielding one record per CSV row.
+func csvReader(r io.Reader, keyField, valueField string, header bool) (func() (record, error), error) {
+	keyCol, err := strconv.Atoi(keyField)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key column %q: %w", keyField, err)
+	}
+	valueCol, err := strconv.Atoi(valueField)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value column %q: %w", valueField, err)
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	if header {
+		if _, err := cr.Read(); err != nil {
+			return nil, err
+		}
+	}
+	return func() (record, error) {
+		row, err := cr.Read()
+		if err != nil {
+			return record{}, err
+		}
+		if keyCol >= len(row) || valueCol >= len(row) {
+			line, _ := cr.FieldPos(0)
+			return record{}, fmt.Errorf("line %d: expected at least %d columns, got %d", line, max(keyCol, valueCol)+1, len(row))
+		}
+		return record{row[keyCol], row[valueCol]}, nil
+	}, nil
+}
+
+// ndjsonReader returns a function yielding one record per JSON object.
+// Non-string field values are kept as their JSON encoding.
+func ndjsonReader(r io.Reader, keyField, valueField string) func() (record, error) {
+	dec := json.NewDecoder(r)
+	line := 0
+	return func() (record, error) {
+		var obj map[string]json.RawMessage
+		if err := dec.Decode(&obj); err != nil {
+			return record{}, err
+		}
+		line++
+		key, ok := obj[keyField]
+		if !ok {
+			return record{}, fmt.Errorf("record %d: missing key field %q", line, keyField)
+		}
+		return record{jsonString(key), jsonString(obj[valueField])}, nil
+	}
+}
+
+func jsonString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// sorted reads all records from next and returns them in key order. For
+// duplicate keys the last record wins.
+func sorted(next func() (record, error)) func() (record, error) {
+	var recs []record
+	var err error
+	for {
+		var rec record
+		if rec, err = next(); err != nil {
+			break
+		}
+		recs = append(recs, rec)
+	}
+	if err != io.EOF {
+		return func() (record, error) { return record{}, err }
+	}
+	sort.SliceStable(recs, func(i, j int) bool { return recs[i].key < recs[j].key })
+	dedup := recs[:0]
+	for _, rec := range recs {
+		if len(dedup) > 0 && dedup[len(dedup)-1].key == rec.key {
+			dedup[len(dedup)-1] = rec
+			continue
+		}
+		dedup = append(dedup, rec)
+	}
+	i := 0
+	return func() (record, error) {
+		if i == len(dedup) {
+			return record{}, io.EOF
+		}
+		i++
+		return dedup[i-1], nil
+	}
+}
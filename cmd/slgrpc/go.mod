@@ -0,0 +1,22 @@
+module github.com/INLOpen/skiplist/cmd/slgrpc
+
+// The root module supports Go 1.22, but this separate module needs the
+// go 1.25.0 that its dependencies google.golang.org/grpc v1.84.0 and
+// golang.org/x/net v0.57.0 declare; it is kept out of the root module so
+// that the library does not inherit that requirement.
+go 1.25.0
+
+replace github.com/INLOpen/skiplist => ../..
+
+require (
+	github.com/INLOpen/skiplist v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.12
+)
+
+require (
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800 // indirect
+)
@@ -0,0 +1,97 @@
+// Command slgrpc serves a skiplist as a lightweight ordered key-value store
+// over gRPC. The service is defined in skiplistpb/skiplist.proto.
+//
+// Usage:
+//
+//	slgrpc [-addr localhost:7070] [-snapshot data.snap]
+//
+// With -snapshot, the list is loaded from the file at startup (if it exists)
+// and saved back to it on SIGINT or SIGTERM.
+//
+// slgrpc lives in its own module so that the skiplist package itself stays free
+// of dependencies.
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/INLOpen/skiplist"
+	"github.com/INLOpen/skiplist/cmd/slgrpc/skiplistpb"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:7070", "address to listen on")
+	snapshot := flag.String("snapshot", "", "snapshot file to load at startup and save at shutdown")
+	flag.Parse()
+
+	sl := skiplist.New[string, []byte]()
+	if *snapshot != "" {
+		if err := load(sl, *snapshot); err != nil {
+			log.Fatalf("loading %s: %v", *snapshot, err)
+		}
+		log.Printf("loaded %d entries from %s", sl.Len(), *snapshot)
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	skiplistpb.RegisterSkipListServer(srv, newServer(sl))
+
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+		srv.GracefulStop()
+	}()
+
+	log.Printf("serving on %s", lis.Addr())
+	if err := srv.Serve(lis); err != nil {
+		log.Fatal(err)
+	}
+
+	if *snapshot != "" {
+		if err := save(sl, *snapshot); err != nil {
+			log.Fatalf("saving %s: %v", *snapshot, err)
+		}
+		log.Printf("saved %d entries to %s", sl.Len(), *snapshot)
+	}
+}
+
+func load(sl *skiplist.SkipList[string, []byte], path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return sl.Load(f)
+}
+
+// save writes the snapshot to a temporary file first so that a failed save
+// does not destroy the previous snapshot.
+func save(sl *skiplist.SkipList[string, []byte], path string) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := sl.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+
+	"github.com/INLOpen/skiplist"
+	"github.com/INLOpen/skiplist/cmd/slgrpc/skiplistpb"
+	"google.golang.org/grpc"
+)
+
+// rangeBatch is the number of entries Range collects under one read lock.
+// The lock is released while a batch is being sent, so that a slow client
+// does not stall writers.
+const rangeBatch = 256
+
+// server implements skiplistpb.SkipListServer. Keys are stored as strings,
+// whose ordering is bytewise.
+type server struct {
+	skiplistpb.UnimplementedSkipListServer
+	sl *skiplist.SkipList[string, []byte]
+}
+
+func newServer(sl *skiplist.SkipList[string, []byte]) *server {
+	return &server{sl: sl}
+}
+
+func (s *server) Insert(_ context.Context, req *skiplistpb.InsertRequest) (*skiplistpb.InsertResponse, error) {
+	old := s.sl.Insert(string(req.GetKey()), req.GetValue())
+	return &skiplistpb.InsertResponse{Replaced: old != nil}, nil
+}
+
+func (s *server) Get(_ context.Context, req *skiplistpb.GetRequest) (*skiplistpb.GetResponse, error) {
+	n, ok := s.sl.Search(string(req.GetKey()))
+	if !ok {
+		return &skiplistpb.GetResponse{}, nil
+	}
+	return &skiplistpb.GetResponse{Found: true, Value: n.Value()}, nil
+}
+
+func (s *server) Delete(_ context.Context, req *skiplistpb.DeleteRequest) (*skiplistpb.DeleteResponse, error) {
+	return &skiplistpb.DeleteResponse{Deleted: s.sl.Delete(string(req.GetKey()))}, nil
+}
+
+func (s *server) Rank(_ context.Context, req *skiplistpb.RankRequest) (*skiplistpb.RankResponse, error) {
+	key := string(req.GetKey())
+	_, found := s.sl.Search(key)
+	return &skiplistpb.RankResponse{Found: found, Rank: int64(s.sl.Rank(key))}, nil
+}
+
+func (s *server) Range(req *skiplistpb.RangeRequest, stream grpc.ServerStreamingServer[skiplistpb.Entry]) error {
+	start, end := string(req.GetStart()), string(req.GetEnd())
+	limit := int(req.GetLimit())
+	sent := 0
+	batch := make([]*skiplistpb.Entry, 0, rangeBatch)
+	for {
+		batchEnd := end
+		if batchEnd == "" {
+			// No upper bound: stop at the largest key present right now.
+			last, ok := s.sl.Max()
+			if !ok {
+				return nil
+			}
+			batchEnd = last.Key()
+		}
+		batch = batch[:0]
+		s.sl.RangeQuery(start, batchEnd, func(k string, v []byte) bool {
+			batch = append(batch, &skiplistpb.Entry{Key: []byte(k), Value: v})
+			return len(batch) < rangeBatch && (limit == 0 || sent+len(batch) < limit)
+		})
+
+		for _, e := range batch {
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		}
+		sent += len(batch)
+		if len(batch) < rangeBatch || (limit != 0 && sent >= limit) {
+			return nil
+		}
+		// Resume just after the last key sent.
+		start = string(batch[len(batch)-1].Key) + "\x00"
+	}
+}
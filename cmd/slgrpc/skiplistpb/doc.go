@@ -0,0 +1,5 @@
+// Package skiplistpb contains the protobuf and gRPC definitions of the slgrpc
+// ordered key-value service.
+package skiplistpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative skiplist.proto
@@ -0,0 +1,114 @@
+package skiplist
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+// Codec defines the wire format used by Save and Load. A snapshot is a header
+// carrying the number of entries followed by the entries in ascending key order.
+// Configure one with WithCodec; the default is GobCodec.
+//
+// Codec กำหนดรูปแบบข้อมูลที่ใช้โดย Save และ Load
+type Codec[K any, V any] interface {
+	NewEncoder(w io.Writer) EntryEncoder[K, V]
+	NewDecoder(r io.Reader) EntryDecoder[K, V]
+}
+
+// EntryEncoder writes one snapshot. WriteHeader is called once, then
+// WriteEntry for every entry, then Close. Close must not close the underlying
+// writer.
+type EntryEncoder[K any, V any] interface {
+	WriteHeader(count int) error
+	WriteEntry(key K, value V) error
+	Close() error
+}
+
+// EntryDecoder reads one snapshot. ReadHeader returns the number of entries,
+// or -1 if the format does not record it; in that case ReadEntry returns io.EOF
+// after the last entry.
+type EntryDecoder[K any, V any] interface {
+	ReadHeader() (count int, err error)
+	ReadEntry() (key K, value V, err error)
+}
+
+// WithCodec sets the codec used by Save and Load.
+// WithCodec กำหนด codec ที่ใช้โดย Save และ Load
+func WithCodec[K any, V any](c Codec[K, V]) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		if c == nil {
+			panic("skiplist: codec cannot be nil")
+		}
+		sl.codec = c
+	}
+}
+
+// snapshotCodec returns the configured codec or the default GobCodec.
+func (sl *SkipList[K, V]) snapshotCodec() Codec[K, V] {
+	if sl.codec != nil {
+		return sl.codec
+	}
+	return GobCodec[K, V]{}
+}
+
+// GobCodec encodes snapshots with encoding/gob. It is the default codec and
+// only interoperates with Go programs.
+// GobCodec เข้ารหัส snapshot ด้วย encoding/gob (ค่าเริ่มต้น)
+type GobCodec[K any, V any] struct{}
+
+// snapshotMagic identifies the stream written by GobCodec.
+const snapshotMagic = "INLOpen/skiplist snapshot v1"
+
+// snapshotHeader is the first value of a gob snapshot stream.
+type snapshotHeader struct {
+	Magic string
+	Count int
+}
+
+// snapshotEntry is one key-value pair of a gob snapshot stream.
+type snapshotEntry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+type gobEntryEncoder[K any, V any] struct{ enc *gob.Encoder }
+
+type gobEntryDecoder[K any, V any] struct{ dec *gob.Decoder }
+
+// NewEncoder implements Codec.
+func (GobCodec[K, V]) NewEncoder(w io.Writer) EntryEncoder[K, V] {
+	return gobEntryEncoder[K, V]{gob.NewEncoder(w)}
+}
+
+// NewDecoder implements Codec.
+func (GobCodec[K, V]) NewDecoder(r io.Reader) EntryDecoder[K, V] {
+	return gobEntryDecoder[K, V]{gob.NewDecoder(r)}
+}
+
+func (e gobEntryEncoder[K, V]) WriteHeader(count int) error {
+	return e.enc.Encode(snapshotHeader{Magic: snapshotMagic, Count: count})
+}
+
+func (e gobEntryEncoder[K, V]) WriteEntry(key K, value V) error {
+	return e.enc.Encode(snapshotEntry[K, V]{Key: key, Value: value})
+}
+
+func (e gobEntryEncoder[K, V]) Close() error { return nil }
+
+func (d gobEntryDecoder[K, V]) ReadHeader() (int, error) {
+	var hdr snapshotHeader
+	if err := d.dec.Decode(&hdr); err != nil {
+		return 0, err
+	}
+	if hdr.Magic != snapshotMagic {
+		return 0, fmt.Errorf("skiplist: not a snapshot (magic %q)", hdr.Magic)
+	}
+	return hdr.Count, nil
+}
+
+func (d gobEntryDecoder[K, V]) ReadEntry() (K, V, error) {
+	var e snapshotEntry[K, V]
+	err := d.dec.Decode(&e)
+	return e.Key, e.Value, err
+}
@@ -0,0 +1,142 @@
+package skiplist
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/hex"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type codecRecord struct {
+	Name    string
+	Tags    []string
+	Scores  map[string]int
+	Ratio   float64
+	Raw     []byte
+	Skipped int `msgpack:"-"`
+	Next    *codecRecord
+}
+
+// testCodecRoundTrip saves src with codec and loads the snapshot into a new list.
+func testCodecRoundTrip[K any, V any](t *testing.T, codec Codec[K, V], src *SkipList[K, V], cmp Comparator[K]) *SkipList[K, V] {
+	t.Helper()
+	src.codec = codec
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	dst := NewWithComparator[K, V](cmp, WithCodec(codec))
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if dst.Len() != src.Len() {
+		t.Fatalf("Len() after Load = %d, want %d", dst.Len(), src.Len())
+	}
+	return dst
+}
+
+func TestCodecs(t *testing.T) {
+	intCodecs := map[string]Codec[int, string]{
+		"Gob":      GobCodec[int, string]{},
+		"Msgpack":  MsgpackCodec[int, string]{},
+		"Protobuf": ProtobufCodec[int, string]{},
+	}
+	for name, codec := range intCodecs {
+		t.Run(name, func(t *testing.T) {
+			src := New[int, string]()
+			for i := -300; i < 300; i += 7 {
+				src.Insert(i*1000003, "v"+strconv.Itoa(i))
+			}
+			src.Insert(0, "")
+			dst := testCodecRoundTrip(t, codec, src, cmp.Compare[int])
+			src.Range(func(k int, v string) bool {
+				if n, ok := dst.Search(k); !ok || n.Value() != v {
+					t.Fatalf("Search(%d) = %v, %v; want %q", k, n, ok, v)
+				}
+				return true
+			})
+
+			empty := testCodecRoundTrip(t, codec, New[int, string](), cmp.Compare[int])
+			if empty.Len() != 0 {
+				t.Errorf("empty snapshot loaded %d entries", empty.Len())
+			}
+		})
+	}
+}
+
+func TestMsgpackCodec_Structs(t *testing.T) {
+	src := New[string, codecRecord]()
+	src.Insert("a", codecRecord{
+		Name:    "alice",
+		Tags:    []string{"x", "y"},
+		Scores:  map[string]int{"math": -40000, "art": 200},
+		Ratio:   0.25,
+		Raw:     []byte{1, 2, 3},
+		Skipped: 9,
+		Next:    &codecRecord{Name: "bob"},
+	})
+	src.Insert("b", codecRecord{})
+	dst := testCodecRoundTrip(t, MsgpackCodec[string, codecRecord]{}, src, strings.Compare)
+
+	n, _ := dst.Search("a")
+	want := codecRecord{
+		Name:   "alice",
+		Tags:   []string{"x", "y"},
+		Scores: map[string]int{"math": -40000, "art": 200},
+		Ratio:  0.25,
+		Raw:    []byte{1, 2, 3},
+		Next:   &codecRecord{Name: "bob"},
+	}
+	if !reflect.DeepEqual(n.Value(), want) {
+		t.Errorf("decoded %+v, want %+v", n.Value(), want)
+	}
+}
+
+// encodeEntries returns the raw codec output for the given entries.
+func encodeEntries[K any, V any](t *testing.T, codec Codec[K, V], keys []K, values []V) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := codec.NewEncoder(&buf)
+	if err := enc.WriteHeader(len(keys)); err != nil {
+		t.Fatal(err)
+	}
+	for i := range keys {
+		if err := enc.WriteEntry(keys[i], values[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestMsgpackCodec_Wire(t *testing.T) {
+	codec := MsgpackCodec[int, string]{}
+	raw := encodeEntries[int, string](t, codec, []int{-1, 1}, []string{"b", "a"})
+	// [[-1, "b"], [1, "a"]]
+	if got, want := hex.EncodeToString(raw), "9292ffa1629201a161"; got != want {
+		t.Errorf("wire = %s, want %s", got, want)
+	}
+
+	dec := codec.NewDecoder(bytes.NewReader(raw[:4]))
+	if _, err := dec.ReadHeader(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := dec.ReadEntry(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadEntry of a truncated stream returned %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestProtobufCodec_Wire(t *testing.T) {
+	raw := encodeEntries[int, string](t, ProtobufCodec[int, string]{}, []int{150}, []string{"hi"})
+	// len=7, field 1 varint 150, field 2 string "hi"
+	if got, want := hex.EncodeToString(raw), "0708960112026869"; got != want {
+		t.Errorf("wire = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,25 @@
+package skiplist
+
+// Comparable is implemented by key types that carry their own ordering.
+// CompareTo must return a negative value, zero or a positive value when the
+// receiver sorts before, equal to or after other, consistently with the rules
+// of a Comparator.
+// Comparable คือ interface สำหรับ key type ที่กำหนดการเรียงลำดับของตัวเอง
+type Comparable[K any] interface {
+	CompareTo(other K) int
+}
+
+// NewFromComparable creates a new skiplist for a key type that implements
+// Comparable, ordered by its CompareTo method.
+// NewFromComparable สร้าง skiplist ใหม่สำหรับ key type ที่มีเมธอด CompareTo
+func NewFromComparable[K Comparable[K], V any](opts ...Option[K, V]) *SkipList[K, V] {
+	return NewWithComparator(CompareComparable[K], opts...)
+}
+
+// CompareComparable is the Comparator of a Comparable key type, for the APIs
+// that take a comparator, such as NewImmutableWithComparator or
+// NewMergeIterator.
+// CompareComparable คือ Comparator ที่เรียกเมธอด CompareTo ของ key
+func CompareComparable[K Comparable[K]](a, b K) int {
+	return a.CompareTo(b)
+}
@@ -0,0 +1,58 @@
+package skiplist
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+// semver is a key type that carries its own ordering.
+type semver struct {
+	major, minor, patch int
+}
+
+func (v semver) CompareTo(other semver) int {
+	if c := cmp.Compare(v.major, other.major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.minor, other.minor); c != 0 {
+		return c
+	}
+	return cmp.Compare(v.patch, other.patch)
+}
+
+func TestNewFromComparable(t *testing.T) {
+	setups := map[string][]Option[semver, string]{
+		"WithPool":  nil,
+		"WithArena": {WithArena[semver, string](1024)},
+	}
+	for name, opts := range setups {
+		t.Run(name, func(t *testing.T) {
+			sl := NewFromComparable(opts...)
+			sl.Insert(semver{1, 10, 0}, "1.10.0")
+			sl.Insert(semver{1, 2, 3}, "1.2.3")
+			sl.Insert(semver{0, 9, 9}, "0.9.9")
+			sl.Insert(semver{1, 2, 10}, "1.2.10")
+
+			var got []string
+			sl.Range(func(_ semver, v string) bool {
+				got = append(got, v)
+				return true
+			})
+			want := []string{"0.9.9", "1.2.3", "1.2.10", "1.10.0"}
+			if !slices.Equal(got, want) {
+				t.Fatalf("Range() = %v, want %v", got, want)
+			}
+			if n, ok := sl.Search(semver{1, 2, 10}); !ok || n.Value() != "1.2.10" {
+				t.Errorf("Search() = %v, %v", n, ok)
+			}
+			if n, ok := sl.Seek(semver{1, 3, 0}); !ok || n.Value() != "1.10.0" {
+				t.Errorf("Seek() = %v, %v", n, ok)
+			}
+		})
+	}
+
+	if c := CompareComparable(semver{1, 0, 0}, semver{0, 9, 9}); c <= 0 {
+		t.Errorf("CompareComparable() = %d, want > 0", c)
+	}
+}
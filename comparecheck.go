@@ -0,0 +1,77 @@
+package skiplist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInconsistentComparator is returned, or raised as a panic by Insert, when
+// WithComparatorCheck finds that the comparator does not define a consistent
+// order.
+var ErrInconsistentComparator = errors.New("skiplist: inconsistent comparator")
+
+// WithComparatorCheck is a debug mode that spot-checks the comparator on
+// one insert (Insert, TryInsert and the writes built on them) out of every,
+// or on every insert if every <= 1. The inserted key is compared with
+// itself, which must return 0, and with its neighbours in both argument
+// orders, which must return opposite signs and place the key between them.
+// A comparator that fails the check would corrupt the order of the list
+// silently; the insert is rejected instead, leaving the list unchanged:
+// TryInsert returns an error wrapping ErrInconsistentComparator and Insert
+// panics with it.
+//
+// The check costs up to five extra comparisons per checked insert and only
+// covers the keys it is run on, so it is meant for tests and debugging.
+// WithComparatorCheck ตรวจสอบความสอดคล้องของฟังก์ชันเปรียบเทียบแบบสุ่มระหว่างการเพิ่มข้อมูล
+func WithComparatorCheck[K any, V any](every int) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.cmpCheck = &comparatorCheck{every: max(every, 1)}
+	}
+}
+
+// comparatorCheck holds the settings and the insert counter of
+// WithComparatorCheck.
+type comparatorCheck struct {
+	every int
+	n     int
+}
+
+// checkComparator spot-checks the comparator on key, about to be inserted
+// between prev (the header if key would be first) and next (nil if key
+// would be last), as configured by WithComparatorCheck. next may hold key
+// itself. The caller must hold the write lock.
+func (sl *SkipList[K, V]) checkComparator(key K, prev, next *node[K, V]) error {
+	c := sl.cmpCheck
+	if c.n++; c.n < c.every {
+		return nil
+	}
+	c.n = 0
+	if r := sl.compare(key, key); r != 0 {
+		return fmt.Errorf("%w: compare(k, k) = %d for key %v", ErrInconsistentComparator, r, key)
+	}
+	if prev != sl.header {
+		ab, ba := sl.compare(prev.key, key), sl.compare(key, prev.key)
+		if ab >= 0 || ba <= 0 {
+			return fmt.Errorf("%w: compare(%v, %v) = %d and compare(%v, %v) = %d for the preceding key",
+				ErrInconsistentComparator, prev.key, key, ab, key, prev.key, ba)
+		}
+	}
+	if next != nil {
+		ab, ba := sl.compare(key, next.key), sl.compare(next.key, key)
+		if ab > 0 || sign(ab) != -sign(ba) {
+			return fmt.Errorf("%w: compare(%v, %v) = %d and compare(%v, %v) = %d for the following key",
+				ErrInconsistentComparator, key, next.key, ab, next.key, key, ba)
+		}
+	}
+	return nil
+}
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
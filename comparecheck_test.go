@@ -0,0 +1,81 @@
+package skiplist
+
+import (
+	"cmp"
+	"errors"
+	"testing"
+)
+
+func TestWithComparatorCheck(t *testing.T) {
+	// Asymmetric: compare(a, b) says a < b for every a != b.
+	asymmetric := func(a, b int) int {
+		if a == b {
+			return 0
+		}
+		return -1
+	}
+	// Not reflexive for 13.
+	irreflexive := func(a, b int) int {
+		if a == 13 && b == 13 {
+			return 1
+		}
+		return cmp.Compare(a, b)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		compare Comparator[int]
+		keys    []int
+		bad     bool
+	}{
+		{"consistent", cmp.Compare[int], []int{5, 1, 9, 3, 7, 3}, false},
+		{"asymmetric", asymmetric, []int{5, 1, 9}, true},
+		{"irreflexive", irreflexive, []int{5, 13}, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewWithComparator[int, int](tt.compare, WithComparatorCheck[int, int](1))
+			var err error
+			for _, k := range tt.keys {
+				if _, err = sl.TryInsert(k, k); err != nil {
+					break
+				}
+			}
+			if got := errors.Is(err, ErrInconsistentComparator); got != tt.bad {
+				t.Fatalf("TryInsert error = %v, want inconsistent: %v", err, tt.bad)
+			}
+			if err := sl.Validate(); err != nil && !tt.bad {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	t.Run("sampling", func(t *testing.T) {
+		calls := 0
+		counting := func(a, b int) int { calls++; return cmp.Compare(a, b) }
+		sl := NewWithComparator[int, int](counting, WithComparatorCheck[int, int](4))
+		sl.Insert(1, 1)
+		before := calls
+		sl.Insert(1, 2) // the second and third inserts are not checked
+		sl.Insert(1, 3)
+		unchecked := calls - before
+		before = calls
+		sl.Insert(1, 4) // fourth insert is checked
+		if checked := calls - before; checked <= unchecked/2 {
+			t.Errorf("checked insert made %d comparisons, unchecked ones %d", checked, unchecked)
+		}
+	})
+
+	t.Run("Insert panics", func(t *testing.T) {
+		sl := NewWithComparator[int, int](asymmetric, WithComparatorCheck[int, int](0))
+		sl.Insert(1, 1)
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, ErrInconsistentComparator) {
+				t.Fatalf("recovered %v, want ErrInconsistentComparator", err)
+			}
+			if sl.Len() != 1 {
+				t.Errorf("Len() = %d after a rejected insert", sl.Len())
+			}
+		}()
+		sl.Insert(2, 2)
+	})
+}
@@ -0,0 +1,75 @@
+package skiplist
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
+// ComparisonCounter wraps a comparator and counts its calls, so that tests
+// can assert the complexity of operations in comparisons, e.g. that a
+// Search costs O(log n) of them, and catch regressions of the descent logic
+// as complexity blowups in CI. Its Compare method is the comparator to give
+// to NewWithComparator:
+//
+//	cc := skiplist.NewComparisonCounter(cmp.Compare[int])
+//	sl := skiplist.NewWithComparator[int, string](cc.Compare)
+//	if n := cc.Measure(func() { sl.Search(42) }); n > cc.LogBound(sl.Len(), 2) { ... }
+//
+// It is safe for concurrent use; counts then include the calls of every
+// goroutine.
+// ComparisonCounter นับจำนวนครั้งที่ฟังก์ชันเปรียบเทียบถูกเรียก เพื่อทดสอบความซับซ้อนของแต่ละ operation
+type ComparisonCounter[K any] struct {
+	compare Comparator[K]
+	count   atomic.Int64
+	limit   atomic.Int64 // 0 = no limit
+}
+
+// NewComparisonCounter returns a counter wrapping compare.
+func NewComparisonCounter[K any](compare Comparator[K]) *ComparisonCounter[K] {
+	return &ComparisonCounter[K]{compare: compare}
+}
+
+// Compare calls the wrapped comparator and counts the call. It panics if the
+// count exceeds the limit set by SetLimit.
+func (c *ComparisonCounter[K]) Compare(a, b K) int {
+	if n := c.count.Add(1); n > c.limit.Load() && c.limit.Load() > 0 {
+		panic(fmt.Sprintf("skiplist: %d comparisons exceed the limit of %d", n, c.limit.Load()))
+	}
+	return c.compare(a, b)
+}
+
+// Count returns the number of comparisons since the counter was created or
+// last reset.
+func (c *ComparisonCounter[K]) Count() int64 {
+	return c.count.Load()
+}
+
+// Reset sets the count to zero and returns its previous value.
+func (c *ComparisonCounter[K]) Reset() int64 {
+	return c.count.Swap(0)
+}
+
+// SetLimit makes Compare panic once the count exceeds n, turning a runaway
+// operation into an immediate failure; n <= 0 removes the limit. Together
+// with Measure, which resets the count, it bounds each measured operation.
+func (c *ComparisonCounter[K]) SetLimit(n int64) {
+	c.limit.Store(max(n, 0))
+}
+
+// Measure resets the count, runs f and returns the number of comparisons f
+// made.
+func (c *ComparisonCounter[K]) Measure(f func()) int64 {
+	c.Reset()
+	f()
+	return c.Count()
+}
+
+// LogBound returns factor times the expected number of comparisons of a
+// descent through a list of n entries, (log_{1/P} n + 1) / P, a bound to
+// assert on single-key operations. A factor of 2 leaves room for the
+// variance of the random node heights.
+func (c *ComparisonCounter[K]) LogBound(n int, factor float64) int64 {
+	levels := math.Log(float64(max(n, 1)))/math.Log(1/P) + 1
+	return int64(math.Ceil(factor * levels / P))
+}
@@ -0,0 +1,48 @@
+package skiplist
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestComparisonCounter(t *testing.T) {
+	cc := NewComparisonCounter(cmp.Compare[int])
+	sl := NewWithComparator[int, int](cc.Compare)
+	const n = 1 << 14
+	for _, k := range rand.Perm(n) {
+		sl.Insert(k, k)
+	}
+	if cc.Count() == 0 {
+		t.Fatal("no comparison counted")
+	}
+
+	bound := cc.LogBound(n, 2)
+	var total int64
+	for i := 0; i < 1000; i++ {
+		key := rand.IntN(n)
+		total += cc.Measure(func() { sl.Search(key) })
+	}
+	if avg := total / 1000; avg > bound {
+		t.Errorf("Search: %d comparisons on average, bound %d", avg, bound)
+	}
+	if c := cc.Measure(func() { sl.Len() }); c != 0 {
+		t.Errorf("Len made %d comparisons", c)
+	}
+
+	// A comparison count runaway fails at once.
+	cc.SetLimit(10)
+	cc.Reset()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Range over all keys within the limit did not panic")
+			}
+		}()
+		sl.CountRange(0, n)
+	}()
+	cc.SetLimit(0)
+	if sl.CountRange(0, n) != n {
+		t.Error("CountRange failed without a limit")
+	}
+}
@@ -0,0 +1,25 @@
+package skiplist
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// ExportCSV writes every entry as one CSV record, in ascending key order.
+// fmtKV converts an entry into the fields of its record. The export is taken
+// under a single read lock, so it is a consistent view of the list.
+// ExportCSV เขียนทุกรายการออกเป็น CSV ตามลำดับ key โดยใช้ fmtKV แปลงแต่ละรายการเป็น field
+func (sl *SkipList[K, V]) ExportCSV(w io.Writer, fmtKV func(key K, value V) []string) error {
+	defer rethrowCallbackPanic("ExportCSV")
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	cw := csv.NewWriter(w)
+	for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+		if err := cw.Write(fmtKV(n.key, n.value)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
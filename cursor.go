@@ -0,0 +1,124 @@
+package skiplist
+
+// Cursor is a low-level position in the levels of a skiplist, for custom
+// search strategies (galloping search, bounded probes, interpolation) built
+// from the same moves as the package's own descents: move right along the
+// current level while a condition holds, then descend one level.
+//
+// A Cursor starts on the header, before the first entry, at the top level.
+// It is only valid inside the callback of WithCursor, which holds the read
+// lock. The rank of the current position is tracked along the way.
+//
+// Cursor คือตำแหน่งระดับล่างใน skiplist สำหรับการค้นหาแบบกำหนดเอง
+// โดยเลื่อนไปทางขวาในชั้นปัจจุบันหรือลงไปยังชั้นถัดไปด้วยตนเอง
+type Cursor[K any, V any] struct {
+	sl      *SkipList[K, V]
+	current *node[K, V]
+	level   int
+	rank    int // rank of current; -1 on the header
+}
+
+// WithCursor calls f with a Cursor on the header of the list at its top
+// level, holding the read lock until f returns.
+// WithCursor เรียก f พร้อม Cursor ที่ชี้ไปยัง header ที่ชั้นบนสุด ภายใต้ read lock
+func (sl *SkipList[K, V]) WithCursor(f func(c *Cursor[K, V])) {
+	defer rethrowCallbackPanic("WithCursor")
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	f(&Cursor[K, V]{sl: sl, current: sl.header, level: sl.level, rank: -1})
+}
+
+// Level returns the current level, 0 being the level linking every entry.
+func (c *Cursor[K, V]) Level() int {
+	return c.level
+}
+
+// AtHeader reports whether the cursor is on the header, before the first
+// entry, where Key and Value are meaningless.
+func (c *Cursor[K, V]) AtHeader() bool {
+	return c.current == c.sl.header
+}
+
+// Key returns the key of the current entry.
+func (c *Cursor[K, V]) Key() K {
+	return c.current.key
+}
+
+// Value returns the value of the current entry.
+func (c *Cursor[K, V]) Value() V {
+	return c.current.value
+}
+
+// Rank returns the 0-based rank of the current entry, or -1 on the header.
+// It panics with ErrNoRankTracking on a list created with
+// WithoutRankTracking, whose spans, as reported by Peek, are all zero.
+func (c *Cursor[K, V]) Rank() int {
+	c.sl.mustRanks()
+	return c.rank
+}
+
+// Peek returns the entry following the current one on the current level,
+// with the number of entries it skips over (its distance in ranks), without
+// moving. It returns false at the end of the level.
+func (c *Cursor[K, V]) Peek() (n INode[K, V], span int, ok bool) {
+	next := c.current.forward[c.level]
+	if next == nil {
+		return nil, 0, false
+	}
+	return next, c.current.span[c.level], true
+}
+
+// Advance moves to the entry following the current one on the current
+// level and returns false, without moving, at the end of the level.
+func (c *Cursor[K, V]) Advance() bool {
+	next := c.current.forward[c.level]
+	if next == nil {
+		return false
+	}
+	c.rank += c.current.span[c.level]
+	c.current = next
+	return true
+}
+
+// AdvanceWhile moves right on the current level as long as the key of the
+// following entry satisfies pred, and returns the number of moves. With
+// pred(k) == compare(k, target) < 0, it is one step of an ordinary search.
+func (c *Cursor[K, V]) AdvanceWhile(pred func(key K) bool) int {
+	moves := 0
+	for next := c.current.forward[c.level]; next != nil && pred(next.key); next = c.current.forward[c.level] {
+		c.rank += c.current.span[c.level]
+		c.current = next
+		moves++
+	}
+	return moves
+}
+
+// DescendLevel moves down one level, staying on the current entry, and
+// returns false, without moving, on level 0.
+func (c *Cursor[K, V]) DescendLevel() bool {
+	if c.level == 0 {
+		return false
+	}
+	c.level--
+	return true
+}
+
+// Height returns the number of levels of the current entry, or of the
+// list for the header. The cursor can only move on the levels below it.
+func (c *Cursor[K, V]) Height() int {
+	if c.AtHeader() {
+		return c.sl.level + 1
+	}
+	return len(c.current.forward)
+}
+
+// Node returns the current entry as a node handle, or nil on the header.
+// Like the nodes of RangeNodes, it must not be used once its entry is
+// deleted.
+func (c *Cursor[K, V]) Node() INode[K, V] {
+	if c.AtHeader() {
+		return nil
+	}
+	return c.current
+}
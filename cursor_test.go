@@ -0,0 +1,64 @@
+package skiplist
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			sl.WithCursor(func(c *Cursor[int, int]) {
+				if !c.AtHeader() || c.Rank() != -1 || c.Level() != 0 || c.Advance() || c.DescendLevel() {
+					t.Error("cursor of an empty list should stay on the header")
+				}
+			})
+
+			for i := 0; i < 1000; i++ {
+				sl.Insert(i*2, i)
+			}
+
+			// An ordinary search, step by step.
+			for _, target := range []int{-1, 0, 1, 777, 1998, 5000} {
+				sl.WithCursor(func(c *Cursor[int, int]) {
+					for {
+						c.AdvanceWhile(func(k int) bool { return cmp.Compare(k, target) < 0 })
+						if c.Level() >= c.Height() {
+							t.Fatalf("level %d above the height %d of the entry", c.Level(), c.Height())
+						}
+						if !c.DescendLevel() {
+							break
+						}
+					}
+					if want := sl.Rank(target) - 1; c.Rank() != want {
+						t.Errorf("target %d: rank %d, want %d", target, c.Rank(), want)
+					}
+					if !c.AtHeader() && (c.Key() >= target || c.Key()/2 != c.Value() || c.Node().Key() != c.Key()) {
+						t.Errorf("target %d: cursor at %d", target, c.Key())
+					}
+					n, span, ok := c.Peek()
+					if ok != (target <= 1998) || (ok && (n.Key() < target || span != 1)) {
+						t.Errorf("target %d: Peek() = %v, %d, %v", target, n, span, ok)
+					}
+				})
+			}
+
+			// Level 0 visits every entry.
+			sl.WithCursor(func(c *Cursor[int, int]) {
+				for c.DescendLevel() {
+				}
+				count := 0
+				for c.Advance() {
+					if c.Rank() != count || c.Key() != count*2 {
+						t.Fatalf("entry %d: rank %d, key %d", count, c.Rank(), c.Key())
+					}
+					count++
+				}
+				if count != 1000 {
+					t.Errorf("visited %d entries", count)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package skiplist
+
+import "runtime"
+
+// DeleteRange removes every entry whose key is between start and end
+// (inclusive) and returns the number of entries removed. The entries are
+// unlinked one after the other from a single update path, in O(log n + k)
+// for k removed entries, under one write lock. With the OnDeleteRange hook,
+// the removal is reported by a single call rather than one OnDelete call per
+// entry (see Hooks). It returns 0 if start is after end.
+// DeleteRange ลบทุกรายการที่มี key อยู่ระหว่าง start และ end (รวมทั้งสองค่า)
+func (sl *SkipList[K, V]) DeleteRange(start, end K) int {
+	tr := sl.traceStart(OpDeleteRange)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	tr.keys = sl.deleteRange(start, end)
+	return tr.keys
+}
+
+// deleteRange implements DeleteRange. The caller must hold the write lock.
+func (sl *SkipList[K, V]) deleteRange(start, end K) int {
+	sl.mustNotBeFrozen()
+	if sl.compare(start, end) > 0 {
+		return 0
+	}
+	update := sl.updateCache
+	current := sl.header
+	kp := sl.prefixOf(start)
+	for i := sl.level; i >= 0; i-- {
+		for current.forward[i] != nil && sl.compareNode(current.forward[i], start, kp) < 0 {
+			current = current.forward[i]
+		}
+		update[i] = current
+	}
+
+	// Once a node is removed, the nodes of update still precede the next
+	// one at every level, so the path is reused for the whole range.
+	batch := sl.hooks.OnDeleteRange != nil
+	sl.batching = batch
+	defer func() { sl.batching = false }()
+	var first, last K
+	count := 0
+	for n := current.forward[0]; n != nil && sl.compare(n.key, end) <= 0; n = current.forward[0] {
+		if count == 0 {
+			first = n.key
+		}
+		last = n.key
+		count++
+		sl.deleteNode(n, update)
+	}
+	if count > 0 {
+		sl.compactLevelsAfterRemoval()
+	}
+	if !batch || count == 0 {
+		return count
+	}
+	sl.batching = false
+	sl.hooks.onDeleteRange(first, last, count)
+	if sl.hooks.OnBoundsChange != nil && (current == sl.header || current.forward[0] == nil) {
+		sl.boundsChanged()
+	}
+	return count
+}
+
+// ClearIncremental removes every entry like Clear, but in batches of at most
+// batch entries (1024 if batch <= 0), each under its own write lock, letting
+// other goroutines run between two batches. Draining a list of tens of
+// millions of entries then never stalls readers and writers for more than
+// one batch, and each removed entry goes through the per-entry bookkeeping
+// (OnDelete, or one OnDeleteRange call per batch, WithChangeTracking,
+// WithSizeFunc accounting, ...). Entries inserted during the drain are
+// removed as well: it returns, with the number of entries removed, once a
+// batch leaves the list empty. That last batch also resets the allocator as
+// Clear does, reclaiming arena memory.
+// ClearIncremental ลบรายการทั้งหมดทีละชุดไม่เกิน batch รายการ โดยปล่อย lock ระหว่างแต่ละชุด
+func (sl *SkipList[K, V]) ClearIncremental(batch int) int {
+	if batch <= 0 {
+		batch = 1024
+	}
+	total := 0
+	for {
+		removed, empty := sl.clearBatch(batch)
+		total += removed
+		if empty {
+			return total
+		}
+		runtime.Gosched()
+	}
+}
+
+// clearBatch removes the batch smallest entries, resetting the list if it is
+// then empty, and reports whether it is.
+func (sl *SkipList[K, V]) clearBatch(batch int) (int, bool) {
+	tr := sl.traceStart(OpClear)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	if sl.length > 0 {
+		first := sl.header.forward[0].key
+		last := sl.getByRank(min(batch, sl.length) - 1).key
+		tr.keys = sl.deleteRange(first, last)
+	}
+	if sl.length > 0 {
+		return tr.keys, false
+	}
+	sl.clear()
+	return tr.keys, true
+}
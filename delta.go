@@ -0,0 +1,232 @@
+package skiplist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// changeStamp records the version at which a key was last modified.
+type changeStamp struct {
+	version uint64
+	deleted bool
+}
+
+// deltaMagic identifies the framed stream written by SaveDelta.
+const deltaMagic = "SLDELTA\x01"
+
+// WithChangeTracking records, for every key, the version (see Version) at
+// which it was last inserted, updated or deleted. This enables SaveDelta.
+// Deleted keys leave a small tombstone behind until TrimChangeLog is called.
+//
+// WithChangeTracking บันทึก version ล่าสุดที่แต่ละ key ถูกแก้ไข เพื่อใช้กับ SaveDelta
+func WithChangeTracking[K any, V any]() Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.changes = NewWithComparator[K, changeStamp](sl.compare)
+	}
+}
+
+// recordChange stamps key with the current version. The caller must hold the write lock.
+func (sl *SkipList[K, V]) recordChange(key K, deleted bool) {
+	sl.changes.insert(key, changeStamp{version: sl.version, deleted: deleted})
+}
+
+func (sl *SkipList[K, V]) mustChanges() {
+	if sl.changes == nil {
+		panic("skiplist: delta API used without WithChangeTracking")
+	}
+}
+
+// SaveDelta writes the changes made after sinceVersion: the current value of
+// every key inserted or updated since then and the keys deleted since then.
+// It returns the version the delta is complete up to, to be passed as
+// sinceVersion to the next call. Applying the deltas in order with ApplyDelta
+// on top of a snapshot taken at sinceVersion reproduces the current contents.
+//
+// Entries are encoded with the configured codec and framed and checksummed like
+// Save. The changes are gathered under a single read lock.
+// SaveDelta panics if the skiplist was not created with WithChangeTracking.
+//
+// SaveDelta เขียนเฉพาะรายการที่เปลี่ยนแปลงหลังจาก sinceVersion
+// และคืนค่า version ที่ใช้เป็น sinceVersion ในครั้งถัดไป
+func (sl *SkipList[K, V]) SaveDelta(w io.Writer, sinceVersion uint64) (uint64, error) {
+	sl.mustChanges()
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	codec := sl.snapshotCodec()
+	var upserts, deletes []*node[K, changeStamp]
+	for c := sl.changes.header.forward[0]; c != nil; c = c.forward[0] {
+		if c.value.version <= sinceVersion {
+			continue
+		}
+		if c.value.deleted {
+			deletes = append(deletes, c)
+		} else {
+			upserts = append(upserts, c)
+		}
+	}
+
+	var upsertBuf bytes.Buffer
+	enc := codec.NewEncoder(&upsertBuf)
+	if err := enc.WriteHeader(len(upserts)); err != nil {
+		return 0, err
+	}
+	// Walk the main list alongside the change log; both are in key order.
+	n := sl.header.forward[0]
+	for _, c := range upserts {
+		for n != nil && sl.compare(n.key, c.key) < 0 {
+			n = n.forward[0]
+		}
+		if err := enc.WriteEntry(n.key, n.value); err != nil {
+			return 0, err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return 0, err
+	}
+
+	var deleteBuf bytes.Buffer
+	enc = codec.NewEncoder(&deleteBuf)
+	if err := enc.WriteHeader(len(deletes)); err != nil {
+		return 0, err
+	}
+	var zero V
+	for _, c := range deletes {
+		if err := enc.WriteEntry(c.key, zero); err != nil {
+			return 0, err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return 0, err
+	}
+
+	fw := newFrameWriter(w, deltaMagic)
+	hdr := binary.AppendUvarint(nil, sinceVersion)
+	hdr = binary.AppendUvarint(hdr, sl.version)
+	hdr = binary.AppendUvarint(hdr, uint64(upsertBuf.Len()))
+	fw.Write(hdr)
+	fw.Write(upsertBuf.Bytes())
+	fw.Write(binary.AppendUvarint(nil, uint64(deleteBuf.Len())))
+	fw.Write(deleteBuf.Bytes())
+	if err := fw.close(len(upserts)+len(deletes), nil); err != nil {
+		return 0, err
+	}
+	return sl.version, nil
+}
+
+// ApplyDelta applies a delta written by SaveDelta with the same codec. The
+// whole delta is read and verified before the list is modified, so a damaged
+// delta (reported with an error wrapping ErrCorrupt) leaves the list unchanged.
+//
+// ApplyDelta นำ delta ที่เขียนโดย SaveDelta มาใช้กับ skiplist
+// delta จะถูกตรวจสอบทั้งหมดก่อน หากเสียหายจะไม่มีการแก้ไขข้อมูล
+func (sl *SkipList[K, V]) ApplyDelta(r io.Reader) error {
+	magic := make([]byte, len(deltaMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return truncated(err)
+	}
+	if string(magic) != deltaMagic {
+		return fmt.Errorf("skiplist: not a delta (magic %q)", magic)
+	}
+	fr := &frameReader{r: bufio.NewReader(r)}
+	payload, err := io.ReadAll(fr)
+	if err != nil {
+		return err
+	}
+	footer, err := fr.finish()
+	if err != nil {
+		return err
+	}
+
+	bad := fmt.Errorf("%w: malformed delta", ErrCorrupt)
+	// Skip the since and current versions, then split the upsert and delete
+	// sections.
+	for i := 0; i < 2; i++ {
+		_, n := binary.Uvarint(payload)
+		if n <= 0 {
+			return bad
+		}
+		payload = payload[n:]
+	}
+	var sections [2][]byte
+	for i := range sections {
+		size, n := binary.Uvarint(payload)
+		if n <= 0 || uint64(len(payload)-n) < size {
+			return bad
+		}
+		sections[i], payload = payload[n:n+int(size)], payload[n+int(size):]
+	}
+
+	codec := sl.snapshotCodec()
+	var upsertKeys, deleteKeys []K
+	var upsertValues []V
+	for i, section := range sections {
+		dec := codec.NewDecoder(bytes.NewReader(section))
+		count, err := dec.ReadHeader()
+		if err != nil {
+			return err
+		}
+		for ; count != 0; count-- {
+			key, value, err := dec.ReadEntry()
+			if err == io.EOF && count < 0 {
+				break
+			}
+			if err != nil {
+				return truncated(err)
+			}
+			if i == 0 {
+				upsertKeys = append(upsertKeys, key)
+				upsertValues = append(upsertValues, value)
+			} else {
+				deleteKeys = append(deleteKeys, key)
+			}
+		}
+	}
+	if footer.count != uint64(len(upsertKeys)+len(deleteKeys)) {
+		return fmt.Errorf("%w: delta records %d changes but holds %d", ErrCorrupt, footer.count, len(upsertKeys)+len(deleteKeys))
+	}
+
+	sl.mutex.Lock()
+	defer sl.mutex.Unlock()
+	if sl.frozen {
+		return ErrFrozen
+	}
+	// The delta is applied only if all its keys are valid.
+	for _, key := range upsertKeys {
+		if err := sl.checkKey(key); err != nil {
+			return err
+		}
+	}
+	for i, key := range upsertKeys {
+		sl.insert(key, upsertValues[i])
+	}
+	for _, key := range deleteKeys {
+		sl.delete(key)
+	}
+	return nil
+}
+
+// TrimChangeLog forgets the tombstones of keys deleted at or before version
+// and returns how many were removed. A later SaveDelta with a sinceVersion
+// below version no longer reports those deletions.
+// TrimChangeLog panics if the skiplist was not created with WithChangeTracking.
+// TrimChangeLog ลบ tombstone ของ key ที่ถูกลบที่ version ไม่เกินค่าที่กำหนด
+func (sl *SkipList[K, V]) TrimChangeLog(version uint64) int {
+	sl.mustChanges()
+	sl.mutex.Lock()
+	defer sl.mutex.Unlock()
+
+	var trim []K
+	for c := sl.changes.header.forward[0]; c != nil; c = c.forward[0] {
+		if c.value.deleted && c.value.version <= version {
+			trim = append(trim, c.key)
+		}
+	}
+	for _, key := range trim {
+		sl.changes.delete(key)
+	}
+	return len(trim)
+}
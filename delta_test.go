@@ -0,0 +1,95 @@
+package skiplist
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestDelta(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			primary := setup.constructor(nil, WithChangeTracking[int, string]())
+			for i := 0; i < 100; i++ {
+				primary.Insert(i, "v0")
+			}
+
+			var snap bytes.Buffer
+			if err := primary.Save(&snap); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			snapSize := snap.Len()
+			since := primary.Version()
+			replica := setup.constructor(nil)
+			if err := replica.Load(&snap); err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			for round := 0; round < 3; round++ {
+				primary.Insert(round*10, "updated")
+				primary.Insert(1000+round, "new")
+				primary.Delete(round*10 + 1)
+				primary.Insert(round*10+2, "temp")
+				primary.Delete(round*10 + 2)
+
+				var delta bytes.Buffer
+				next, err := primary.SaveDelta(&delta, since)
+				if err != nil {
+					t.Fatalf("SaveDelta: %v", err)
+				}
+				if next != primary.Version() {
+					t.Errorf("SaveDelta returned version %d, want %d", next, primary.Version())
+				}
+				if delta.Len() >= snapSize/2 {
+					t.Errorf("delta of %d bytes is not much smaller than a snapshot", delta.Len())
+				}
+				if err := replica.ApplyDelta(&delta); err != nil {
+					t.Fatalf("ApplyDelta: %v", err)
+				}
+				since = next
+
+				if replica.Len() != primary.Len() {
+					t.Fatalf("round %d: replica has %d entries, want %d", round, replica.Len(), primary.Len())
+				}
+				primary.Range(func(k int, v string) bool {
+					if n, ok := replica.Search(k); !ok || n.Value() != v {
+						t.Fatalf("round %d: replica Search(%d) = %v, %v; want %q", round, k, n, ok, v)
+					}
+					return true
+				})
+			}
+
+			if n := primary.TrimChangeLog(primary.Version()); n != 6 {
+				t.Errorf("TrimChangeLog removed %d tombstones, want 6", n)
+			}
+		})
+	}
+}
+
+func TestApplyDelta_Corrupt(t *testing.T) {
+	sl := New[int, string](WithChangeTracking[int, string]())
+	sl.Insert(1, "a")
+	var delta bytes.Buffer
+	if _, err := sl.SaveDelta(&delta, 0); err != nil {
+		t.Fatal(err)
+	}
+	b := delta.Bytes()
+	b[len(deltaMagic)+10] ^= 0xff
+
+	dst := New[int, string]()
+	if err := dst.ApplyDelta(bytes.NewReader(b)); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("ApplyDelta of a damaged delta returned %v, want ErrCorrupt", err)
+	}
+	if dst.Len() != 0 {
+		t.Errorf("damaged delta modified the list")
+	}
+}
+
+func TestSaveDelta_WithoutTracking(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("SaveDelta without WithChangeTracking should panic")
+		}
+	}()
+	New[int, int]().SaveDelta(&bytes.Buffer{}, 0)
+}
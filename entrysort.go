@@ -0,0 +1,89 @@
+package skiplist
+
+// Entries returns a copy of all entries in ascending key order, read under a
+// single read lock. The slice belongs to the caller, who can re-sort it by
+// value or by other criteria with the EntryCompare functions below.
+// Entries คืนค่าสำเนาของรายการทั้งหมดเรียงตาม key ซึ่งผู้เรียกนำไปเรียงใหม่ได้
+func (sl *SkipList[K, V]) Entries() []Entry[K, V] {
+	tr := sl.traceStart(OpRangeQuery)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	out := make([]Entry[K, V], 0, sl.length)
+	for current := sl.header.forward[0]; current != nil; current = current.forward[0] {
+		out = append(out, Entry[K, V]{Key: current.key, Value: current.value})
+	}
+	tr.keys = len(out)
+	return out
+}
+
+// Entries returns the entries of the current version of the skiplist, in key
+// order. Unlike Keys and Values, the slice is a fresh copy that the caller
+// may modify and re-sort.
+// Entries คืนค่าสำเนาของรายการในเวอร์ชันปัจจุบันซึ่งผู้เรียกแก้ไขได้
+func (v *SliceView[K, V]) Entries() []Entry[K, V] {
+	keys, values := v.Snapshot()
+	out := make([]Entry[K, V], len(keys))
+	for i := range keys {
+		out[i] = Entry[K, V]{Key: keys[i], Value: values[i]}
+	}
+	return out
+}
+
+// EntryCompare orders two entries, with the signature expected by
+// slices.SortFunc, slices.SortStableFunc and slices.BinarySearchFunc.
+// EntryCompare เปรียบเทียบสอง Entry ใช้กับฟังก์ชันเรียงลำดับของแพ็กเกจ slices ได้โดยตรง
+type EntryCompare[K any, V any] func(a, b Entry[K, V]) int
+
+// ByKey returns an EntryCompare ordering entries by key with compare.
+// ByKey คืนค่า EntryCompare ที่เรียงตาม key
+func ByKey[K any, V any](compare Comparator[K]) EntryCompare[K, V] {
+	return func(a, b Entry[K, V]) int { return compare(a.Key, b.Key) }
+}
+
+// ByValue returns an EntryCompare ordering entries by value with compare.
+// ByValue คืนค่า EntryCompare ที่เรียงตาม value
+func ByValue[K any, V any](compare func(a, b V) int) EntryCompare[K, V] {
+	return func(a, b Entry[K, V]) int { return compare(a.Value, b.Value) }
+}
+
+// Reverse returns an EntryCompare ordering entries in the opposite order of
+// c.
+// Reverse คืนค่า EntryCompare ที่เรียงกลับด้านกับ c
+func (c EntryCompare[K, V]) Reverse() EntryCompare[K, V] {
+	return func(a, b Entry[K, V]) int { return c(b, a) }
+}
+
+// Then returns an EntryCompare ordering entries by c, and entries equal
+// under c by next, for secondary criteria:
+//
+//	slices.SortFunc(entries, skiplist.ByValue[string](cmp.Compare[int]).Then(skiplist.ByKey[string, int](strings.Compare)))
+//
+// Then คืนค่า EntryCompare ที่ใช้ next เป็นเกณฑ์รองเมื่อ c ถือว่าเท่ากัน
+func (c EntryCompare[K, V]) Then(next EntryCompare[K, V]) EntryCompare[K, V] {
+	return func(a, b Entry[K, V]) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// EntrySorter adapts a slice of entries and an EntryCompare to
+// sort.Interface, for code built on sort.Sort and sort.Stable.
+// EntrySorter ทำให้ slice ของ Entry ใช้กับ sort.Interface ได้
+type EntrySorter[K any, V any] struct {
+	Entries []Entry[K, V]
+	Compare EntryCompare[K, V]
+}
+
+// Len implements sort.Interface.
+func (s EntrySorter[K, V]) Len() int { return len(s.Entries) }
+
+// Less implements sort.Interface.
+func (s EntrySorter[K, V]) Less(i, j int) bool { return s.Compare(s.Entries[i], s.Entries[j]) < 0 }
+
+// Swap implements sort.Interface.
+func (s EntrySorter[K, V]) Swap(i, j int) { s.Entries[i], s.Entries[j] = s.Entries[j], s.Entries[i] }
@@ -0,0 +1,41 @@
+package skiplist
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestEntriesSortAdapters(t *testing.T) {
+	sl := New[int, string]()
+	for i, v := range []string{"d", "b", "a", "b", "c"} {
+		sl.Insert(i, v)
+	}
+
+	entries := sl.Entries()
+	if len(entries) != 5 || entries[0].Key != 0 || entries[4].Value != "c" {
+		t.Fatalf("Entries() = %v", entries)
+	}
+	view := sl.AsSortedSlice().Entries()
+	if !slices.Equal(view, entries) {
+		t.Fatalf("SliceView.Entries() = %v, want %v", view, entries)
+	}
+
+	byValue := ByValue[int](cmp.Compare[string]).Then(ByKey[int, string](cmp.Compare[int]).Reverse())
+	slices.SortFunc(entries, byValue)
+	want := []Entry[int, string]{{2, "a"}, {3, "b"}, {1, "b"}, {4, "c"}, {0, "d"}}
+	if !slices.Equal(entries, want) {
+		t.Errorf("SortFunc by value then key desc = %v, want %v", entries, want)
+	}
+
+	sort.Sort(EntrySorter[int, string]{Entries: view, Compare: byValue})
+	if !slices.Equal(view, want) {
+		t.Errorf("sort.Sort by value then key desc = %v, want %v", view, want)
+	}
+
+	// The copies are the caller's: re-sorting them leaves the list intact.
+	if first, _ := sl.Min(); first.Key() != 0 {
+		t.Errorf("Min() = %v after re-sorting the copies", first.Key())
+	}
+}
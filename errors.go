@@ -0,0 +1,114 @@
+package skiplist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The errors below, together with ErrArenaFull, ErrUnsorted, ErrCorrupt,
+// ErrMigrationInProgress, ErrInconsistentComparator, ErrEntryTooLarge,
+// ErrKeyEncoding, ErrNoRankTracking, ErrChunkSnapshot and ErrQuotaExceeded,
+// are the failure causes reported by the package.
+// Compare them with errors.Is: some are wrapped with details.
+
+// ErrKeyNotFound is returned by the error-returning variants of lookups and
+// deletes (TrySearch, TryDelete) when the key is absent.
+var ErrKeyNotFound = errors.New("skiplist: key not found")
+
+// ErrFrozen is returned, or raised as a panic by the methods that do not
+// return errors, when a list made read-only by Freeze is modified.
+var ErrFrozen = errors.New("skiplist: list is frozen")
+
+// ErrInvalidKey wraps the errors of the key validator registered with
+// WithKeyValidator.
+var ErrInvalidKey = errors.New("skiplist: invalid key")
+
+// ErrInvalidRange is returned by the error-returning variants of range and
+// rank queries (TryRangeQuery, TryCountRange, TryGetByRank) when the start of
+// the range is after its end or the rank is out of bounds.
+var ErrInvalidRange = errors.New("skiplist: invalid range")
+
+// TrySearch is like Search but returns ErrKeyNotFound when key is absent.
+// TrySearch ทำงานเหมือน Search แต่คืนค่า ErrKeyNotFound เมื่อไม่พบ key
+func (sl *SkipList[K, V]) TrySearch(key K) (INode[K, V], error) {
+	if n, ok := sl.Search(key); ok {
+		return n, nil
+	}
+	return nil, ErrKeyNotFound
+}
+
+// TryDelete is like Delete but returns ErrKeyNotFound when key is absent and
+// ErrFrozen, instead of panicking, when the list is frozen.
+// TryDelete ทำงานเหมือน Delete แต่คืนค่า error แทนค่า bool
+func (sl *SkipList[K, V]) TryDelete(key K) error {
+	tr := sl.traceStart(OpDelete)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	if sl.frozen {
+		return ErrFrozen
+	}
+	if sl.delete(key) {
+		tr.keys = 1
+		return nil
+	}
+	if sl.lww != nil {
+		// A delete is a write in LWW mode even if the key is absent locally.
+		sl.stampLWW(key, true)
+	}
+	return ErrKeyNotFound
+}
+
+// TryRangeQuery is like RangeQuery but returns ErrInvalidRange when start is
+// after end, instead of calling f for no entry.
+// TryRangeQuery ทำงานเหมือน RangeQuery แต่คืนค่า ErrInvalidRange เมื่อ start มากกว่า end
+func (sl *SkipList[K, V]) TryRangeQuery(start, end K, f func(key K, value V) bool, opts ...ScanOption) error {
+	if sl.compare(start, end) > 0 {
+		return ErrInvalidRange
+	}
+	sl.RangeQuery(start, end, f, opts...)
+	return nil
+}
+
+// TryCountRange is like CountRange but returns ErrInvalidRange when start is
+// after end, instead of 0.
+// TryCountRange ทำงานเหมือน CountRange แต่คืนค่า ErrInvalidRange เมื่อ start มากกว่า end
+func (sl *SkipList[K, V]) TryCountRange(start, end K) (int, error) {
+	if sl.compare(start, end) > 0 {
+		return 0, ErrInvalidRange
+	}
+	return sl.CountRange(start, end), nil
+}
+
+// TryRank is like Rank but returns ErrNoRankTracking, instead of panicking,
+// when the list was created with WithoutRankTracking.
+// TryRank ทำงานเหมือน Rank แต่คืนค่า ErrNoRankTracking เมื่อไม่ได้เก็บข้อมูลอันดับ
+func (sl *SkipList[K, V]) TryRank(key K) (int, error) {
+	if sl.noRanks {
+		return 0, ErrNoRankTracking
+	}
+	return sl.Rank(key), nil
+}
+
+// TryGetByRank is like GetByRank but returns an error wrapping
+// ErrInvalidRange when rank is out of bounds, and ErrNoRankTracking when the
+// list was created with WithoutRankTracking.
+// TryGetByRank ทำงานเหมือน GetByRank แต่คืนค่า ErrInvalidRange เมื่ออันดับอยู่นอกขอบเขต
+func (sl *SkipList[K, V]) TryGetByRank(rank int) (INode[K, V], error) {
+	if sl.noRanks {
+		return nil, ErrNoRankTracking
+	}
+	tr := sl.traceStart(OpGetByRank)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	if rank < 0 || rank >= sl.length {
+		return nil, fmt.Errorf("%w: rank %d out of [0, %d)", ErrInvalidRange, rank, sl.length)
+	}
+	tr.keys = 1
+	return sl.getByRank(rank), nil
+}
@@ -0,0 +1,127 @@
+package skiplist
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestTypedErrors(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			sl.Insert(1, "a")
+			sl.Insert(2, "b")
+			sl.Insert(3, "c")
+
+			if n, err := sl.TrySearch(2); err != nil || n.Value() != "b" {
+				t.Errorf("TrySearch(2) = %v, %v", n, err)
+			}
+			if _, err := sl.TrySearch(4); !errors.Is(err, ErrKeyNotFound) {
+				t.Errorf("TrySearch(4) error = %v, want ErrKeyNotFound", err)
+			}
+
+			if err := sl.TryDelete(2); err != nil {
+				t.Errorf("TryDelete(2) = %v", err)
+			}
+			if err := sl.TryDelete(2); !errors.Is(err, ErrKeyNotFound) {
+				t.Errorf("second TryDelete(2) = %v, want ErrKeyNotFound", err)
+			}
+
+			var keys []int
+			err := sl.TryRangeQuery(1, 3, func(k int, _ string) bool {
+				keys = append(keys, k)
+				return true
+			})
+			if err != nil || len(keys) != 2 {
+				t.Errorf("TryRangeQuery(1, 3) = %v, visited %v", err, keys)
+			}
+			if err := sl.TryRangeQuery(3, 1, func(int, string) bool { return true }); !errors.Is(err, ErrInvalidRange) {
+				t.Errorf("TryRangeQuery(3, 1) = %v, want ErrInvalidRange", err)
+			}
+			if n, err := sl.TryCountRange(1, 3); err != nil || n != 2 {
+				t.Errorf("TryCountRange(1, 3) = %d, %v", n, err)
+			}
+			if _, err := sl.TryCountRange(3, 1); !errors.Is(err, ErrInvalidRange) {
+				t.Errorf("TryCountRange(3, 1) = %v, want ErrInvalidRange", err)
+			}
+
+			if n, err := sl.TryGetByRank(1); err != nil || n.Key() != 3 {
+				t.Errorf("TryGetByRank(1) = %v, %v", n, err)
+			}
+			for _, rank := range []int{-1, 2} {
+				if _, err := sl.TryGetByRank(rank); !errors.Is(err, ErrInvalidRange) {
+					t.Errorf("TryGetByRank(%d) = %v, want ErrInvalidRange", rank, err)
+				}
+			}
+		})
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	sl := New[int, string]()
+	sl.Insert(1, "a")
+	sl.Insert(2, "b")
+	var snap bytes.Buffer
+	if err := sl.Save(&snap); err != nil {
+		t.Fatal(err)
+	}
+	if sl.IsFrozen() {
+		t.Fatal("IsFrozen() = true before Freeze")
+	}
+	sl.Freeze()
+	if !sl.IsFrozen() {
+		t.Fatal("IsFrozen() = false after Freeze")
+	}
+
+	if _, err := sl.TryInsert(3, "c"); !errors.Is(err, ErrFrozen) {
+		t.Errorf("TryInsert() = %v, want ErrFrozen", err)
+	}
+	if err := sl.TryDelete(1); !errors.Is(err, ErrFrozen) {
+		t.Errorf("TryDelete() = %v, want ErrFrozen", err)
+	}
+	if _, err := sl.BulkLoad(func() (int, string, bool) { return 9, "z", true }); !errors.Is(err, ErrFrozen) {
+		t.Errorf("BulkLoad() = %v, want ErrFrozen", err)
+	}
+	if err := sl.Load(bytes.NewReader(snap.Bytes())); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Load() = %v, want ErrFrozen", err)
+	}
+
+	for name, op := range map[string]func(){
+		"Insert":       func() { sl.Insert(3, "c") },
+		"Delete":       func() { sl.Delete(1) },
+		"DeleteAbsent": func() { sl.Delete(7) },
+		"PopMin":       func() { sl.PopMin() },
+		"DeleteMax":    func() { sl.DeleteMax() },
+		"Clear":        func() { sl.Clear() },
+		"Rotate":       func() { sl.Rotate() },
+	} {
+		if r := catchPanic(op); r != ErrFrozen {
+			t.Errorf("%s on a frozen list panicked with %v, want ErrFrozen", name, r)
+		}
+	}
+
+	if sl.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", sl.Len())
+	}
+	if n, ok := sl.Search(2); !ok || n.Value() != "b" {
+		t.Errorf("Search(2) = %v, %v", n, ok)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFreeze_RotatedList(t *testing.T) {
+	sl := New[int, string]()
+	sl.Insert(1, "a")
+	frozen := sl.Rotate()
+	if !frozen.sl.IsFrozen() || sl.IsFrozen() {
+		t.Errorf("IsFrozen() = %v for the frozen list and %v for the source", frozen.sl.IsFrozen(), sl.IsFrozen())
+	}
+	sl.Insert(2, "b")
+	frozen.Release()
+	if frozen.Len() != 0 || sl.Len() != 1 {
+		t.Errorf("Len() = %d (frozen), %d (source)", frozen.Len(), sl.Len())
+	}
+}
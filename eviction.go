@@ -0,0 +1,127 @@
+package skiplist
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// EvictionPolicy chooses the entry evicted from a list created with
+// WithCapacity when an insert of a new key finds it full. Victim is called
+// with the write lock held and the number n > 0 of entries, and returns the
+// rank of the victim in [0, n); entry returns the key and value at a rank in
+// O(log n), or in O(n) on a list created with WithoutRankTracking. Victim
+// must not call back into the skiplist.
+// EvictionPolicy เลือกรายการที่จะถูกนำออกเมื่อ skiplist เต็มตาม WithCapacity
+type EvictionPolicy[K any, V any] interface {
+	Victim(n int, entry func(rank int) (K, V)) int
+}
+
+// EvictionFunc adapts a function to EvictionPolicy.
+type EvictionFunc[K any, V any] func(n int, entry func(rank int) (K, V)) int
+
+// Victim implements EvictionPolicy.
+func (f EvictionFunc[K, V]) Victim(n int, entry func(rank int) (K, V)) int {
+	return f(n, entry)
+}
+
+// EvictMin returns the policy evicting the entry with the smallest key.
+// EvictMin คืนค่า policy ที่นำรายการที่มี key น้อยที่สุดออก
+func EvictMin[K any, V any]() EvictionPolicy[K, V] {
+	return EvictionFunc[K, V](func(int, func(int) (K, V)) int { return 0 })
+}
+
+// EvictMax returns the policy evicting the entry with the largest key.
+// EvictMax คืนค่า policy ที่นำรายการที่มี key มากที่สุดออก
+func EvictMax[K any, V any]() EvictionPolicy[K, V] {
+	return EvictionFunc[K, V](func(n int, _ func(int) (K, V)) int { return n - 1 })
+}
+
+// WeightedRandomEviction returns a policy evicting a random entry with a
+// probability proportional to weight(key, value), for caches where strict
+// min/max eviction is wrong: weight the entries by their age to favour
+// evicting old ones without always evicting the oldest, or by the inverse of
+// their value to protect valuable ones. Negative and NaN weights count as zero; if
+// every candidate weighs zero, the victim is chosen uniformly.
+//
+// Weighing every entry costs O(n) per eviction, so the policy draws its
+// victim from samples candidates picked uniformly at random (with
+// replacement), in O(samples log n), like the sampled eviction of Redis.
+// samples <= 0 weighs every entry for an exact draw.
+// WeightedRandomEviction คืนค่า policy ที่สุ่มรายการที่จะนำออกด้วยความน่าจะเป็นตามน้ำหนัก
+// โดยสุ่มเลือกจากตัวอย่าง samples รายการ
+func WeightedRandomEviction[K any, V any](weight func(key K, value V) float64, samples int) EvictionPolicy[K, V] {
+	if weight == nil {
+		panic("skiplist: eviction weight function cannot be nil")
+	}
+	return EvictionFunc[K, V](func(n int, entry func(int) (K, V)) int {
+		count := samples
+		if count <= 0 || count > n {
+			count = n
+		}
+		ranks := make([]int, count)
+		weights := make([]float64, count)
+		var total float64
+		for i := range ranks {
+			if count == n {
+				ranks[i] = i
+			} else {
+				ranks[i] = rand.IntN(n)
+			}
+			if w := weight(entry(ranks[i])); w > 0 {
+				weights[i] = w
+				total += w
+			}
+		}
+		if total == 0 {
+			return ranks[rand.IntN(count)]
+		}
+		x := rand.Float64() * total
+		for i, w := range weights {
+			if x < w {
+				return ranks[i]
+			}
+			x -= w
+		}
+		// Rounding left x at or above the last positive weight.
+		for i := count - 1; ; i-- {
+			if weights[i] > 0 {
+				return ranks[i]
+			}
+		}
+	})
+}
+
+// WithCapacity bounds the list to max entries: an insert of a new key into a
+// full list evicts the entry chosen by policy (EvictMin if nil), which is
+// reported to OnDelete like any deletion. Updates of existing keys never
+// evict, nor do inserts rejected by a validator, a size limit or a full
+// WithFixedArena. The bound applies to the insert paths (Insert, TryInsert
+// and the writes built on them); BulkLoad and Load do not evict.
+// It panics if max is not positive.
+//
+// WithCapacity จำกัดจำนวนรายการไม่เกิน max โดยนำรายการที่ policy เลือกออกก่อนเพิ่ม key ใหม่
+func WithCapacity[K any, V any](max int, policy EvictionPolicy[K, V]) Option[K, V] {
+	if max <= 0 {
+		panic("skiplist: capacity must be positive")
+	}
+	if policy == nil {
+		policy = EvictMin[K, V]()
+	}
+	return func(sl *SkipList[K, V]) {
+		sl.capacity = max
+		sl.eviction = policy
+	}
+}
+
+// evict removes the entry chosen by the eviction policy. The caller must
+// hold the write lock of a non-empty list.
+func (sl *SkipList[K, V]) evict() {
+	rank := sl.eviction.Victim(sl.length, func(rank int) (K, V) {
+		n := sl.getByRank(rank)
+		return n.key, n.value
+	})
+	if rank < 0 || rank >= sl.length {
+		panic(fmt.Sprintf("skiplist: eviction policy chose rank %d of %d entries", rank, sl.length))
+	}
+	sl.delete(sl.getByRank(rank).key)
+}
@@ -0,0 +1,102 @@
+package skiplist
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestWithCapacity(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			var evicted []int
+			sl := setup.constructor(nil, WithCapacity(3, EvictMax[int, int]()),
+				WithHooks(Hooks[int, int]{OnDelete: func(k, _ int) { evicted = append(evicted, k) }}))
+			for _, k := range []int{5, 1, 9, 3} {
+				sl.Insert(k, k)
+			}
+			if sl.Len() != 3 || len(evicted) != 1 || evicted[0] != 9 {
+				t.Fatalf("Len() = %d, evicted %v; want 3 entries after evicting 9", sl.Len(), evicted)
+			}
+			// Updating an existing key does not evict.
+			sl.Insert(3, 30)
+			if sl.Len() != 3 || len(evicted) != 1 {
+				t.Errorf("update evicted: Len() = %d, evicted %v", sl.Len(), evicted)
+			}
+			if err := sl.CheckSpans(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	sl := New[int, int](WithCapacity[int, int](2, nil))
+	for k := 0; k < 10; k++ {
+		sl.Insert(k, k)
+	}
+	if first, _ := sl.Min(); sl.Len() != 2 || first.Key() != 8 {
+		t.Errorf("default policy kept %d entries from %d, want 8 and 9", sl.Len(), first.Key())
+	}
+}
+
+func TestWithCapacityFixedArena(t *testing.T) {
+	// A fixed arena never reclaims the nodes of evicted entries, so it
+	// eventually rejects an insert, which must then evict nothing.
+	sl := New[int, int](WithFixedArena[int, int](4096), WithCapacity[int, int](3, nil))
+	full := false
+	for k := 0; k < 1000 && !full; k++ {
+		before := sl.Len()
+		_, err := sl.TryInsert(k, k)
+		switch {
+		case errors.Is(err, ErrArenaFull):
+			full = true
+			if sl.Len() != before {
+				t.Fatalf("rejected insert of %d changed Len() from %d to %d", k, before, sl.Len())
+			}
+		case err != nil:
+			t.Fatal(err)
+		}
+	}
+	if !full || sl.Len() != 3 {
+		t.Fatalf("full = %v, Len() = %d, want a full arena holding 3 entries", full, sl.Len())
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWeightedRandomEviction(t *testing.T) {
+	const n = 100
+	// Weighing by key, like an age, evicts the high keys far more often than
+	// the low ones but not always the highest.
+	for _, samples := range []int{0, 16} {
+		policy := WeightedRandomEviction(func(k, _ int) float64 { return float64(k) }, samples)
+		entry := func(rank int) (int, int) { return rank, 0 }
+		var low, high, top int
+		for i := 0; i < 10000; i++ {
+			switch r := policy.Victim(n, entry); {
+			case r < 0 || r >= n:
+				t.Fatalf("samples %d: Victim() = %d", samples, r)
+			case r == n-1:
+				top++
+				fallthrough
+			case r >= n/2:
+				high++
+			default:
+				low++
+			}
+		}
+		if high < 2*low || top > 1000 {
+			t.Errorf("samples %d: %d victims in the low half, %d in the high half, %d at the top", samples, low, high, top)
+		}
+	}
+
+	// NaN and zero weights fall back to a uniform draw.
+	zero := WeightedRandomEviction(func(int, int) float64 { return math.NaN() }, 0)
+	seen := map[int]bool{}
+	for i := 0; i < 200; i++ {
+		seen[zero.Victim(4, func(r int) (int, int) { return r, 0 })] = true
+	}
+	if len(seen) != 4 {
+		t.Errorf("uniform fallback picked ranks %v", seen)
+	}
+}
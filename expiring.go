@@ -0,0 +1,248 @@
+package skiplist
+
+import "time"
+
+// ExpiringOptions configures NewExpiringIndex.
+// ExpiringOptions กำหนดค่าของ ExpiringIndex
+type ExpiringOptions struct {
+	// TTL is the lifetime given to the entries written with Set.
+	TTL time.Duration
+	// Sliding makes every successful Get push the deadline of the entry
+	// back to now plus its TTL (sliding expiration), so that entries expire
+	// after TTL without access rather than TTL after their write.
+	Sliding bool
+	// Now returns the current time (default time.Now). Tests can set it to
+	// a fake clock.
+	Now func() time.Time
+}
+
+// ExpiringIndex gives the entries of a skiplist a deadline, making it a
+// ready-made session or route cache with ordered primary keys. The deadlines
+// are kept in a secondary skiplist ordered by time, so that expiring the
+// entries that are due costs O(log n) each, without scanning the primary
+// list; Expire removes them, or Task runs Expire in the background with
+// StartMaintenance. Get never returns an entry past its deadline, even
+// before it is removed.
+//
+// Entries written to the skiplist directly have no deadline and never
+// expire; entries deleted from it directly leave a deadline behind that is
+// dropped when it falls due.
+//
+// All methods are safe for concurrent use.
+//
+// ExpiringIndex กำหนดเวลาหมดอายุให้รายการใน skiplist โดยเก็บเวลาหมดอายุไว้ใน skiplist รองที่เรียงตามเวลา
+// และรองรับการต่ออายุเมื่อมีการอ่าน (sliding expiration)
+type ExpiringIndex[K any, V any] struct {
+	sl   *SkipList[K, V]
+	opts ExpiringOptions
+
+	mu syncMutex
+	// deadlines maps each key to its deadline and TTL, byTime orders the
+	// keys by deadline.
+	deadlines *SkipList[K, expiry]
+	byTime    *SkipList[expiryKey[K], struct{}]
+}
+
+// expiry is the deadline of a key, in Unix nanoseconds, and the TTL that
+// sliding expiration renews.
+type expiry struct {
+	deadline int64
+	ttl      time.Duration
+}
+
+// expiryKey orders the keys of an ExpiringIndex by deadline, then by key.
+type expiryKey[K any] struct {
+	deadline int64
+	key      K
+}
+
+// NewExpiringIndex creates an ExpiringIndex storing its entries in sl.
+// It panics if opts.TTL is not positive.
+// NewExpiringIndex สร้าง ExpiringIndex ที่เก็บข้อมูลไว้ใน sl
+func NewExpiringIndex[K any, V any](sl *SkipList[K, V], opts ExpiringOptions) *ExpiringIndex[K, V] {
+	if opts.TTL <= 0 {
+		panic("skiplist: expiring index TTL must be positive")
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	compare := sl.compare
+	return &ExpiringIndex[K, V]{
+		sl:        sl,
+		opts:      opts,
+		deadlines: NewWithComparator[K, expiry](compare),
+		byTime: NewWithComparator[expiryKey[K], struct{}](func(a, b expiryKey[K]) int {
+			if a.deadline != b.deadline {
+				if a.deadline < b.deadline {
+					return -1
+				}
+				return 1
+			}
+			return compare(a.key, b.key)
+		}),
+	}
+}
+
+// List returns the skiplist holding the entries.
+func (x *ExpiringIndex[K, V]) List() *SkipList[K, V] { return x.sl }
+
+// Set writes key with the default TTL.
+// Set เขียน key พร้อมอายุตาม TTL ที่กำหนดไว้
+func (x *ExpiringIndex[K, V]) Set(key K, value V) {
+	x.SetWithTTL(key, value, x.opts.TTL)
+}
+
+// SetWithTTL writes key with a lifetime of ttl, replacing the deadline of a
+// previous write. With sliding expiration, Get renews ttl rather than the
+// default TTL.
+// SetWithTTL เขียน key พร้อมอายุ ttl
+func (x *ExpiringIndex[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.sl.Insert(key, value)
+	x.schedule(key, ttl)
+}
+
+// Get returns the value of key if it has not expired. With sliding
+// expiration, it also renews the deadline of the entry. An expired entry
+// found by Get is removed.
+// Get คืนค่า value ของ key ที่ยังไม่หมดอายุ และต่ออายุหากใช้ sliding expiration
+func (x *ExpiringIndex[K, V]) Get(key K) (V, bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	var zero V
+	n, ok := x.sl.Search(key)
+	if !ok {
+		return zero, false
+	}
+	if e, ok := x.lookup(key); ok {
+		if e.deadline <= x.opts.Now().UnixNano() {
+			x.remove(key, e)
+			x.sl.Delete(key)
+			return zero, false
+		}
+		if x.opts.Sliding {
+			x.schedule(key, e.ttl)
+		}
+	}
+	return n.Value(), true
+}
+
+// Touch renews the deadline of key to now plus its TTL, as a sliding Get
+// would, and reports whether key has an unexpired deadline.
+// Touch ต่ออายุของ key และคืนค่า false หาก key ไม่มีอยู่หรือหมดอายุแล้ว
+func (x *ExpiringIndex[K, V]) Touch(key K) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	e, ok := x.lookup(key)
+	if !ok || e.deadline <= x.opts.Now().UnixNano() {
+		return false
+	}
+	x.schedule(key, e.ttl)
+	return true
+}
+
+// Deadline returns the time at which key expires, and false if key has no
+// deadline.
+// Deadline คืนค่าเวลาที่ key จะหมดอายุ
+func (x *ExpiringIndex[K, V]) Deadline(key K) (time.Time, bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	e, ok := x.lookup(key)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, e.deadline), true
+}
+
+// NextExpiry returns the earliest deadline, and false if no entry has one.
+// NextExpiry คืนค่าเวลาหมดอายุที่เร็วที่สุด
+func (x *ExpiringIndex[K, V]) NextExpiry() (time.Time, bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	n, ok := x.byTime.Min()
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, n.Key().deadline), true
+}
+
+// Delete removes key and its deadline, and reports whether key was present.
+// Delete ลบ key และเวลาหมดอายุของมัน
+func (x *ExpiringIndex[K, V]) Delete(key K) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if e, ok := x.lookup(key); ok {
+		x.remove(key, e)
+	}
+	return x.sl.Delete(key)
+}
+
+// Expire removes every entry whose deadline has passed, in deadline order,
+// and returns the number of entries removed.
+// Expire ลบรายการที่หมดอายุแล้วทั้งหมด และคืนค่าจำนวนรายการที่ถูกลบ
+func (x *ExpiringIndex[K, V]) Expire() int {
+	removed, _ := x.expire(-1)
+	return removed
+}
+
+// Task returns a maintenance task running Expire every interval, at most
+// budget entries per slice, for StartMaintenance on the skiplist of the
+// index.
+// Task คืนค่างานเบื้องหลังที่เรียก Expire ทุก interval สำหรับใช้กับ StartMaintenance
+func (x *ExpiringIndex[K, V]) Task(name string, interval time.Duration) MaintenanceTask[K, V] {
+	return MaintenanceTask[K, V]{
+		Name:     name,
+		Interval: interval,
+		Step: func(_ *SkipList[K, V], budget int) bool {
+			_, done := x.expire(budget)
+			return done
+		},
+	}
+}
+
+// expire removes up to budget due entries (all of them if budget < 0) and
+// reports whether none is left.
+func (x *ExpiringIndex[K, V]) expire(budget int) (int, bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	now := x.opts.Now().UnixNano()
+	removed := 0
+	for budget < 0 || removed < budget {
+		n, ok := x.byTime.Min()
+		if !ok || n.Key().deadline > now {
+			return removed, true
+		}
+		ek := n.Key()
+		x.byTime.Delete(ek)
+		x.deadlines.Delete(ek.key)
+		x.sl.Delete(ek.key)
+		removed++
+	}
+	return removed, false
+}
+
+// schedule sets the deadline of key to now plus ttl. x.mu must be held.
+func (x *ExpiringIndex[K, V]) schedule(key K, ttl time.Duration) {
+	if e, ok := x.lookup(key); ok {
+		x.byTime.Delete(expiryKey[K]{deadline: e.deadline, key: key})
+	}
+	deadline := x.opts.Now().Add(ttl).UnixNano()
+	x.deadlines.Insert(key, expiry{deadline: deadline, ttl: ttl})
+	x.byTime.Insert(expiryKey[K]{deadline: deadline, key: key}, struct{}{})
+}
+
+// remove drops the deadline e of key. x.mu must be held.
+func (x *ExpiringIndex[K, V]) remove(key K, e expiry) {
+	x.byTime.Delete(expiryKey[K]{deadline: e.deadline, key: key})
+	x.deadlines.Delete(key)
+}
+
+// lookup returns the deadline of key. x.mu must be held.
+func (x *ExpiringIndex[K, V]) lookup(key K) (expiry, bool) {
+	n, ok := x.deadlines.Search(key)
+	if !ok {
+		return expiry{}, false
+	}
+	return n.Value(), true
+}
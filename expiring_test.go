@@ -0,0 +1,91 @@
+package skiplist
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestExpiringIndex_FixedTTL(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1000, 0)}
+	sl := New[string, int]()
+	x := NewExpiringIndex(sl, ExpiringOptions{TTL: time.Minute, Now: clock.now})
+
+	x.Set("a", 1)
+	x.SetWithTTL("b", 2, 3*time.Minute)
+	sl.Insert("forever", 0)
+	if d, ok := x.NextExpiry(); !ok || !d.Equal(clock.t.Add(time.Minute)) {
+		t.Errorf("NextExpiry() = %v, %v", d, ok)
+	}
+
+	clock.advance(59 * time.Second)
+	if v, ok := x.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %v, %v before its deadline", v, ok)
+	}
+	clock.advance(time.Second)
+	// Without sliding expiration, the read did not renew the deadline.
+	if _, ok := x.Get("a"); ok {
+		t.Error("Get(a) found the entry at its deadline")
+	}
+	if _, ok := sl.Search("a"); ok {
+		t.Error("expired entry found by Get was not removed")
+	}
+
+	clock.advance(5 * time.Minute)
+	if n := x.Expire(); n != 1 {
+		t.Errorf("Expire() = %d, want 1", n)
+	}
+	if sl.Len() != 1 {
+		t.Errorf("Len() = %d, want only the entry without deadline", sl.Len())
+	}
+	if _, ok := x.NextExpiry(); ok {
+		t.Error("NextExpiry() reports a deadline after everything expired")
+	}
+}
+
+func TestExpiringIndex_Sliding(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1000, 0)}
+	sl := New[int, string]()
+	x := NewExpiringIndex(sl, ExpiringOptions{TTL: time.Minute, Sliding: true, Now: clock.now})
+
+	for i := 0; i < 10; i++ {
+		x.Set(i, "session")
+	}
+	// Keep the even sessions alive past several TTLs.
+	for step := 0; step < 5; step++ {
+		clock.advance(40 * time.Second)
+		for i := 0; i < 10; i += 2 {
+			if _, ok := x.Get(i); !ok {
+				t.Fatalf("step %d: session %d expired while in use", step, i)
+			}
+		}
+		x.Expire()
+	}
+	if sl.Len() != 5 {
+		t.Errorf("Len() = %d, want the 5 active sessions", sl.Len())
+	}
+	if d, ok := x.Deadline(4); !ok || !d.Equal(clock.t.Add(time.Minute)) {
+		t.Errorf("Deadline(4) = %v, %v", d, ok)
+	}
+
+	clock.advance(50 * time.Second)
+	if !x.Touch(0) || x.Touch(1) {
+		t.Error("Touch did not report the live and expired keys")
+	}
+	if !x.Delete(2) || x.Delete(2) {
+		t.Error("Delete(2) did not remove the key once")
+	}
+	clock.advance(20 * time.Second)
+	task := x.Task("expire", time.Second)
+	if done := task.Step(sl, 1); done {
+		t.Error("Step with budget 1 finished with 2 entries due")
+	}
+	task.Step(sl, 10)
+	if sl.Len() != 1 {
+		t.Errorf("Len() = %d, want only the touched session", sl.Len())
+	}
+}
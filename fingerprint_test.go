@@ -0,0 +1,49 @@
+package skiplist
+
+import "testing"
+
+func TestFingerprintRange(t *testing.T) {
+	hash := func(k, v int) uint64 { return uint64(k)<<32 | uint64(uint32(v)) }
+	a, b := New[int, int](), New[int, int]()
+	for i := 0; i < 100; i++ {
+		a.Insert(i, i)
+		b.Insert(99-i, 99-i) // same entries, other insertion order
+	}
+
+	if fa, fb := a.FingerprintRange(0, 99, hash), b.FingerprintRange(0, 99, hash); fa != fb {
+		t.Fatalf("equal replicas have fingerprints %x and %x", fa, fb)
+	}
+	empty := New[int, int]().FingerprintRange(0, 99, hash)
+	if got := a.FingerprintRange(200, 300, hash); got != empty {
+		t.Errorf("empty range fingerprint = %x, want %x", got, empty)
+	}
+	if got := a.FingerprintRange(50, 10, hash); got != empty {
+		t.Errorf("inverted range fingerprint = %x, want %x", got, empty)
+	}
+
+	// Bisect down to the single diverging key.
+	b.Insert(42, -1)
+	lo, hi := 0, 99
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if a.FingerprintRange(lo, mid, hash) != b.FingerprintRange(lo, mid, hash) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	if lo != 42 {
+		t.Errorf("bisection located key %d, want 42", lo)
+	}
+
+	// The fingerprint depends on the order of the hashes.
+	c, d := New[int, int](), New[int, int]()
+	c.Insert(1, 2)
+	c.Insert(2, 1)
+	d.Insert(1, 1)
+	d.Insert(2, 2)
+	valueOnly := func(_, v int) uint64 { return uint64(v) }
+	if c.FingerprintRange(0, 3, valueOnly) == d.FingerprintRange(0, 3, valueOnly) {
+		t.Error("swapping two values did not change the fingerprint")
+	}
+}
@@ -0,0 +1,54 @@
+package skiplist
+
+import (
+	"cmp"
+	"math"
+)
+
+// WithTotalOrderFloats orders float keys by the totalOrder predicate of IEEE
+// 754 instead of the comparator given at construction:
+//
+//	-NaN < -Inf < ... < -0 < +0 < ... < +Inf < +NaN
+//
+// Every float value, including NaN, then has a well-defined place, so a stray
+// NaN cannot break the ordering invariants even when the comparator passed to
+// NewWithComparator is built on < (which is false for every comparison with
+// NaN). -0 and +0 are different keys, and NaNs are equal only if their bits
+// are: math.NaN() sorts after +Inf.
+//
+// New[float64] is NaN-safe as well: cmp.Compare sorts NaN before every other
+// value and treats -0 and +0 as the same key.
+//
+// The option replaces the comparator, so it must come before the options that
+// build companion lists from it (WithMVCC, WithLWW, WithChangeTracking,
+// WithSecondaryIndex); it panics otherwise.
+//
+// WithTotalOrderFloats เรียงลำดับ key ที่เป็น float ตาม totalOrder ของ IEEE 754
+// ทำให้ NaN มีตำแหน่งที่แน่นอนและไม่ทำให้โครงสร้างเสียหาย
+func WithTotalOrderFloats[K ~float32 | ~float64, V any]() Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		if sl.history != nil || sl.lww != nil || sl.changes != nil || sl.secondary != nil {
+			panic("skiplist: WithTotalOrderFloats must come before WithMVCC, WithLWW, WithChangeTracking and WithSecondaryIndex")
+		}
+		sl.compare = CompareTotalOrder[K]
+	}
+}
+
+// CompareTotalOrder is the Comparator of WithTotalOrderFloats, for the APIs
+// that take a comparator.
+// CompareTotalOrder เปรียบเทียบ float ตาม totalOrder ของ IEEE 754
+func CompareTotalOrder[K ~float32 | ~float64](a, b K) int {
+	// Converting a float32 to float64 is exact and keeps its sign and NaN
+	// payload, so both widths share one implementation.
+	return cmp.Compare(totalOrderBits(float64(a)), totalOrderBits(float64(b)))
+}
+
+// totalOrderBits maps f to an unsigned integer in totalOrder: the bits of
+// negative values are inverted, and the sign bit of the others is set.
+func totalOrderBits(f float64) uint64 {
+	b := math.Float64bits(f)
+	if b>>63 != 0 {
+		return ^b
+	}
+	return b | 1<<63
+}
@@ -0,0 +1,86 @@
+package skiplist
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestWithTotalOrderFloats(t *testing.T) {
+	negNaN := math.Copysign(math.NaN(), -1)
+	negZero := math.Copysign(0, -1)
+	inOrder := []float64{negNaN, math.Inf(-1), -1.5, -math.SmallestNonzeroFloat64, negZero, 0,
+		math.SmallestNonzeroFloat64, 2, math.MaxFloat64, math.Inf(1), math.NaN()}
+
+	// A comparator built on < misplaces NaN; the option replaces it.
+	naive := func(a, b float64) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	sl := NewWithComparator(naive, WithTotalOrderFloats[float64, int]())
+	for _, i := range []int{10, 3, 7, 0, 5, 9, 1, 4, 8, 2, 6} {
+		sl.Insert(inOrder[i], i)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if sl.Len() != len(inOrder) {
+		t.Fatalf("Len() = %d, want %d", sl.Len(), len(inOrder))
+	}
+	i := 0
+	sl.Range(func(k float64, v int) bool {
+		if v != i || math.Float64bits(k) != math.Float64bits(inOrder[i]) {
+			t.Errorf("entry %d = (%v, %d), want (%v, %d)", i, k, v, inOrder[i], i)
+		}
+		i++
+		return true
+	})
+	for i, k := range inOrder {
+		if n, ok := sl.Search(k); !ok || n.Value() != i {
+			t.Errorf("Search(%v) = %v, %v", k, n, ok)
+		}
+	}
+	if !sl.Delete(math.NaN()) || !sl.Delete(negZero) || sl.Len() != len(inOrder)-2 {
+		t.Errorf("Delete(NaN), Delete(-0) failed, Len() = %d", sl.Len())
+	}
+	if n, ok := sl.Search(0); !ok || n.Value() != 5 {
+		t.Errorf("Search(+0) after Delete(-0) = %v, %v", n, ok)
+	}
+
+	if CompareTotalOrder[float32](float32(math.Inf(1)), float32(math.NaN())) >= 0 {
+		t.Error("CompareTotalOrder[float32](+Inf, NaN) >= 0")
+	}
+	if r := catchPanic(func() {
+		New[float64, int](WithMVCC[float64, int](), WithTotalOrderFloats[float64, int]())
+	}); r == nil {
+		t.Error("WithTotalOrderFloats after WithMVCC did not panic")
+	}
+}
+
+func TestFloatKeys_DefaultOrder(t *testing.T) {
+	// cmp.Compare sorts NaN first and treats -0 and +0 as one key.
+	sl := New[float64, string]()
+	sl.Insert(math.Inf(1), "+inf")
+	sl.Insert(math.NaN(), "nan")
+	sl.Insert(0, "+0")
+	sl.Insert(math.Copysign(0, -1), "-0")
+	sl.Insert(math.Inf(-1), "-inf")
+	sl.Insert(math.NaN(), "nan2")
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	sl.Range(func(_ float64, v string) bool {
+		got = append(got, v)
+		return true
+	})
+	want := []string{"nan2", "-inf", "-0", "+inf"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Range() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,156 @@
+package skiplist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// formatEdge is the number of entries printed at each end of the list by
+// String and GoString.
+const formatEdge = 3
+
+// String implements fmt.Stringer with a one-line summary of the list: its
+// length, levels, allocator and its first and last few entries, e.g.
+//
+//	SkipList{len: 100, levels: 4, allocator: pool, entries: [0:a 1:b 2:c ... 97:x 98:y 99:z]}
+//
+// It takes the read lock, so it must not be called from a hook or from a
+// callback running under the write lock.
+// String คืนค่าสรุปของ skiplist ในบรรทัดเดียว สำหรับใช้ใน log
+func (sl *SkipList[K, V]) String() string {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "SkipList{len: %d, levels: %d, allocator: %s, entries: ", sl.length, sl.level+1, sl.allocatorName())
+	b.WriteString("[")
+	sl.formatEntries(&b, "%v:%v", " ")
+	b.WriteString("]}")
+	return b.String()
+}
+
+// GoString implements fmt.GoStringer, used by %#v, with the structural
+// details of the list: its type, version, number of nodes per level, enabled
+// features and its first and last few entries. It walks every node once, so
+// it is O(n), and takes the read lock like String.
+// GoString คืนค่ารายละเอียดโครงสร้างของ skiplist สำหรับ %#v
+func (sl *SkipList[K, V]) GoString() string {
+	st := sl.Stats()
+
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%T{Len: %d, Levels: %d, Version: %d, LevelCounts: %#v, Allocator: %q",
+		sl, st.Len, st.Levels, st.Version, st.LevelCounts, st.Allocator)
+	if st.Allocator == "arena" {
+		fmt.Fprintf(&b, ", ArenaChunks: %d, ArenaCapacity: %d, ArenaUsed: %d", st.ArenaChunks, st.ArenaCapacity, st.ArenaUsed)
+	}
+	fmt.Fprintf(&b, ", Features: %#v, Entries: {", sl.features())
+	sl.formatEntries(&b, "%#v: %#v", ", ")
+	b.WriteString("}}")
+	return b.String()
+}
+
+// formatEntries writes the first and last formatEdge entries, or all of them
+// if there are few, separated by sep and each formatted with format.
+// The caller must hold a lock.
+func (sl *SkipList[K, V]) formatEntries(b *strings.Builder, format, sep string) {
+	if sl.length == 0 {
+		return
+	}
+	head := sl.length
+	if head > 2*formatEdge {
+		head = formatEdge
+	}
+	n := sl.header.forward[0]
+	for i := 0; i < head; i, n = i+1, n.forward[0] {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		fmt.Fprintf(b, format, n.key, n.value)
+	}
+	if head == sl.length {
+		return
+	}
+	b.WriteString(sep + "..." + sep)
+	// Walk back from the last node to print the tail in key order.
+	tail := sl.last()
+	for i := 1; i < formatEdge; i++ {
+		tail = tail.backward
+	}
+	for i := 0; i < formatEdge; i, tail = i+1, tail.forward[0] {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		fmt.Fprintf(b, format, tail.key, tail.value)
+	}
+}
+
+// features lists the optional features the list was created with.
+// The caller must hold a lock.
+func (sl *SkipList[K, V]) features() []string {
+	var f []string
+	add := func(on bool, name string) {
+		if on {
+			f = append(f, name)
+		}
+	}
+	add(sl.byteKeys, "bytes")
+	add(sl.keyPrefix != nil, "keyPrefix")
+	add(sl.backLinks, "bidirectional")
+	add(sl.weight != nil, "weights")
+	add(sl.merkle != nil, "merkle")
+	add(sl.history != nil, "mvcc")
+	add(sl.lww != nil, "lww")
+	add(sl.changes != nil, "changeTracking")
+	add(sl.secondary != nil, "secondaryIndex")
+	add(sl.ids != nil, "idIndex")
+	add(sl.secureWipe, "secureWipe")
+	add(sl.hot != nil, "hotCache")
+	add(sl.validateKey != nil, "keyValidator")
+	add(sl.watermarks != nil, "usageWatermark")
+	add(sl.tracer != nil, "tracer")
+	add(sl.lockAudit != nil, "lockAudit")
+	add(sl.latency != nil, "latencySampling")
+	add(sl.frozen, "frozen")
+	return f
+}
+
+// Dump writes a human-readable description of the list to w: a summary line
+// as printed by GoString without the entries, then one line per entry with
+// its height, in ascending key order. At most limit entries are written, all
+// of them if limit <= 0; a final line counts the entries left out.
+//
+// Unlike printing the struct with %+v, Dump reads the list under the read
+// lock, so it is safe while other goroutines modify it. The lock is held
+// while writing to w.
+// Dump เขียนรายละเอียดของ skiplist ลงใน w อย่างปลอดภัย โดยแสดงไม่เกิน limit รายการ (limit <= 0 คือทั้งหมด)
+func (sl *SkipList[K, V]) Dump(w io.Writer, limit int) error {
+	st := sl.Stats()
+
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%T len=%d levels=%d version=%d allocator=%s level_counts=%v",
+		sl, st.Len, st.Levels, st.Version, st.Allocator, st.LevelCounts)
+	if st.Allocator == "arena" {
+		fmt.Fprintf(bw, " arena_used=%d/%d", st.ArenaUsed, st.ArenaCapacity)
+	}
+	if f := sl.features(); len(f) > 0 {
+		fmt.Fprintf(bw, " features=%s", strings.Join(f, ","))
+	}
+	bw.WriteString("\n")
+	written := 0
+	for n := sl.header.forward[0]; n != nil && (limit <= 0 || written < limit); n = n.forward[0] {
+		fmt.Fprintf(bw, "%v: %v (height %d)\n", n.key, n.value, len(n.forward))
+		written++
+	}
+	if rest := sl.length - written; rest > 0 {
+		fmt.Fprintf(bw, "... %d more entries\n", rest)
+	}
+	return bw.Flush()
+}
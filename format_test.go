@@ -0,0 +1,102 @@
+package skiplist
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSkipList_String(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			want := fmt.Sprintf("SkipList{len: 0, levels: 1, allocator: %s, entries: []}", sl.Stats().Allocator)
+			if got := fmt.Sprint(sl); got != want {
+				t.Errorf("empty list: %q, want %q", got, want)
+			}
+
+			for i := 0; i < 6; i++ {
+				sl.Insert(i, fmt.Sprint("v", i))
+			}
+			if got := fmt.Sprint(sl); !strings.HasSuffix(got, "entries: [0:v0 1:v1 2:v2 3:v3 4:v4 5:v5]}") {
+				t.Errorf("6 entries: %q", got)
+			}
+
+			for i := 6; i < 100; i++ {
+				sl.Insert(i, fmt.Sprint("v", i))
+			}
+			got := fmt.Sprintf("%v", sl)
+			if !strings.HasPrefix(got, "SkipList{len: 100, ") ||
+				!strings.HasSuffix(got, "entries: [0:v0 1:v1 2:v2 ... 97:v97 98:v98 99:v99]}") {
+				t.Errorf("100 entries: %q", got)
+			}
+		})
+	}
+}
+
+func TestSkipList_GoString(t *testing.T) {
+	sl := New[int, string](WithArena[int, string](1<<12), WithWeights[int, string](func(int, string) int { return 1 }))
+	for i := 0; i < 10; i++ {
+		sl.Insert(i, fmt.Sprint("v", i))
+	}
+	sl.Freeze()
+	got := fmt.Sprintf("%#v", sl)
+	for _, want := range []string{
+		"*skiplist.SkipList[int,string]{Len: 10, ",
+		"Version: 10, ",
+		`Allocator: "arena", ArenaChunks: `,
+		`Features: []string{"weights", "frozen"}`,
+		`Entries: {0: "v0", 1: "v1", 2: "v2", ..., 7: "v7", 8: "v8", 9: "v9"}}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("%%#v = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestSkipList_Dump(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			for i := 0; i < 10; i++ {
+				sl.Insert(i, fmt.Sprint("v", i))
+			}
+
+			var b strings.Builder
+			if err := sl.Dump(&b, 3); err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+			if len(lines) != 5 {
+				t.Fatalf("Dump(3) wrote %d lines, want 5:\n%s", len(lines), b.String())
+			}
+			if !strings.HasPrefix(lines[0], "*skiplist.SkipList[int,string] len=10 ") {
+				t.Errorf("summary = %q", lines[0])
+			}
+			if !strings.HasPrefix(lines[1], "0: v0 (height ") || !strings.HasPrefix(lines[3], "2: v2 (height ") {
+				t.Errorf("entries = %q", lines[1:4])
+			}
+			if lines[4] != "... 7 more entries" {
+				t.Errorf("last line = %q", lines[4])
+			}
+
+			b.Reset()
+			if err := sl.Dump(&b, 0); err != nil {
+				t.Fatal(err)
+			}
+			if n := strings.Count(b.String(), "\n"); n != 11 {
+				t.Errorf("Dump(0) wrote %d lines, want 11", n)
+			}
+		})
+	}
+}
+
+func TestSkipList_DumpWriteError(t *testing.T) {
+	sl := New[int, int]()
+	sl.Insert(1, 1)
+	if err := sl.Dump(failingWriter{}, 0); err != errWrite {
+		t.Errorf("Dump() = %v, want %v", err, errWrite)
+	}
+	// The read lock was released.
+	sl.Insert(2, 2)
+}
@@ -0,0 +1,30 @@
+package skiplist
+
+// Freeze makes the list read-only, permanently. Afterwards the methods that
+// report errors (TryInsert, TryDelete, BulkLoad, Load, ApplyDelta) return
+// ErrFrozen, and the other methods that modify the list (Insert, Delete,
+// PopMin, Clear, Rotate, ...) panic with ErrFrozen, leaving it unchanged.
+// Reads are unaffected. The list held by a FrozenSkipList is frozen.
+// Freeze ทำให้ skiplist เป็นแบบอ่านอย่างเดียวอย่างถาวร การแก้ไขหลังจากนี้จะได้ ErrFrozen
+func (sl *SkipList[K, V]) Freeze() {
+	sl.mutex.Lock()
+	defer sl.mutex.Unlock()
+	sl.frozen = true
+}
+
+// IsFrozen reports whether Freeze was called.
+// IsFrozen คืนค่า true หาก skiplist ถูก Freeze แล้ว
+func (sl *SkipList[K, V]) IsFrozen() bool {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+	return sl.frozen
+}
+
+// mustNotBeFrozen panics with ErrFrozen if the list is frozen. Every write
+// path calls it before modifying anything. The caller must hold the write
+// lock.
+func (sl *SkipList[K, V]) mustNotBeFrozen() {
+	if sl.frozen {
+		panic(ErrFrozen)
+	}
+}
@@ -0,0 +1,71 @@
+package skiplist
+
+import (
+	"log"
+	"time"
+)
+
+// GrowthReason tells why an arena added a chunk.
+type GrowthReason uint8
+
+const (
+	// GrowthFirstChunk is the allocation of the first chunk of a node size
+	// class, which is deferred until the first node of the class is needed.
+	GrowthFirstChunk GrowthReason = iota
+	// GrowthChunkFull is the allocation of a further chunk because the last
+	// one of the size class was exhausted.
+	GrowthChunkFull
+)
+
+// String returns the name of the reason, e.g. "chunk full".
+func (r GrowthReason) String() string {
+	switch r {
+	case GrowthFirstChunk:
+		return "first chunk"
+	case GrowthChunkFull:
+		return "chunk full"
+	}
+	return "GrowthReason(unknown)"
+}
+
+// ArenaGrowth describes a chunk added to the arena of a skiplist.
+// ArenaGrowth คือข้อมูลการขยาย Arena แต่ละครั้ง
+type ArenaGrowth struct {
+	// OldSize and NewSize are the bytes held by all the chunks of the arena
+	// before and after the chunk was added.
+	OldSize, NewSize int
+	// Blocks is the number of nodes that fit in the new chunk.
+	Blocks int
+	Reason GrowthReason
+	// Duration is the time spent allocating the chunk, by which the insert
+	// that triggered the growth was stalled.
+	Duration time.Duration
+}
+
+// WithGrowthObserver calls fn every time the arena adds a chunk, with the
+// size of the arena before and after and the reason of the growth, so that
+// an initial size or growth strategy that does not fit the workload shows
+// up in production logs: an arena that keeps growing by small chunks stalls
+// many inserts. If fn is nil, growths are logged with the log package.
+//
+// fn is called by the insert that triggered the growth, with the write lock
+// held (or with a lock of the list during MigrateAllocator): like a hook, it must
+// be fast and must not call back into the list. The observer is kept by the
+// arenas created later by Rotate and MigrateAllocator. It has no effect on
+// pool-backed lists.
+// WithGrowthObserver เรียก fn ทุกครั้งที่ Arena ขยาย พร้อมขนาดก่อนและหลัง และสาเหตุของการขยาย
+func WithGrowthObserver[K any, V any](fn func(ArenaGrowth)) Option[K, V] {
+	if fn == nil {
+		fn = logArenaGrowth
+	}
+	return func(sl *SkipList[K, V]) {
+		sl.growth = func(g ArenaGrowth) {
+			defer rethrowCallbackPanic("GrowthObserver")
+			fn(g)
+		}
+	}
+}
+
+func logArenaGrowth(g ArenaGrowth) {
+	log.Printf("skiplist: arena grew from %d to %d bytes (%s, %d nodes) in %v", g.OldSize, g.NewSize, g.Reason, g.Blocks, g.Duration)
+}
@@ -0,0 +1,73 @@
+package skiplist
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithGrowthObserver(t *testing.T) {
+	var growths []ArenaGrowth
+	observe := WithGrowthObserver[int, int](func(g ArenaGrowth) { growths = append(growths, g) })
+	sl := New[int, int](WithArena[int, int](4096), observe)
+	for i := 0; i < 2000; i++ {
+		sl.Insert(i, i)
+	}
+	if len(growths) == 0 {
+		t.Fatal("observer not called")
+	}
+	if growths[0].Reason != GrowthFirstChunk || growths[0].OldSize != 0 {
+		t.Errorf("first growth = %+v, want a first chunk from 0 bytes", growths[0])
+	}
+	full := 0
+	for i, g := range growths {
+		if g.NewSize <= g.OldSize || g.Blocks < 1 {
+			t.Errorf("growth %d = %+v", i, g)
+		}
+		if i > 0 && g.OldSize != growths[i-1].NewSize {
+			t.Errorf("growth %d starts at %d bytes, previous ended at %d", i, g.OldSize, growths[i-1].NewSize)
+		}
+		if g.Reason == GrowthChunkFull {
+			full++
+		}
+	}
+	if full == 0 {
+		t.Error("no growth reported as chunk full")
+	}
+	if last, a := growths[len(growths)-1], sl.allocator.(*arenaAllocator[int, int]); last.NewSize != a.allocated {
+		t.Errorf("last growth ends at %d bytes, arena holds %d", last.NewSize, a.allocated)
+	}
+
+	// The observer carries over to the arena of a migration.
+	growths = nil
+	if err := sl.MigrateAllocator(WithArena[int, int](4096)); err != nil {
+		t.Fatal(err)
+	}
+	if len(growths) == 0 {
+		t.Error("observer not called during MigrateAllocator")
+	}
+
+	// Pool-backed lists never report growth.
+	growths = nil
+	pool := New[int, int](observe)
+	pool.Insert(1, 1)
+	if len(growths) != 0 {
+		t.Errorf("pool-backed list reported %d growths", len(growths))
+	}
+}
+
+func TestWithGrowthObserverPanic(t *testing.T) {
+	boom := errors.New("boom")
+	sl := New[int, int](WithArena[int, int](4096), WithGrowthObserver[int, int](func(ArenaGrowth) { panic(boom) }))
+	func() {
+		defer func() {
+			p, ok := recover().(*CallbackPanic)
+			if !ok || p.API != "GrowthObserver" || !errors.Is(p, boom) {
+				t.Fatalf("recovered %v, want a GrowthObserver CallbackPanic", p)
+			}
+		}()
+		sl.Insert(1, 1)
+	}()
+	if err := sl.Validate(); err != nil || sl.Len() != 0 {
+		t.Fatalf("after panic: Len() = %d, Validate() = %v", sl.Len(), err)
+	}
+}
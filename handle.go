@@ -0,0 +1,202 @@
+package skiplist
+
+// DeleteNode removes the entry of n, a node returned by Search, RangeNodes or
+// another API of this list, and reports whether it did. It returns false if n
+// is not a node of this list or its entry was already deleted, as long as
+// the node was not reused since for another entry (see RangeNodes). With
+// WithBidirectionalLevels the node is unlinked by climbing its backward links,
+// without comparing keys; otherwise a single descent locates n by identity.
+// Hooks, history and the other options observe the deletion as with Delete.
+// DeleteNode ลบรายการของโหนด n ที่ได้จาก Search หรือ RangeNodes โดยไม่ต้องค้นหาด้วย key ซ้ำ
+func (sl *SkipList[K, V]) DeleteNode(n INode[K, V]) bool {
+	tr := sl.traceStart(OpDelete)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	target, ok := n.(*node[K, V])
+	if !ok || target == nil || !sl.unlink(target) {
+		return false
+	}
+	tr.keys = 1
+	return true
+}
+
+// unlink deletes the entry of n if n is linked in this list and reports
+// whether it did. The caller must hold the write lock.
+func (sl *SkipList[K, V]) unlink(n *node[K, V]) bool {
+	if n.gen == 0 || n.backward == nil {
+		return false
+	}
+	update := sl.updateCache
+	var ok bool
+	if sl.backLinks {
+		ok = sl.climbPath(n, update)
+	} else {
+		ok = sl.descendPath(n, update)
+	}
+	if !ok {
+		return false
+	}
+	sl.deleteNode(n, update)
+	return true
+}
+
+// climbPath fills update with the last node before n at every level using
+// the backward links of WithBidirectionalLevels, and reports whether n is
+// linked in this list. The caller must hold a lock.
+func (sl *SkipList[K, V]) climbPath(n *node[K, V], update []INode[K, V]) bool {
+	if len(n.back) != len(n.forward) {
+		return false
+	}
+	x := n
+	for i := 0; i <= sl.level; i++ {
+		if i < len(n.forward) {
+			x = n.back[i]
+			if x == nil || len(x.forward) <= i || x.forward[i] != n {
+				return false
+			}
+		} else {
+			// The nodes skipped by the backward link of the top level of x
+			// are all lower than x.
+			for len(x.forward) <= i {
+				top := len(x.forward) - 1
+				if len(x.back) <= top || x.back[top] == nil {
+					return false
+				}
+				x = x.back[top]
+			}
+		}
+		update[i] = x
+	}
+	// n is in this list if the top level leads back to its header.
+	for x != sl.header {
+		if len(x.back) <= sl.level || x.back[sl.level] == nil {
+			return false
+		}
+		x = x.back[sl.level]
+	}
+	return true
+}
+
+// descendPath fills update with the last node before n at every level by
+// descending to the key of n, and reports whether n is linked in this list.
+// The caller must hold a lock.
+func (sl *SkipList[K, V]) descendPath(n *node[K, V], update []INode[K, V]) bool {
+	kp := sl.prefixOf(n.key)
+	current := sl.header
+	for i := sl.level; i >= 0; i-- {
+		for next := current.forward[i]; next != nil && next != n && sl.compareNode(next, n.key, kp) < 0; next = current.forward[i] {
+			current = next
+		}
+		update[i] = current
+	}
+	return current.forward[0] == n
+}
+
+// Handle is a long-lived reference to an entry of a SkipList. Unlike a node
+// (INode), whose memory is reused for other entries once its entry is
+// deleted, a Handle knows when its entry is gone: every node records a
+// generation stamp when it receives an entry, and Valid compares it to the
+// one taken by the handle. A Handle is invalidated by the deletion of its
+// entry, and by Clear, Rotate and MigrateAllocator; replacing the value with
+// Insert keeps it valid. The zero Handle is invalid.
+// Handle คือการอ้างอิงรายการแบบระยะยาวที่ตรวจสอบได้ว่ารายการยังอยู่หรือไม่
+type Handle[K any, V any] struct {
+	sl    *SkipList[K, V]
+	n     *node[K, V]
+	gen   uint64
+	epoch uint64
+}
+
+// SearchHandle returns a Handle to the entry of key, and whether it exists.
+// SearchHandle คืนค่า Handle ของรายการที่มี key ตรงกับที่กำหนด
+func (sl *SkipList[K, V]) SearchHandle(key K) (Handle[K, V], bool) {
+	tr := sl.traceStart(OpSearch)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	n := sl.findGreaterOrEqual(key)
+	if n == nil || sl.compare(n.key, key) != 0 {
+		return Handle[K, V]{}, false
+	}
+	tr.keys = 1
+	return sl.handle(n), true
+}
+
+// Handle returns a Handle to the entry of n. It does not take the lock and
+// is meant for nodes passed to callbacks running under the lock, such as
+// those of RangeNodes, which may keep the handle for later use; elsewhere,
+// use SearchHandle. It returns the zero Handle if n is not a node of a live
+// entry.
+// Handle คืนค่า Handle ของโหนด n ใช้ภายใน callback ที่ถือ lock อยู่ เช่น RangeNodes
+func (sl *SkipList[K, V]) Handle(n INode[K, V]) Handle[K, V] {
+	target, ok := n.(*node[K, V])
+	if !ok || target == nil || target.gen == 0 {
+		return Handle[K, V]{}
+	}
+	return sl.handle(target)
+}
+
+// handle returns a Handle to n. The caller must hold a lock.
+func (sl *SkipList[K, V]) handle(n *node[K, V]) Handle[K, V] {
+	return Handle[K, V]{sl: sl, n: n, gen: n.gen, epoch: sl.epoch}
+}
+
+// valid reports whether the entry of h still exists. The caller must hold a
+// lock of h.sl.
+func (h Handle[K, V]) valid() bool {
+	return h.n != nil && h.epoch == h.sl.epoch && h.n.gen == h.gen
+}
+
+// Valid reports whether the entry of h still exists. It takes the read lock,
+// so it must not be called from a callback running under the lock.
+// Valid คืนค่า true ถ้ารายการของ h ยังอยู่ใน list
+func (h Handle[K, V]) Valid() bool {
+	if h.sl == nil {
+		return false
+	}
+	h.sl.mutex.RLock()
+	defer h.sl.mutex.RUnlock()
+	return h.valid()
+}
+
+// Load returns the key and current value of the entry of h, and false if the
+// entry no longer exists. The check and the read are made under one read
+// lock, so a recycled node is never read.
+// Load คืนค่า key และ value ปัจจุบันของรายการ หรือ false ถ้ารายการถูกลบไปแล้ว
+func (h Handle[K, V]) Load() (key K, value V, ok bool) {
+	if h.sl == nil {
+		return key, value, false
+	}
+	h.sl.mutex.RLock()
+	defer h.sl.mutex.RUnlock()
+	if !h.valid() {
+		return key, value, false
+	}
+	return h.n.key, h.n.value, true
+}
+
+// Delete removes the entry of h, like DeleteNode, and reports whether it
+// still existed.
+// Delete ลบรายการของ h และคืนค่า true ถ้ารายการยังอยู่
+func (h Handle[K, V]) Delete() bool {
+	if h.sl == nil {
+		return false
+	}
+	sl := h.sl
+	tr := sl.traceStart(OpDelete)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	if !h.valid() || !sl.unlink(h.n) {
+		return false
+	}
+	tr.keys = 1
+	return true
+}
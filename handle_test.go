@@ -0,0 +1,162 @@
+package skiplist
+
+import "testing"
+
+func TestSkipList_DeleteNode(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		for _, back := range []bool{false, true} {
+			name := setup.name
+			if back {
+				name += "/Bidirectional"
+			}
+			t.Run(name, func(t *testing.T) {
+				var deleted []int
+				opts := []Option[int, int]{
+					WithWeights[int, int](func(_, v int) int { return v }),
+					WithMerkle[int, int](func(k, _ int) uint64 { return mix64(uint64(k)) }),
+					WithHooks(Hooks[int, int]{OnDelete: func(k, _ int) { deleted = append(deleted, k) }}),
+				}
+				if back {
+					opts = append(opts, WithBidirectionalLevels[int, int]())
+				}
+				sl := setup.constructor(nil, opts...)
+				other := setup.constructor(nil, opts...)
+				for i := 0; i < 1000; i++ {
+					sl.Insert(i, i)
+					other.Insert(i, i)
+				}
+
+				var nodes []INode[int, int]
+				sl.RangeNodes(func(n INode[int, int]) bool {
+					if n.Key()%3 == 0 {
+						nodes = append(nodes, n)
+					}
+					return true
+				})
+				for _, n := range nodes {
+					if found, _ := other.Search(n.Key()); other.DeleteNode(n) || found == nil {
+						t.Fatalf("other.DeleteNode deleted a node of sl")
+					}
+					k := n.Key()
+					if !sl.DeleteNode(n) {
+						t.Fatalf("DeleteNode(%d) = false", k)
+					}
+					if _, ok := sl.Search(k); ok {
+						t.Fatalf("key %d found after DeleteNode", k)
+					}
+				}
+				if sl.Len() != 666 || other.Len() != 1000 || len(deleted) != 334 {
+					t.Errorf("Len() = %d, other.Len() = %d, %d OnDelete calls", sl.Len(), other.Len(), len(deleted))
+				}
+				if err := sl.Validate(); err != nil {
+					t.Fatal(err)
+				}
+
+				// Handles of deleted entries are rejected.
+				if sl.DeleteNode(nodes[len(nodes)-1]) {
+					t.Error("DeleteNode of a deleted entry = true")
+				}
+				if sl.DeleteNode(nil) {
+					t.Error("DeleteNode(nil) = true")
+				}
+				if sl.Len() != 666 {
+					t.Errorf("Len() = %d after rejected deletes", sl.Len())
+				}
+			})
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			var zero Handle[int, string]
+			if zero.Valid() || zero.Delete() {
+				t.Error("the zero Handle is valid")
+			}
+			if _, ok := sl.SearchHandle(1); ok {
+				t.Error("SearchHandle on an empty list found a key")
+			}
+			for i := 0; i < 100; i++ {
+				sl.Insert(i, "v")
+			}
+
+			h, ok := sl.SearchHandle(10)
+			if !ok || !h.Valid() {
+				t.Fatalf("SearchHandle(10) = %v, %v", h, ok)
+			}
+			sl.Insert(10, "updated")
+			if k, v, ok := h.Load(); !ok || k != 10 || v != "updated" {
+				t.Errorf("Load() after an update = %d, %q, %v", k, v, ok)
+			}
+
+			// The node of a deleted entry is reused by a pool for the next
+			// insert; the handle must not see the new entry.
+			sl.Delete(10)
+			sl.Insert(1000, "other")
+			if h.Valid() {
+				t.Error("handle valid after its entry was deleted")
+			}
+			if _, _, ok := h.Load(); ok {
+				t.Error("Load() succeeded after the entry was deleted")
+			}
+			if h.Delete() || sl.Len() != 100 {
+				t.Errorf("Delete() of a stale handle removed an entry: Len() = %d", sl.Len())
+			}
+
+			// Handles taken in RangeNodes delete their entries.
+			var handles []Handle[int, string]
+			sl.RangeNodes(func(n INode[int, string]) bool {
+				handles = append(handles, sl.Handle(n))
+				return n.Key() < 20
+			})
+			for _, h := range handles {
+				if !h.Delete() {
+					t.Fatalf("Delete() of a live handle = false")
+				}
+			}
+			if sl.Len() != 80 {
+				t.Errorf("Len() = %d, want 80", sl.Len())
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			// Clear invalidates every handle, including those of nodes an
+			// arena hands out again.
+			h, _ = sl.SearchHandle(50)
+			sl.Clear()
+			for i := 0; i < 100; i++ {
+				sl.Insert(i, "new")
+			}
+			if h.Valid() {
+				t.Error("handle valid after Clear")
+			}
+		})
+	}
+}
+
+func TestHandleRotateMigrate(t *testing.T) {
+	sl := New[int, int]()
+	for i := 0; i < 10; i++ {
+		sl.Insert(i, i)
+	}
+	h, _ := sl.SearchHandle(5)
+	if err := sl.MigrateAllocator(WithArena[int, int](1 << 12)); err != nil {
+		t.Fatal(err)
+	}
+	if h.Valid() {
+		t.Error("handle valid after MigrateAllocator")
+	}
+	// Handles taken after the migration refer to the new nodes.
+	h, _ = sl.SearchHandle(5)
+	if !h.Valid() {
+		t.Error("handle invalid after MigrateAllocator")
+	}
+	frozen := sl.Rotate()
+	defer frozen.Release()
+	if h.Valid() {
+		t.Error("handle valid after Rotate")
+	}
+}
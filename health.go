@@ -0,0 +1,137 @@
+package skiplist
+
+import (
+	"fmt"
+	"math"
+)
+
+// healthMinLen is the number of entries below which HealthCheck does not
+// test the level distribution: small lists deviate from it by chance.
+const healthMinLen = 64
+
+// healthMinExpected is the smallest expected count of a bin of the
+// chi-square test; the tail of the distribution is pooled into the last bin.
+const healthMinExpected = 5
+
+// healthAlpha is the p-value below which HealthCheck reports the structure as
+// degenerate. A correctly built list is flagged once in a thousand checks.
+const healthAlpha = 1e-3
+
+// Health is the result of HealthCheck.
+// Health คือผลลัพธ์ของ HealthCheck
+type Health struct {
+	Len int `json:"len"`
+	// Heights[i] is the number of nodes of height i+1 (linked at levels 0
+	// to i), and Expected[i] the number expected for P.
+	Heights  []int     `json:"heights"`
+	Expected []float64 `json:"expected"`
+	// ChiSquare is the statistic of a chi-square goodness-of-fit test of
+	// Heights against the geometric distribution of parameter P.
+	ChiSquare float64 `json:"chi_square"`
+	// Score is the p-value of the test, from 0 to 1: the probability that a
+	// correctly built list deviates at least as much. It is 1 for lists too
+	// small to be tested.
+	Score float64 `json:"score"`
+	// Degenerate is true when Score is so low that the levels were almost
+	// certainly not drawn correctly.
+	Degenerate      bool     `json:"degenerate"`
+	Recommendations []string `json:"recommendations,omitempty"`
+}
+
+// HealthCheck compares the heights of the nodes against the geometric
+// distribution they are drawn from (probability P of growing by one level)
+// and flags statistically degenerate structures, e.g. levels drawn from a
+// broken random source or carried over by a faulty import, whose searches no
+// longer run in O(log n). It walks every node once under the read lock.
+// HealthCheck ตรวจสอบว่าความสูงของโหนดสอดคล้องกับการแจกแจงเรขาคณิตตามค่า P หรือไม่
+// และคืนค่าคะแนนพร้อมคำแนะนำ
+func (sl *SkipList[K, V]) HealthCheck() Health {
+	sl.mutex.RLock()
+	h := Health{Len: sl.length, Heights: make([]int, sl.level+1)}
+	for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+		h.Heights[len(n.forward)-1]++
+	}
+	sl.mutex.RUnlock()
+
+	total := float64(h.Len)
+	h.Expected = make([]float64, len(h.Heights))
+	for i := range h.Expected {
+		h.Expected[i] = total * (1 - P) * math.Pow(P, float64(i))
+	}
+	h.Score = 1
+	if h.Len < healthMinLen {
+		h.Recommendations = append(h.Recommendations,
+			fmt.Sprintf("too few entries (%d) for a meaningful check", h.Len))
+		return h
+	}
+
+	// Bin i holds the nodes of height i+1, except the last bin, which pools
+	// all the taller nodes so that every bin expects enough of them.
+	var observed, expected []float64
+	tail := total
+	for i := 0; ; i++ {
+		e := total * (1 - P) * math.Pow(P, float64(i))
+		if tail-e < healthMinExpected {
+			break
+		}
+		observed = append(observed, float64(h.count(i)))
+		expected = append(expected, e)
+		tail -= e
+	}
+	observed = append(observed, total-sumFloats(observed))
+	expected = append(expected, tail)
+	for i := range observed {
+		d := observed[i] - expected[i]
+		h.ChiSquare += d * d / expected[i]
+	}
+	h.Score = chiSquareTail(h.ChiSquare, len(observed)-1)
+	h.Degenerate = h.Score < healthAlpha
+	if !h.Degenerate {
+		return h
+	}
+
+	var mean float64
+	for i, c := range h.Heights {
+		mean += float64((i + 1) * c)
+	}
+	mean /= total
+	want := 1 / (1 - P)
+	if mean < want {
+		h.Recommendations = append(h.Recommendations, fmt.Sprintf(
+			"nodes are too short (mean height %.2f, expected %.2f): searches degrade towards a linear scan", mean, want))
+	} else {
+		h.Recommendations = append(h.Recommendations, fmt.Sprintf(
+			"nodes are too tall or too uniform (mean height %.2f, expected %.2f): memory use and update cost grow", mean, want))
+	}
+	h.Recommendations = append(h.Recommendations,
+		"rebuild the list (Save then Load, or BulkLoad into a new list) to redraw the node levels")
+	return h
+}
+
+// count returns the number of nodes of height i+1.
+func (h *Health) count(i int) int {
+	if i < len(h.Heights) {
+		return h.Heights[i]
+	}
+	return 0
+}
+
+func sumFloats(s []float64) float64 {
+	var t float64
+	for _, x := range s {
+		t += x
+	}
+	return t
+}
+
+// chiSquareTail returns the probability that a chi-square variable with k
+// degrees of freedom exceeds x, using the Wilson-Hilferty approximation,
+// which is accurate enough to tell a sound structure from a degenerate one.
+func chiSquareTail(x float64, k int) float64 {
+	if k < 1 {
+		return 1
+	}
+	v := 2 / (9 * float64(k))
+	z := (math.Cbrt(x/float64(k)) - (1 - v)) / math.Sqrt(v)
+	return math.Erfc(z/math.Sqrt2) / 2
+}
@@ -0,0 +1,88 @@
+package skiplist
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestHealthCheck(t *testing.T) {
+	t.Run("Healthy", func(t *testing.T) {
+		sl := New[int, int]()
+		sl.rand = rand.New(rand.NewPCG(1, 2))
+		for i := 0; i < 10000; i++ {
+			sl.Insert(i, i)
+		}
+		h := sl.HealthCheck()
+		if h.Degenerate || h.Score < healthAlpha || len(h.Recommendations) != 0 {
+			t.Errorf("HealthCheck() = %+v, want a healthy structure", h)
+		}
+		total := 0
+		for _, c := range h.Heights {
+			total += c
+		}
+		if total != 10000 || h.Len != 10000 {
+			t.Errorf("Heights sum to %d, Len = %d, want 10000", total, h.Len)
+		}
+		if len(h.Expected) != len(h.Heights) || h.Expected[0] != 7500 {
+			t.Errorf("Expected = %v", h.Expected)
+		}
+	})
+
+	t.Run("TooSmall", func(t *testing.T) {
+		sl := New[int, int]()
+		for i := 0; i < 10; i++ {
+			sl.Insert(i, i)
+		}
+		h := sl.HealthCheck()
+		if h.Degenerate || h.Score != 1 || len(h.Recommendations) != 1 {
+			t.Errorf("HealthCheck() = %+v", h)
+		}
+	})
+
+	tests := []struct {
+		name string
+		nums []uint64
+		want string
+	}{
+		// The default value of the mock source stops every node at level 1.
+		{"TooShort", nil, "too short"},
+		// Zeros grow every node to MaxLevel.
+		{"TooTall", make([]uint64, 1000), "too tall"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := New[int, int]()
+			sl.rand = rand.New(&mockRandSource{nums: tt.nums})
+			for i := 0; i < 1000; i++ {
+				sl.Insert(i, i)
+			}
+			h := sl.HealthCheck()
+			if !h.Degenerate || h.Score >= healthAlpha {
+				t.Fatalf("HealthCheck() = %+v, want a degenerate structure", h)
+			}
+			if len(h.Recommendations) == 0 || !strings.Contains(h.Recommendations[0], tt.want) {
+				t.Errorf("Recommendations = %q, want %q", h.Recommendations, tt.want)
+			}
+		})
+	}
+}
+
+func TestChiSquareTail(t *testing.T) {
+	// Reference values of the upper tail of the chi-square distribution.
+	tests := []struct {
+		x    float64
+		k    int
+		want float64
+	}{
+		{3.841, 1, 0.05},
+		{9.488, 4, 0.05},
+		{23.209, 10, 0.01},
+		{10, 10, 0.4405},
+	}
+	for _, tt := range tests {
+		if got := chiSquareTail(tt.x, tt.k); got < tt.want*0.9 || got > tt.want*1.1 {
+			t.Errorf("chiSquareTail(%v, %d) = %v, want about %v", tt.x, tt.k, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package skiplist
+
+// Heap adapts a skiplist to container/heap's heap.Interface, so that code
+// written against container/heap can switch to a skiplist incrementally and
+// gain ordered iteration and rank queries on the same data. heap.Push maps to
+// Insert and heap.Pop to PopMin; the values pushed and popped are
+// Entry[K, V]:
+//
+//	h := sl.AsHeap()
+//	heap.Push(h, skiplist.Entry[int, string]{Key: 3, Value: "c"})
+//	min := heap.Pop(h).(skiplist.Entry[int, string])
+//
+// The skiplist is always sorted, so index i is simply rank i: Less compares
+// ranks, heap.Init and heap.Fix have nothing to do, and Swap only records
+// which rank the following Pop removes, which makes heap.Remove(h, i) delete
+// the entry at rank i. Unlike a heap, the skiplist holds each key once:
+// pushing a key already present replaces its value.
+//
+// A Heap keeps state between the calls of a heap operation, so like a slice
+// driven by container/heap it must not be used by several goroutines at
+// once; the skiplist itself stays safe for concurrent use.
+// Heap ทำให้ skiplist ใช้กับแพ็กเกจ container/heap ได้ เพื่อย้ายโค้ดเดิมมาใช้ skiplist ทีละส่วน
+type Heap[K any, V any] struct {
+	sl      *SkipList[K, V]
+	pending int // rank moved to the end by the last Swap, -1 if none
+}
+
+// AsHeap returns a Heap over sl.
+// AsHeap คืนค่า Heap ที่ใช้ข้อมูลใน sl
+func (sl *SkipList[K, V]) AsHeap() *Heap[K, V] {
+	return &Heap[K, V]{sl: sl, pending: -1}
+}
+
+// List returns the skiplist behind the heap.
+func (h *Heap[K, V]) List() *SkipList[K, V] { return h.sl }
+
+// Len implements heap.Interface.
+func (h *Heap[K, V]) Len() int { return h.sl.Len() }
+
+// Less implements heap.Interface: entries are ordered by rank.
+func (h *Heap[K, V]) Less(i, j int) bool { return i < j }
+
+// Swap implements heap.Interface. The skiplist order cannot change, so Swap
+// only remembers the rank that container/heap moves to the end for the next
+// Pop to remove.
+func (h *Heap[K, V]) Swap(i, j int) {
+	last := h.sl.Len() - 1
+	switch {
+	case j == last:
+		h.pending = i
+	case i == last:
+		h.pending = j
+	}
+}
+
+// Push implements heap.Interface by inserting x, which must be an
+// Entry[K, V].
+func (h *Heap[K, V]) Push(x any) {
+	e := x.(Entry[K, V])
+	h.sl.Insert(e.Key, e.Value)
+}
+
+// Pop implements heap.Interface. It removes and returns, as an Entry[K, V],
+// the entry the preceding Swap moved to the end: the minimum within
+// heap.Pop, the entry at rank i within heap.Remove(h, i). It returns nil if
+// the skiplist is empty.
+func (h *Heap[K, V]) Pop() any {
+	rank := h.pending
+	h.pending = -1
+	var n INode[K, V]
+	var ok bool
+	switch {
+	case rank == 0:
+		n, ok = h.sl.PopMin()
+	case rank < 0:
+		n, ok = h.sl.PopMax()
+	default:
+		if n, ok = h.sl.GetByRank(rank); ok {
+			n = &node[K, V]{key: n.Key(), value: n.Value()}
+			h.sl.Delete(n.Key())
+		}
+	}
+	if !ok {
+		return nil
+	}
+	return Entry[K, V]{Key: n.Key(), Value: n.Value()}
+}
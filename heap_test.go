@@ -0,0 +1,43 @@
+package skiplist
+
+import (
+	"container/heap"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestHeap(t *testing.T) {
+	sl := New[int, int]()
+	h := sl.AsHeap()
+	heap.Init(h)
+	for _, k := range rand.Perm(100) {
+		heap.Push(h, Entry[int, int]{Key: k, Value: k * 10})
+	}
+	if h.Len() != 100 || h.List() != sl {
+		t.Fatalf("Len() = %d after 100 pushes", h.Len())
+	}
+
+	// heap.Remove deletes the entry at the given rank.
+	if e := heap.Remove(h, 42).(Entry[int, int]); e.Key != 42 || e.Value != 420 {
+		t.Errorf("Remove(42) = %v", e)
+	}
+	if e := heap.Remove(h, h.Len()-1).(Entry[int, int]); e.Key != 99 {
+		t.Errorf("Remove(last) = %v", e)
+	}
+	heap.Fix(h, 10)
+
+	for want := 0; h.Len() > 0; want++ {
+		if want == 42 {
+			want++
+		}
+		if e := heap.Pop(h).(Entry[int, int]); e.Key != want || e.Value != want*10 {
+			t.Fatalf("Pop() = %v, want key %d", e, want)
+		}
+	}
+	if sl.Len() != 0 {
+		t.Errorf("Len() = %d after popping everything", sl.Len())
+	}
+	if x := h.Pop(); x != nil {
+		t.Errorf("Pop() on an empty heap = %v", x)
+	}
+}
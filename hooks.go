@@ -0,0 +1,97 @@
+package skiplist
+
+// Hooks holds optional callbacks invoked after the skiplist is modified. Any
+// field may be nil.
+//
+// Hooks run synchronously while the write lock is held, after the change has
+// been applied, so they observe every modification in order. They must be
+// fast and must not call back into the same skiplist (doing so deadlocks).
+// A panic raised by a hook propagates as a *CallbackPanic and leaves the list
+// valid; see CallbackPanic.
+// Clear, DeleteRange, PopMin and PopMax report removed keys through OnDelete,
+// unless OnDeleteRange is set for the bulk deletes.
+//
+// OnBoundsChange is called whenever the smallest or the largest key of the
+// list changes, after the OnInsert or OnDelete call of the change that moved
+// it. min and max are the new bounds; when the list became empty, empty is true
+// and min and max are zero values. A routing layer of a sharded system can use
+// it to keep the key range owned by each list current without polling.
+// BulkLoad and Load report the final bounds once rather than per entry.
+//
+// OnDeleteRange, when set, replaces the OnDelete calls of the bulk deletes,
+// DeleteRange and Clear: each reports the keys it removed with a single call
+// giving the smallest and largest of them and their count, and bounds
+// changes once. Removing thousands of entries then costs one callback
+// instead of one per entry. Without OnDeleteRange, they call OnDelete for
+// every removed key.
+//
+// OnRankChange reports the 0-based rank of a key entering, leaving or moving
+// within the list, so that a leaderboard can animate position changes
+// without recomputing the ranks of a whole page: an insert of a new key
+// reports (key, -1, rank), a delete (key, rank, -1), and Move (to, rank of
+// from, rank of to) once. It is coarse: the entries whose rank shifted
+// because of the change are not reported, and updates of existing keys,
+// which keep their rank, report nothing. Deletes cost an extra O(log n)
+// descent to find the rank while it is set. Bulk deletes reported through
+// OnDeleteRange do not report ranks.
+//
+// Hooks คือ callback ที่ถูกเรียกหลังจากมีการแก้ไขข้อมูลใน skiplist
+// ถูกเรียกขณะถือ write lock จึงต้องทำงานเร็วและห้ามเรียกกลับเข้ามาที่ skiplist เดิม
+type Hooks[K any, V any] struct {
+	OnInsert func(key K, value V)      // a new key was added
+	OnUpdate func(key K, old, value V) // the value of an existing key was replaced
+	OnDelete func(key K, value V)      // a key was removed
+
+	OnBoundsChange func(min, max K, empty bool) // the smallest or largest key changed
+
+	OnDeleteRange func(first, last K, count int) // a bulk delete removed count keys from first to last
+
+	OnRankChange func(key K, oldRank, newRank int) // key moved from oldRank to newRank; -1 when not in the list
+}
+
+// WithHooks registers change hooks. A later WithHooks replaces earlier ones.
+// WithHooks ลงทะเบียน callback สำหรับการเปลี่ยนแปลงข้อมูล
+func WithHooks[K any, V any](h Hooks[K, V]) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.hooks = h
+	}
+}
+
+// The hook wrappers below run a hook, which must be set, and wrap its panics.
+
+func (h *Hooks[K, V]) onInsert(key K, value V) {
+	defer rethrowCallbackPanic("OnInsert")
+	h.OnInsert(key, value)
+}
+
+func (h *Hooks[K, V]) onUpdate(key K, old, value V) {
+	defer rethrowCallbackPanic("OnUpdate")
+	h.OnUpdate(key, old, value)
+}
+
+func (h *Hooks[K, V]) onDelete(key K, value V) {
+	defer rethrowCallbackPanic("OnDelete")
+	h.OnDelete(key, value)
+}
+
+func (h *Hooks[K, V]) onDeleteRange(first, last K, count int) {
+	defer rethrowCallbackPanic("OnDeleteRange")
+	h.OnDeleteRange(first, last, count)
+}
+
+func (h *Hooks[K, V]) onRankChange(key K, oldRank, newRank int) {
+	defer rethrowCallbackPanic("OnRankChange")
+	h.OnRankChange(key, oldRank, newRank)
+}
+
+// boundsChanged reports the current bounds to OnBoundsChange, which must be
+// set. The caller must hold the write lock.
+func (sl *SkipList[K, V]) boundsChanged() {
+	defer rethrowCallbackPanic("OnBoundsChange")
+	if sl.length == 0 {
+		var zero K
+		sl.hooks.OnBoundsChange(zero, zero, true)
+		return
+	}
+	sl.hooks.OnBoundsChange(sl.header.forward[0].key, sl.last().key, false)
+}
@@ -0,0 +1,325 @@
+package skiplist
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			var events []string
+			sl := setup.constructor(nil, WithHooks(Hooks[int, string]{
+				OnInsert: func(k int, v string) { events = append(events, "insert "+strconv.Itoa(k)+"="+v) },
+				OnUpdate: func(k int, old, v string) {
+					events = append(events, "update "+strconv.Itoa(k)+" "+old+"->"+v)
+				},
+				OnDelete: func(k int, v string) { events = append(events, "delete "+strconv.Itoa(k)+"="+v) },
+			}))
+
+			sl.Insert(1, "a")
+			sl.Insert(2, "b")
+			sl.Insert(1, "c")
+			sl.Delete(2)
+			sl.Delete(3)
+			sl.Insert(4, "d")
+			sl.PopMin()
+			sl.Insert(5, "e")
+			sl.Clear()
+
+			want := []string{
+				"insert 1=a",
+				"insert 2=b",
+				"update 1 a->c",
+				"delete 2=b",
+				"insert 4=d",
+				"delete 1=c",
+				"insert 5=e",
+				"delete 4=d",
+				"delete 5=e",
+			}
+			if !reflect.DeepEqual(events, want) {
+				t.Errorf("events = %q\nwant %q", events, want)
+			}
+		})
+	}
+}
+
+func TestHooks_OnBoundsChange(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			var events []string
+			sl := setup.constructor(nil, WithHooks(Hooks[int, string]{
+				OnBoundsChange: func(min, max int, empty bool) {
+					if empty {
+						events = append(events, "empty")
+						return
+					}
+					events = append(events, strconv.Itoa(min)+".."+strconv.Itoa(max))
+				},
+			}))
+
+			sl.Insert(5, "a")
+			sl.Insert(3, "b")
+			sl.Insert(4, "c") // inside the bounds
+			sl.Insert(3, "d") // update
+			sl.Insert(9, "e")
+			sl.Delete(4) // inside the bounds
+			sl.Delete(9)
+			sl.PopMin()
+			sl.PopMax()
+			sl.PopMax() // already empty
+
+			keys := []int{10, 20, 30}
+			if _, err := sl.BulkLoad(func() (int, string, bool) {
+				if len(keys) == 0 {
+					return 0, "", false
+				}
+				k := keys[0]
+				keys = keys[1:]
+				return k, "v", true
+			}); err != nil {
+				t.Fatal(err)
+			}
+			sl.Clear()
+			sl.Clear() // already empty
+
+			want := []string{"5..5", "3..5", "3..9", "3..5", "5..5", "empty", "10..30", "empty"}
+			if !reflect.DeepEqual(events, want) {
+				t.Errorf("events = %q\nwant %q", events, want)
+			}
+		})
+	}
+}
+
+func TestHooks_OnDeleteRange(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			var events []string
+			sl := setup.constructor(nil, WithHooks(Hooks[int, string]{
+				OnDelete: func(k int, v string) { events = append(events, "delete "+strconv.Itoa(k)) },
+				OnDeleteRange: func(first, last int, count int) {
+					events = append(events, "range "+strconv.Itoa(first)+".."+strconv.Itoa(last)+" "+strconv.Itoa(count))
+				},
+				OnBoundsChange: func(min, max int, empty bool) {
+					events = append(events, "bounds "+strconv.Itoa(min)+" "+strconv.Itoa(max)+" "+strconv.FormatBool(empty))
+				},
+			}))
+			for i := 0; i < 100; i++ {
+				sl.Insert(i, "v")
+			}
+			events = nil
+
+			if n := sl.DeleteRange(10, 19); n != 10 {
+				t.Fatalf("DeleteRange(10, 19) = %d", n)
+			}
+			if n := sl.DeleteRange(15, 25); n != 6 {
+				t.Fatalf("DeleteRange(15, 25) = %d", n)
+			}
+			if n := sl.DeleteRange(30, 20); n != 0 {
+				t.Fatalf("DeleteRange(30, 20) = %d", n)
+			}
+			sl.DeleteRange(-5, 4)
+			sl.Delete(50)
+			sl.Clear()
+
+			want := []string{
+				"range 10..19 10",
+				"range 20..25 6",
+				"range 0..4 5",
+				"bounds 5 99 false",
+				"delete 50",
+				"range 5..99 78",
+				"bounds 0 0 true",
+			}
+			if !reflect.DeepEqual(events, want) {
+				t.Errorf("events = %q\nwant %q", events, want)
+			}
+		})
+	}
+}
+
+func TestHooks_OnRankChange(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			type change struct{ key, from, to int }
+			var changes []change
+			var deletes int
+			sl := setup.constructor(nil, WithHooks(Hooks[int, string]{
+				OnDelete:     func(int, string) { deletes++ },
+				OnRankChange: func(k, from, to int) { changes = append(changes, change{k, from, to}) },
+			}))
+
+			for _, k := range []int{50, 10, 30} {
+				sl.Insert(k, "p")
+			}
+			sl.Insert(30, "q") // an update keeps the rank
+			if !sl.Move(10, 40) || sl.Move(11, 12) {
+				t.Fatal("Move did not report whether the key was present")
+			}
+			sl.Delete(50)
+			sl.PopMin()
+
+			want := []change{{50, -1, 0}, {10, -1, 0}, {30, -1, 1}, {40, 0, 1}, {50, 2, -1}, {30, 0, -1}}
+			if !reflect.DeepEqual(changes, want) {
+				t.Errorf("rank changes = %v\nwant %v", changes, want)
+			}
+			if n, ok := sl.Search(40); !ok || n.Value() != "p" || sl.Len() != 1 || deletes != 3 {
+				t.Errorf("after Move: Search(40) = %v, %v, Len() = %d, %d deletes", n, ok, sl.Len(), deletes)
+			}
+			if err := sl.CheckSpans(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestMoveReplacesAndFails(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			var events []string
+			sl := setup.constructor(nil, WithHooks(Hooks[int, string]{
+				OnInsert:     func(k int, v string) { events = append(events, fmt.Sprintf("insert %d %s", k, v)) },
+				OnUpdate:     func(k int, _, v string) { events = append(events, fmt.Sprintf("update %d %s", k, v)) },
+				OnDelete:     func(k int, v string) { events = append(events, fmt.Sprintf("delete %d %s", k, v)) },
+				OnRankChange: func(k, from, to int) { events = append(events, fmt.Sprintf("rank %d %d->%d", k, from, to)) },
+			}))
+			sl.Insert(10, "a")
+			sl.Insert(20, "b")
+			sl.Insert(30, "c")
+			events = nil
+
+			// The entry at 30 is replaced and reported as deleted.
+			if !sl.Move(10, 30) {
+				t.Fatal("Move(10, 30) = false")
+			}
+			want := []string{"delete 30 c", "rank 30 2->-1", "insert 30 a", "delete 10 a", "rank 30 0->1"}
+			if !reflect.DeepEqual(events, want) {
+				t.Errorf("events = %q\nwant %q", events, want)
+			}
+			if n, ok := sl.Search(30); !ok || n.Value() != "a" || sl.Len() != 2 {
+				t.Errorf("after Move: Search(30) = %v, %v, Len() = %d", n, ok, sl.Len())
+			}
+			if err := sl.CheckSpans(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	// A move that cannot write its destination leaves the list unchanged.
+	sl := New[int, int](WithFixedArena[int, int](4096))
+	// A tall node may fail to fit where a short one still does, so fill the
+	// arena until even the shortest node is rejected.
+	for k, fails := 0, 0; fails < 200; k++ {
+		if _, err := sl.TryInsert(k, k); err != nil {
+			fails++
+		}
+	}
+	n := sl.Len()
+	func() {
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, ErrArenaFull) {
+				t.Errorf("Move into a full arena panicked with %v", err)
+			}
+		}()
+		sl.Move(0, -1)
+	}()
+	if _, ok := sl.Search(0); !ok || sl.Len() != n {
+		t.Errorf("failed Move lost the entry: Len() = %d, want %d", sl.Len(), n)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A move does not grow the list, so it never evicts.
+	sl = New[int, int](WithCapacity[int, int](2, nil))
+	sl.Insert(1, 1)
+	sl.Insert(2, 2)
+	sl.Move(2, 3)
+	var keys []int
+	sl.Range(func(k, _ int) bool { keys = append(keys, k); return true })
+	if !reflect.DeepEqual(keys, []int{1, 3}) {
+		t.Errorf("Move in a full list left keys %v, want [1 3]", keys)
+	}
+}
+
+func TestDeleteRange(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			var deleted []int
+			sl := setup.constructor(nil, WithHooks(Hooks[int, int]{
+				OnDelete: func(k, _ int) { deleted = append(deleted, k) },
+			}))
+			for i := 0; i < 1000; i++ {
+				sl.Insert(i, i)
+			}
+			if n := sl.DeleteRange(100, 899); n != 800 {
+				t.Fatalf("DeleteRange = %d, want 800", n)
+			}
+			if len(deleted) != 800 || deleted[0] != 100 || deleted[799] != 899 {
+				t.Fatalf("OnDelete called for %d keys", len(deleted))
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if err := sl.CheckSpans(); err != nil {
+				t.Fatal(err)
+			}
+			if sl.Len() != 200 || sl.Rank(900) != 100 {
+				t.Fatalf("Len() = %d, Rank(900) = %d", sl.Len(), sl.Rank(900))
+			}
+			if n := sl.DeleteRange(0, 2000); n != 200 || !sl.IsEmpty() {
+				t.Fatalf("DeleteRange of everything = %d, Len() = %d", n, sl.Len())
+			}
+		})
+	}
+}
+
+func TestClearIncremental(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			var batches []int
+			sl := setup.constructor(nil, WithHooks(Hooks[int, int]{
+				OnDeleteRange: func(first, last, count int) { batches = append(batches, count) },
+			}))
+			for i := 0; i < 2500; i++ {
+				sl.Insert(i, i)
+			}
+			if n := sl.ClearIncremental(1000); n != 2500 || !sl.IsEmpty() {
+				t.Fatalf("ClearIncremental = %d, Len() = %d", n, sl.Len())
+			}
+			if !reflect.DeepEqual(batches, []int{1000, 1000, 500}) {
+				t.Errorf("OnDeleteRange batches = %v", batches)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if sl.ClearIncremental(0) != 0 {
+				t.Error("ClearIncremental on an empty list removed entries")
+			}
+		})
+	}
+
+	// Writers make progress between batches, and their entries are drained
+	// too.
+	sl := New[int, int]()
+	for i := 0; i < 20000; i++ {
+		sl.Insert(i, i)
+	}
+	done := make(chan int)
+	go func() {
+		n := 0
+		for ; n < 100 && !sl.IsEmpty(); n++ {
+			sl.Insert(-1-n, 0)
+		}
+		done <- n
+	}()
+	removed := sl.ClearIncremental(10)
+	inserted := <-done
+	if removed+sl.Len() != 20000+inserted {
+		t.Errorf("removed %d entries, %d left, %d inserted during the drain", removed, sl.Len(), inserted)
+	}
+}
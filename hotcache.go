@@ -0,0 +1,109 @@
+package skiplist
+
+import "sync/atomic"
+
+// hotCacheMaxSize bounds WithHotCache: the cache is scanned linearly, which
+// only beats a descent while it stays tiny.
+const hotCacheMaxSize = 64
+
+// hotCacheAging is the number of misses per slot after which the access
+// counters of the cached nodes are halved, so that keys that stopped being
+// hot are eventually replaced.
+const hotCacheAging = 256
+
+// WithHotCache keeps the n most frequently searched nodes (at most 64) in a
+// small front cache consulted by Search before descending the list. Every
+// node then counts its lookups; a node found by a descent replaces the
+// cached node with the fewest lookups when it has more. Under skewed
+// (Zipfian) read workloads, the few keys at the head of the distribution are
+// then found with a handful of comparisons regardless of the list size, while
+// the skiplist serves the tail.
+//
+// The cache is lock-free, so concurrent Search calls keep sharing the read
+// lock. Only Search uses it; writes keep it consistent at the cost of a scan
+// of the cache per delete.
+// WithHotCache เก็บโหนดที่ถูกค้นหาบ่อยที่สุด n โหนดไว้ใน cache ขนาดเล็กที่ Search ตรวจสอบก่อน
+func WithHotCache[K any, V any](n int) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		if n > 0 {
+			sl.hot = &hotCache[K, V]{slots: make([]atomic.Pointer[node[K, V]], min(n, hotCacheMaxSize))}
+		} else {
+			sl.hot = nil
+		}
+	}
+}
+
+// hotCache is the front cache of WithHotCache. Readers holding the read lock
+// update it concurrently; writers holding the write lock evict nodes from it
+// before they are unlinked, so a cached node is always linked in the list.
+type hotCache[K any, V any] struct {
+	slots  []atomic.Pointer[node[K, V]]
+	misses atomic.Uint64
+}
+
+// lookup returns the cached node of key, or nil. The caller must hold a lock.
+func (c *hotCache[K, V]) lookup(sl *SkipList[K, V], key K, kp uint64) *node[K, V] {
+	for i := range c.slots {
+		if n := c.slots[i].Load(); n != nil && sl.compareNode(n, key, kp) == 0 {
+			atomic.AddUint32(&n.hits, 1)
+			return n
+		}
+	}
+	return nil
+}
+
+// admit counts a lookup of n, just found by a descent, and caches n in place
+// of the coldest cached node if n has been looked up more often.
+// The caller must hold a lock.
+func (c *hotCache[K, V]) admit(n *node[K, V]) {
+	hits := atomic.AddUint32(&n.hits, 1)
+	if c.misses.Add(1)%(hotCacheAging*uint64(len(c.slots))) == 0 {
+		c.age()
+	}
+	var victim *node[K, V]
+	slot, coldest := -1, uint32(0)
+	for i := range c.slots {
+		s := c.slots[i].Load()
+		if s == n {
+			return
+		}
+		// An empty slot counts as a node that was never looked up.
+		var h uint32
+		if s != nil {
+			h = atomic.LoadUint32(&s.hits)
+		}
+		if slot < 0 || h < coldest {
+			slot, victim, coldest = i, s, h
+		}
+	}
+	if hits > coldest {
+		// A concurrent reader may have replaced the victim; its choice wins.
+		c.slots[slot].CompareAndSwap(victim, n)
+	}
+}
+
+// age halves the access counters of the cached nodes. Lost updates from
+// concurrent readers are harmless: the counters are only a heuristic.
+func (c *hotCache[K, V]) age() {
+	for i := range c.slots {
+		if s := c.slots[i].Load(); s != nil {
+			atomic.StoreUint32(&s.hits, atomic.LoadUint32(&s.hits)/2)
+		}
+	}
+}
+
+// evict removes n from the cache. The caller must hold the write lock.
+func (c *hotCache[K, V]) evict(n *node[K, V]) {
+	for i := range c.slots {
+		if c.slots[i].Load() == n {
+			c.slots[i].Store(nil)
+		}
+	}
+}
+
+// reset empties the cache. The caller must hold the write lock.
+func (c *hotCache[K, V]) reset() {
+	for i := range c.slots {
+		c.slots[i].Store(nil)
+	}
+}
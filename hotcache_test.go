@@ -0,0 +1,112 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestWithHotCache(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil, WithHotCache[int, int](4))
+			for i := 0; i < 1000; i++ {
+				sl.Insert(i, i)
+			}
+
+			// Keys 0-3 are searched far more often than the others and must
+			// end up cached.
+			for round := 0; round < 50; round++ {
+				for k := 0; k < 4; k++ {
+					if n, ok := sl.Search(k * 100); !ok || n.Value() != k*100 {
+						t.Fatalf("Search(%d) = %v, %v", k*100, n, ok)
+					}
+				}
+				if n, ok := sl.Search(500 + round); !ok || n.Value() != 500+round {
+					t.Fatalf("Search(%d) = %v, %v", 500+round, n, ok)
+				}
+			}
+			for k := 0; k < 4; k++ {
+				if sl.hot.lookup(sl, k*100, sl.prefixOf(k*100)) == nil {
+					t.Errorf("key %d is not cached", k*100)
+				}
+			}
+
+			// Updates are visible through the cached node.
+			sl.Insert(100, -1)
+			if n, ok := sl.Search(100); !ok || n.Value() != -1 {
+				t.Errorf("Search(100) after update = %v, %v", n, ok)
+			}
+
+			// Deleted keys are evicted, including when the node is reused.
+			sl.Delete(200)
+			if _, ok := sl.Search(200); ok {
+				t.Error("Search(200) found a deleted key")
+			}
+			sl.Insert(1000, 1000)
+			if _, ok := sl.Search(200); ok {
+				t.Error("Search(200) found a deleted key after a reinsert")
+			}
+			if n, ok := sl.Search(1000); !ok || n.Value() != 1000 {
+				t.Errorf("Search(1000) = %v, %v", n, ok)
+			}
+
+			sl.Clear()
+			if _, ok := sl.Search(0); ok {
+				t.Error("Search(0) found a key after Clear")
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestWithHotCacheRotate(t *testing.T) {
+	sl := New[int, int](WithHotCache[int, int](2))
+	sl.Insert(1, 1)
+	for i := 0; i < 10; i++ {
+		sl.Search(1)
+	}
+	frozen := sl.Rotate()
+	defer frozen.Release()
+	if _, ok := sl.Search(1); ok {
+		t.Error("Search(1) found a key of the frozen list")
+	}
+	if n, ok := frozen.Search(1); !ok || n.Value() != 1 {
+		t.Errorf("frozen.Search(1) = %v, %v", n, ok)
+	}
+}
+
+func TestWithHotCacheConcurrent(t *testing.T) {
+	sl := New[int, int](WithHotCache[int, int](8))
+	for i := 0; i < 256; i++ {
+		sl.Insert(i, i)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				// The returned node may be recycled by the writer, so only
+				// the cache bookkeeping is exercised here.
+				sl.Search((i * (g + 1)) % 32)
+			}
+		}(g)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			k := i % 32
+			sl.Delete(k)
+			sl.Insert(k, k)
+		}
+	}()
+	wg.Wait()
+	for k := 0; k < 32; k++ {
+		if n, ok := sl.Search(k); !ok || n.Value() != k {
+			t.Errorf("Search(%d) = %v, %v", k, n, ok)
+		}
+	}
+}
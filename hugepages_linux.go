@@ -0,0 +1,18 @@
+package skiplist
+
+import "syscall"
+
+// adviseHugePages applies MADV_HUGEPAGE to the whole pages within b. Errors
+// are ignored: the advice is a hint.
+func adviseHugePages(b []byte) {
+	page := syscall.Getpagesize()
+	start := int(uintptrOf(b) % uintptr(page))
+	if start > 0 {
+		start = page - start
+	}
+	end := start + (len(b)-start)/page*page
+	if end <= start {
+		return
+	}
+	_ = syscall.Madvise(b[start:end], syscall.MADV_HUGEPAGE)
+}
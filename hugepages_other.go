@@ -0,0 +1,6 @@
+//go:build !linux
+
+package skiplist
+
+// adviseHugePages is a no-op outside Linux.
+func adviseHugePages([]byte) {}
@@ -0,0 +1,61 @@
+package skiplist
+
+// WithIDIndex keeps a table from entry IDs (INode.ID) to nodes, so that an
+// entry can be found with SearchByID in O(1). External systems can then hold
+// the 8-byte ID of an entry instead of its key, which pays off with large
+// string or composite keys. The table costs one map entry per entry of the
+// list. It is emptied by Clear and Rotate, whose frozen list is not indexed.
+// WithIDIndex เก็บตารางจาก ID ของรายการไปยังโหนดเพื่อค้นหาด้วย SearchByID ได้ใน O(1)
+func WithIDIndex[K any, V any]() Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		if sl.ids == nil {
+			sl.ids = make(map[uint64]*node[K, V])
+		}
+	}
+}
+
+// SearchByID returns the node of the entry with the given ID, as returned
+// by INode.ID, and whether the entry still exists. It panics if the list was
+// not created with WithIDIndex.
+// SearchByID คืนค่าโหนดของรายการที่มี ID ตามที่กำหนด (ต้องเปิดใช้ WithIDIndex)
+func (sl *SkipList[K, V]) SearchByID(id uint64) (INode[K, V], bool) {
+	sl.mustIDIndex()
+	tr := sl.traceStart(OpSearch)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	n, ok := sl.ids[id]
+	if !ok {
+		return nil, false
+	}
+	tr.keys = 1
+	return n, true
+}
+
+// stamp assigns the next ID to n, which receives a new entry, and indexes
+// it. The caller must hold the write lock.
+func (sl *SkipList[K, V]) stamp(n *node[K, V]) {
+	sl.gen++
+	n.gen = sl.gen
+	if sl.ids != nil {
+		sl.ids[n.gen] = n
+	}
+}
+
+// reindexIDs rebuilds the ID index from the nodes of the list, after they
+// were copied to another allocator. The caller must hold the write lock.
+func (sl *SkipList[K, V]) reindexIDs() {
+	clear(sl.ids)
+	for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+		sl.ids[n.gen] = n
+	}
+}
+
+// mustIDIndex panics if the list has no ID index.
+func (sl *SkipList[K, V]) mustIDIndex() {
+	if sl.ids == nil {
+		panic("skiplist: SearchByID used without WithIDIndex")
+	}
+}
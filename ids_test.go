@@ -0,0 +1,58 @@
+package skiplist
+
+import "testing"
+
+func TestSearchByID(t *testing.T) {
+	for _, setup := range getTestSetups[string, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil, WithIDIndex[string, int]())
+			keys := []string{"delta", "alpha", "charlie", "bravo"}
+			ids := map[string]uint64{}
+			var last uint64
+			for i, k := range keys {
+				sl.Insert(k, i)
+				n, _ := sl.Search(k)
+				if n.ID() <= last {
+					t.Fatalf("ID(%q) = %d after %d", k, n.ID(), last)
+				}
+				last = n.ID()
+				ids[k] = last
+			}
+
+			sl.Insert("alpha", 10) // an update keeps the ID
+			if n, ok := sl.SearchByID(ids["alpha"]); !ok || n.Key() != "alpha" || n.Value() != 10 {
+				t.Errorf("SearchByID(alpha) = %v, %v", n, ok)
+			}
+			sl.Delete("bravo")
+			if _, ok := sl.SearchByID(ids["bravo"]); ok {
+				t.Error("SearchByID found a deleted entry")
+			}
+			sl.Insert("bravo", 3) // a reinserted key gets a new ID
+			n, _ := sl.Search("bravo")
+			if n.ID() <= last {
+				t.Errorf("reinserted ID = %d, want > %d", n.ID(), last)
+			}
+
+			if err := sl.MigrateAllocator(WithArena[string, int](1024)); err != nil {
+				t.Fatal(err)
+			}
+			for _, k := range []string{"alpha", "charlie", "delta"} {
+				if n, ok := sl.SearchByID(ids[k]); !ok || n.Key() != k || n.ID() != ids[k] {
+					t.Errorf("after MigrateAllocator: SearchByID(%d) = %v, %v", ids[k], n, ok)
+				}
+			}
+
+			sl.Clear()
+			if _, ok := sl.SearchByID(ids["delta"]); ok {
+				t.Error("SearchByID found an entry after Clear")
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SearchByID without WithIDIndex did not panic")
+		}
+	}()
+	New[int, int]().SearchByID(1)
+}
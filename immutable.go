@@ -0,0 +1,205 @@
+package skiplist
+
+import (
+	"cmp"
+	"sort"
+)
+
+// immutableChunkSize is the maximum number of entries held by one chunk of an
+// ImmutableSkipList. An update copies exactly one chunk, so this bounds the
+// per-update copying cost of the leaf level.
+const immutableChunkSize = 64
+
+// immutableChunk is a sorted run of entries. A chunk is never modified after it
+// has been published in an ImmutableSkipList.
+type immutableChunk[K any, V any] struct {
+	keys   []K
+	values []V
+}
+
+// ImmutableSkipList is a persistent (immutable) ordered map. Insert and Delete
+// never modify the receiver: they return a new list that shares every unmodified
+// part of the structure with the old one. Any number of goroutines can therefore
+// read any version without locking, and keeping an old version around is a
+// cheap snapshot.
+//
+// The structure is a two-level skip structure: an express lane of chunk
+// pointers over immutable sorted chunks of at most 64 entries. An update copies
+// the one chunk it touches plus the express lane (pointers only), so an update
+// costs O(64 + n/64) pointer copies while searches stay O(log n).
+// The zero value is not ready to use; create lists with NewImmutable,
+// NewImmutableWithComparator or SkipList.Immutable.
+//
+// ImmutableSkipList คือ ordered map แบบ persistent (ไม่เปลี่ยนแปลงได้)
+// Insert และ Delete จะคืนค่า list ใหม่ที่ใช้โครงสร้างส่วนที่ไม่เปลี่ยนแปลงร่วมกับ list เดิม
+type ImmutableSkipList[K any, V any] struct {
+	compare Comparator[K]
+	chunks  []*immutableChunk[K, V]
+	length  int
+}
+
+// NewImmutable creates an empty ImmutableSkipList for key types that implement cmp.Ordered.
+// NewImmutable สร้าง ImmutableSkipList ว่างสำหรับ key type ที่รองรับ `cmp.Ordered`
+func NewImmutable[K cmp.Ordered, V any]() *ImmutableSkipList[K, V] {
+	return NewImmutableWithComparator[K, V](cmp.Compare[K])
+}
+
+// NewImmutableWithComparator creates an empty ImmutableSkipList with a custom comparator.
+// The comparator function must not be nil.
+// NewImmutableWithComparator สร้าง ImmutableSkipList ว่างพร้อมฟังก์ชันเปรียบเทียบที่กำหนดเอง
+func NewImmutableWithComparator[K any, V any](compare Comparator[K]) *ImmutableSkipList[K, V] {
+	if compare == nil {
+		panic("skiplist: comparator cannot be nil")
+	}
+	return &ImmutableSkipList[K, V]{compare: compare}
+}
+
+// Immutable returns an ImmutableSkipList holding the current contents of the
+// skiplist. The copy is taken under a single read lock.
+// Immutable คืนค่า ImmutableSkipList ที่มีข้อมูลปัจจุบันของ skiplist
+func (sl *SkipList[K, V]) Immutable() *ImmutableSkipList[K, V] {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	l := &ImmutableSkipList[K, V]{compare: sl.compare, length: sl.length}
+	var c *immutableChunk[K, V]
+	for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+		if c == nil || len(c.keys) == immutableChunkSize/2 {
+			c = &immutableChunk[K, V]{
+				keys:   make([]K, 0, immutableChunkSize/2),
+				values: make([]V, 0, immutableChunkSize/2),
+			}
+			l.chunks = append(l.chunks, c)
+		}
+		c.keys = append(c.keys, n.key)
+		c.values = append(c.values, n.value)
+	}
+	return l
+}
+
+// Len returns the number of entries in the list.
+func (l *ImmutableSkipList[K, V]) Len() int {
+	return l.length
+}
+
+// locate returns the index of the chunk that holds key, or would hold it, and
+// the position of key within that chunk along with whether it is present.
+func (l *ImmutableSkipList[K, V]) locate(key K) (ci, pos int, found bool) {
+	ci = sort.Search(len(l.chunks), func(i int) bool {
+		c := l.chunks[i]
+		return l.compare(c.keys[len(c.keys)-1], key) >= 0
+	})
+	if ci == len(l.chunks) {
+		if ci == 0 {
+			return 0, 0, false
+		}
+		// key is larger than every key: it belongs at the end of the last chunk.
+		ci--
+		return ci, len(l.chunks[ci].keys), false
+	}
+	c := l.chunks[ci]
+	pos = sort.Search(len(c.keys), func(i int) bool {
+		return l.compare(c.keys[i], key) >= 0
+	})
+	return ci, pos, pos < len(c.keys) && l.compare(c.keys[pos], key) == 0
+}
+
+// Search returns the value stored for key.
+// Search ค้นหา value จาก key ที่กำหนด
+func (l *ImmutableSkipList[K, V]) Search(key K) (V, bool) {
+	ci, pos, found := l.locate(key)
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return l.chunks[ci].values[pos], true
+}
+
+// withChunks returns a copy of l whose express lane replaces chunk ci with repl.
+func (l *ImmutableSkipList[K, V]) withChunks(ci int, length int, repl ...*immutableChunk[K, V]) *ImmutableSkipList[K, V] {
+	chunks := make([]*immutableChunk[K, V], 0, len(l.chunks)-1+len(repl))
+	chunks = append(chunks, l.chunks[:ci]...)
+	chunks = append(chunks, repl...)
+	if ci < len(l.chunks) {
+		chunks = append(chunks, l.chunks[ci+1:]...)
+	}
+	return &ImmutableSkipList[K, V]{compare: l.compare, chunks: chunks, length: length}
+}
+
+// Insert returns a new list in which key maps to value. The receiver is not modified.
+// Insert คืนค่า list ใหม่ที่มี key-value ที่กำหนด โดยไม่แก้ไข list เดิม
+func (l *ImmutableSkipList[K, V]) Insert(key K, value V) *ImmutableSkipList[K, V] {
+	if len(l.chunks) == 0 {
+		c := &immutableChunk[K, V]{keys: []K{key}, values: []V{value}}
+		return &ImmutableSkipList[K, V]{compare: l.compare, chunks: []*immutableChunk[K, V]{c}, length: 1}
+	}
+
+	ci, pos, found := l.locate(key)
+	old := l.chunks[ci]
+	if found {
+		c := &immutableChunk[K, V]{keys: old.keys, values: append([]V(nil), old.values...)}
+		c.values[pos] = value
+		return l.withChunks(ci, l.length, c)
+	}
+
+	keys := make([]K, 0, len(old.keys)+1)
+	keys = append(append(append(keys, old.keys[:pos]...), key), old.keys[pos:]...)
+	values := make([]V, 0, len(old.values)+1)
+	values = append(append(append(values, old.values[:pos]...), value), old.values[pos:]...)
+
+	if len(keys) <= immutableChunkSize {
+		return l.withChunks(ci, l.length+1, &immutableChunk[K, V]{keys: keys, values: values})
+	}
+	half := len(keys) / 2
+	left := &immutableChunk[K, V]{keys: keys[:half:half], values: values[:half:half]}
+	right := &immutableChunk[K, V]{keys: keys[half:], values: values[half:]}
+	return l.withChunks(ci, l.length+1, left, right)
+}
+
+// Delete returns a new list without key and true, or the receiver itself and
+// false if key is not present. The receiver is not modified.
+// Delete คืนค่า list ใหม่ที่ไม่มี key ที่กำหนด และ true หากพบ key
+func (l *ImmutableSkipList[K, V]) Delete(key K) (*ImmutableSkipList[K, V], bool) {
+	ci, pos, found := l.locate(key)
+	if !found {
+		return l, false
+	}
+	old := l.chunks[ci]
+	if len(old.keys) == 1 {
+		return l.withChunks(ci, l.length-1), true
+	}
+	keys := make([]K, 0, len(old.keys)-1)
+	keys = append(append(keys, old.keys[:pos]...), old.keys[pos+1:]...)
+	values := make([]V, 0, len(old.values)-1)
+	values = append(append(values, old.values[:pos]...), old.values[pos+1:]...)
+	return l.withChunks(ci, l.length-1, &immutableChunk[K, V]{keys: keys, values: values}), true
+}
+
+// Range iterates over all entries in ascending key order until f returns false.
+// Range วนลูปไปตามรายการทั้งหมดตามลำดับ key จนกว่า f จะคืนค่า false
+func (l *ImmutableSkipList[K, V]) Range(f func(key K, value V) bool) {
+	defer rethrowCallbackPanic("Range")
+	for _, c := range l.chunks {
+		for i := range c.keys {
+			if !f(c.keys[i], c.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// RangeQuery iterates over entries whose key is between start and end (inclusive)
+// until f returns false.
+// RangeQuery วนลูปไปตามรายการที่ key อยู่ระหว่าง start และ end (รวมทั้งสองค่า)
+func (l *ImmutableSkipList[K, V]) RangeQuery(start, end K, f func(key K, value V) bool) {
+	defer rethrowCallbackPanic("RangeQuery")
+	ci, pos, _ := l.locate(start)
+	for ; ci < len(l.chunks); ci, pos = ci+1, 0 {
+		c := l.chunks[ci]
+		for ; pos < len(c.keys); pos++ {
+			if l.compare(c.keys[pos], end) > 0 || !f(c.keys[pos], c.values[pos]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package skiplist
+
+import (
+	"math/rand/v2"
+	"sync"
+	"testing"
+)
+
+func collectImmutable[K any, V any](l *ImmutableSkipList[K, V]) []K {
+	var keys []K
+	l.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+func TestImmutableSkipList_StructuralSharing(t *testing.T) {
+	empty := NewImmutable[int, string]()
+	v1 := empty.Insert(2, "b").Insert(1, "a").Insert(3, "c")
+	v2 := v1.Insert(2, "B")
+	v3, ok := v2.Delete(1)
+	if !ok {
+		t.Fatal("Delete(1) should succeed")
+	}
+
+	if empty.Len() != 0 || v1.Len() != 3 || v2.Len() != 3 || v3.Len() != 2 {
+		t.Fatalf("unexpected lengths: %d %d %d %d", empty.Len(), v1.Len(), v2.Len(), v3.Len())
+	}
+	if v, _ := v1.Search(2); v != "b" {
+		t.Errorf("old version changed: v1[2] = %q", v)
+	}
+	if v, _ := v2.Search(2); v != "B" {
+		t.Errorf("v2[2] = %q, want B", v)
+	}
+	if _, ok := v3.Search(1); ok {
+		t.Error("v3 should not contain key 1")
+	}
+	if _, ok := v2.Search(1); !ok {
+		t.Error("v2 should still contain key 1")
+	}
+	if same, ok := v3.Delete(42); ok || same != v3 {
+		t.Error("Delete of a missing key should return the receiver and false")
+	}
+
+	var got []int
+	v1.RangeQuery(2, 3, func(k int, _ string) bool {
+		got = append(got, k)
+		return true
+	})
+	if len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Errorf("RangeQuery(2, 3) = %v", got)
+	}
+}
+
+func TestImmutableSkipList_Randomized(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	ref := New[int, int]()
+	l := NewImmutable[int, int]()
+	var versions []*ImmutableSkipList[int, int]
+	var lens []int
+
+	for i := 0; i < 5000; i++ {
+		k := r.IntN(1000)
+		if r.IntN(3) == 0 {
+			ref.Delete(k)
+			l, _ = l.Delete(k)
+		} else {
+			ref.Insert(k, i)
+			l = l.Insert(k, i)
+		}
+		if i%500 == 0 {
+			versions = append(versions, l)
+			lens = append(lens, l.Len())
+		}
+	}
+
+	if l.Len() != ref.Len() {
+		t.Fatalf("Len() = %d, want %d", l.Len(), ref.Len())
+	}
+	keys := collectImmutable(l)
+	i := 0
+	ref.Range(func(k, v int) bool {
+		if keys[i] != k {
+			t.Fatalf("key %d: got %d, want %d", i, keys[i], k)
+		}
+		if got, _ := l.Search(k); got != v {
+			t.Fatalf("Search(%d) = %d, want %d", k, got, v)
+		}
+		i++
+		return true
+	})
+
+	// Old versions are untouched and can be read concurrently.
+	var wg sync.WaitGroup
+	for i, v := range versions {
+		wg.Add(1)
+		go func(v *ImmutableSkipList[int, int], want int) {
+			defer wg.Done()
+			if n := len(collectImmutable(v)); n != want {
+				t.Errorf("old version has %d entries, want %d", n, want)
+			}
+		}(v, lens[i])
+	}
+	wg.Wait()
+}
+
+func TestSkipList_Immutable(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			for i := 0; i < 200; i++ {
+				sl.Insert(i, i*i)
+			}
+			l := sl.Immutable()
+			sl.Insert(1000, 0)
+			if l.Len() != 200 {
+				t.Fatalf("Len() = %d, want 200", l.Len())
+			}
+			for i := 0; i < 200; i++ {
+				if v, ok := l.Search(i); !ok || v != i*i {
+					t.Fatalf("Search(%d) = %d, %v", i, v, ok)
+				}
+			}
+			if _, ok := l.Search(1000); ok {
+				t.Error("immutable copy observed a later insert")
+			}
+		})
+	}
+}
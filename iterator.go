@@ -12,6 +12,10 @@ import "sync/atomic"
 //		// ...
 //	}
 //
+// An Iterator must not be copied by value: a copy shares the read lock held
+// by a RangeIterator and would release it twice. Use Clone to duplicate an
+// iterator; go vet reports copies.
+//
 // Iterator คือโครงสร้างที่ใช้สำหรับวนลูปผ่านรายการใน Skiplist
 // รูปแบบการใช้งานทั่วไป:
 //
@@ -22,6 +26,7 @@ import "sync/atomic"
 //		// ...
 //	}
 type Iterator[K any, V any] struct {
+	noCopy  noCopy          // ให้ go vet ตรวจจับการคัดลอก Iterator
 	sl      *SkipList[K, V] // อ้างอิงถึง Skiplist ที่กำลังวนลูป
 	current INode[K, V]     // โหนดปัจจุบันที่ Iterator ชี้อยู่
 	reverse bool
@@ -29,6 +34,10 @@ type Iterator[K any, V any] struct {
 	// Optional inclusive end bound for iteration. If set, Next() stops before any key > end.
 	end    K
 	hasEnd bool
+	// Optional inclusive start bound, set by RangeWithIteratorBounds: Seek,
+	// SeekToRank and backward moves never go below it.
+	start    K
+	hasStart bool
 	// If non-zero, the iterator holds sl.mutex.RLock() and Close() must be called to release it.
 	// Use an atomic uint32 to make Close() safe against concurrent Close() calls.
 	lockHeld uint32
@@ -302,10 +311,71 @@ func (it *Iterator[K, V]) Prev() bool {
 
 	it.current = currentNode.backward
 
-	if it.current == it.sl.header {
+	if it.current == it.sl.header || (it.hasStart && it.sl.compare(it.current.Key(), it.start) < 0) {
+		it.current = nil
+		return false
+	}
+	return true
+}
+
+// Skip moves the iterator k elements in its direction of iteration and
+// returns true if it then points at an element, with the same result as k
+// calls to Next. Skipping forward costs O(log k) by following the upper
+// levels from the current node; skipping backward (Skip on a WithReverse
+// iterator) costs O(log k) with WithBidirectionalLevels and O(log n)
+// otherwise. Skip(0) reports whether the iterator points at an element.
+// It panics if k is negative.
+// Skip เลื่อน Iterator ไป k รายการตามทิศทางการวนลูป ให้ผลเหมือนการเรียก Next k ครั้ง
+func (it *Iterator[K, V]) Skip(k int) bool {
+	if k < 0 {
+		panic("skiplist: Skip called with a negative count")
+	}
+	if !it.unsafe {
+		it.sl.mutex.RLock()
+		defer it.sl.mutex.RUnlock()
+	}
+
+	sl := it.sl
+	cur, _ := it.current.(*node[K, V])
+	if it.reverse && cur == nil {
+		// As in Next, the first move of a reverse iterator lands on the last
+		// element within the end bound.
+		if k == 0 {
+			return false
+		}
+		cur = sl.header
+		for i := sl.level; i >= 0; i-- {
+			for cur.forward[i] != nil && (!it.hasEnd || sl.compare(cur.forward[i].key, it.end) <= 0) {
+				cur = cur.forward[i]
+			}
+		}
+		k--
+	}
+	if cur == nil || (cur == sl.header && k == 0) {
+		return false
+	}
+
+	var target *node[K, V]
+	switch {
+	case cur == sl.header:
+		// Only a forward iterator starts at the header; an empty list or a
+		// reverse iterator whose bound is below every key ends up here too.
+		if !it.reverse {
+			target = sl.skipForward(cur, k)
+		}
+	case it.reverse:
+		target = sl.skipBackward(cur, k)
+	default:
+		target = sl.skipForward(cur, k)
+		if target != nil && it.hasEnd && sl.compare(target.key, it.end) > 0 {
+			target = nil
+		}
+	}
+	if target == nil {
 		it.current = nil
 		return false
 	}
+	it.current = target
 	return true
 }
 
@@ -456,6 +526,9 @@ func (it *Iterator[K, V]) Seek(key K) bool {
 		defer it.sl.mutex.RUnlock()
 	}
 
+	if it.hasStart && it.sl.compare(key, it.start) < 0 {
+		key = it.start
+	}
 	// Reuse SkipList's findGreaterOrEqual for the correct ceiling node logic.
 	found := it.findGreaterOrEqual(key)
 
@@ -472,10 +545,39 @@ func (it *Iterator[K, V]) Seek(key K) bool {
 	return found != nil
 }
 
+// SeekToRank moves the iterator to the element at the given 0-based rank
+// and returns true if it exists. Within RangeWithIteratorBounds, the rank is
+// relative to the window: rank 0 is the first element >= start, and a rank
+// past the last element <= end exhausts the iterator, so that the iterator
+// never leaves the window. Otherwise, it is the rank in the whole list.
+// The position is found by span arithmetic in O(log n).
+// SeekToRank เลื่อน Iterator ไปยังรายการ ณ อันดับที่กำหนด (นับภายในช่วงของ RangeWithIteratorBounds)
+func (it *Iterator[K, V]) SeekToRank(rank int) bool {
+	if !it.unsafe {
+		it.sl.mutex.RLock()
+		defer it.sl.mutex.RUnlock()
+	}
+	sl := it.sl
+	lo, hi := 0, sl.length
+	if it.hasStart {
+		lo = sl.rank(it.start, false)
+	}
+	if it.hasEnd {
+		hi = sl.rank(it.end, true)
+	}
+	if rank < 0 || lo+rank >= hi {
+		it.current = nil
+		return false
+	}
+	it.current = sl.getByRank(lo + rank)
+	return true
+}
+
 func (it *Iterator[K, V]) findGreaterOrEqual(key K) *node[K, V] {
 	current := it.sl.header
+	kp := it.sl.prefixOf(key)
 	for i := it.sl.level; i >= 0; i-- {
-		for current.forward[i] != nil && it.sl.compare(current.forward[i].key, key) < 0 {
+		for current.forward[i] != nil && it.sl.compareNode(current.forward[i], key, kp) < 0 {
 			current = current.forward[i]
 		}
 	}
@@ -491,9 +593,13 @@ func (it *Iterator[K, V]) Clone() *Iterator[K, V] {
 	// A shallow copy is sufficient as the underlying skiplist is shared,
 	// and the iterator's state is just a pointer and flags.
 	return &Iterator[K, V]{
-		sl:      it.sl,
-		current: it.current,
-		unsafe:  it.unsafe,
-		reverse: it.reverse,
+		sl:       it.sl,
+		current:  it.current,
+		unsafe:   it.unsafe,
+		reverse:  it.reverse,
+		end:      it.end,
+		hasEnd:   it.hasEnd,
+		start:    it.start,
+		hasStart: it.hasStart,
 	}
 }
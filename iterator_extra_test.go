@@ -9,6 +9,7 @@ import (
 // Test that a RangeIterator holds the read lock until Close() is called,
 // which blocks writers (Insert) until the iterator is closed.
 func TestRangeIterator_CloseConcurrency(t *testing.T) {
+	skipWithoutSync(t)
 	for _, setup := range getTestSetups[int, int]() {
 		t.Run(setup.name, func(t *testing.T) {
 			sl := setup.constructor(nil)
@@ -198,3 +199,54 @@ func TestIterator_ReverseWithEnd(t *testing.T) {
 		})
 	}
 }
+
+func TestRangeWithIteratorBounds(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			for i := 0; i < 100; i += 10 {
+				sl.Insert(i, i)
+			}
+			sl.RangeWithIteratorBounds(25, 65, func(it *Iterator[int, int]) {
+				var keys []int
+				for it.Next() {
+					keys = append(keys, it.Key())
+				}
+				if !reflect.DeepEqual(keys, []int{30, 40, 50, 60}) {
+					t.Errorf("window keys = %v", keys)
+				}
+
+				// Seek clamps to the window.
+				if !it.Seek(0) || it.Key() != 30 {
+					t.Error("Seek below the window did not land on its first key")
+				}
+				if it.Prev() {
+					t.Errorf("Prev left the window to %d", it.Key())
+				}
+				if !it.Seek(45) || it.Key() != 50 || it.Seek(70) {
+					t.Error("Seek inside or above the window misbehaved")
+				}
+
+				// Ranks count from the start of the window.
+				for r, want := range []int{30, 40, 50, 60} {
+					if !it.SeekToRank(r) || it.Key() != want {
+						t.Errorf("SeekToRank(%d) did not land on %d", r, want)
+					}
+				}
+				if it.SeekToRank(4) || it.SeekToRank(-1) {
+					t.Error("SeekToRank outside the window succeeded")
+				}
+				c := it.Clone()
+				if c.Seek(0); c.Key() != 30 {
+					t.Error("Clone lost the window")
+				}
+			})
+
+			// Without a window, SeekToRank uses absolute ranks.
+			it := sl.NewIterator()
+			if !it.SeekToRank(9) || it.Key() != 90 || it.SeekToRank(10) {
+				t.Error("SeekToRank on a plain iterator misbehaved")
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package skiplist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// The Append functions below encode the fields of a composite key into a
+// []byte whose bytes.Compare order is the order of the fields, compared one
+// after the other, so that a list created with NewBytes can index
+// multi-field keys without a custom comparator:
+//
+//	key := skiplist.AppendString(nil, tenant)
+//	key = skiplist.AppendTime(key, created)
+//	key = skiplist.AppendInt64(key, id)
+//
+// Fixed-size fields are written big-endian with the bits that break the
+// unsigned order (sign bits, float encodings) transformed; strings and byte
+// slices are escaped and terminated, so that a shorter value sorts before
+// its extensions and the following fields never compare against string
+// bytes. The Decode functions read the fields back in the same order, each
+// returning the rest of the key.
+//
+// ฟังก์ชัน Append แปลง field ของ key หลายส่วนเป็น []byte ที่ลำดับของ bytes.Compare
+// ตรงกับลำดับของ field เพื่อใช้เป็น key ของ NewBytes
+
+// ErrKeyEncoding is returned by the Decode functions when the key does not
+// hold a field of the expected type at its start.
+var ErrKeyEncoding = errors.New("skiplist: malformed key encoding")
+
+// Strings and byte slices end with terminator; a zero byte inside them is
+// written as escapedZero.
+var (
+	terminator  = []byte{0x00, 0x01}
+	escapedZero = []byte{0x00, 0xFF}
+)
+
+// AppendUint64 appends the order-preserving encoding of v to b.
+// AppendUint64 เข้ารหัส uint64 ต่อท้าย b โดยรักษาลำดับ
+func AppendUint64(b []byte, v uint64) []byte {
+	return binary.BigEndian.AppendUint64(b, v)
+}
+
+// AppendInt64 appends the order-preserving encoding of v to b: negative
+// values sort before positive ones.
+// AppendInt64 เข้ารหัส int64 ต่อท้าย b โดยรักษาลำดับ
+func AppendInt64(b []byte, v int64) []byte {
+	return AppendUint64(b, uint64(v)^1<<63)
+}
+
+// AppendFloat64 appends the order-preserving encoding of f to b, in the
+// totalOrder of IEEE 754 (see WithTotalOrderFloats).
+// AppendFloat64 เข้ารหัส float64 ต่อท้าย b ตาม totalOrder
+func AppendFloat64(b []byte, f float64) []byte {
+	return AppendUint64(b, totalOrderBits(f))
+}
+
+// AppendBool appends the order-preserving encoding of v to b: false sorts
+// before true.
+// AppendBool เข้ารหัส bool ต่อท้าย b (false มาก่อน true)
+func AppendBool(b []byte, v bool) []byte {
+	if v {
+		return append(b, 1)
+	}
+	return append(b, 0)
+}
+
+// AppendTime appends the order-preserving encoding of t to b, as its Unix
+// seconds and nanoseconds. The location and monotonic reading of t are not
+// encoded.
+// AppendTime เข้ารหัสเวลาต่อท้าย b โดยรักษาลำดับ
+func AppendTime(b []byte, t time.Time) []byte {
+	b = AppendInt64(b, t.Unix())
+	return binary.BigEndian.AppendUint32(b, uint32(t.Nanosecond()))
+}
+
+// AppendString appends the order-preserving encoding of s to b.
+// AppendString เข้ารหัส string ต่อท้าย b โดยรักษาลำดับ
+func AppendString(b []byte, s string) []byte {
+	for {
+		i := strings.IndexByte(s, 0)
+		if i < 0 {
+			b = append(b, s...)
+			return append(b, terminator...)
+		}
+		b = append(append(b, s[:i]...), escapedZero...)
+		s = s[i+1:]
+	}
+}
+
+// AppendBytes appends the order-preserving encoding of v to b.
+// AppendBytes เข้ารหัส []byte ต่อท้าย b โดยรักษาลำดับ
+func AppendBytes(b, v []byte) []byte {
+	return AppendString(b, string(v))
+}
+
+// DecodeUint64 decodes a field written by AppendUint64.
+// DecodeUint64 ถอดรหัส field ที่เขียนด้วย AppendUint64
+func DecodeUint64(b []byte) (uint64, []byte, error) {
+	if len(b) < 8 {
+		return 0, b, ErrKeyEncoding
+	}
+	return binary.BigEndian.Uint64(b), b[8:], nil
+}
+
+// DecodeInt64 decodes a field written by AppendInt64.
+// DecodeInt64 ถอดรหัส field ที่เขียนด้วย AppendInt64
+func DecodeInt64(b []byte) (int64, []byte, error) {
+	u, rest, err := DecodeUint64(b)
+	if err != nil {
+		return 0, b, err
+	}
+	return int64(u ^ 1<<63), rest, nil
+}
+
+// DecodeFloat64 decodes a field written by AppendFloat64.
+// DecodeFloat64 ถอดรหัส field ที่เขียนด้วย AppendFloat64
+func DecodeFloat64(b []byte) (float64, []byte, error) {
+	u, rest, err := DecodeUint64(b)
+	if err != nil {
+		return 0, b, err
+	}
+	if u>>63 != 0 {
+		return math.Float64frombits(u &^ (1 << 63)), rest, nil
+	}
+	return math.Float64frombits(^u), rest, nil
+}
+
+// DecodeBool decodes a field written by AppendBool.
+// DecodeBool ถอดรหัส field ที่เขียนด้วย AppendBool
+func DecodeBool(b []byte) (bool, []byte, error) {
+	if len(b) < 1 || b[0] > 1 {
+		return false, b, ErrKeyEncoding
+	}
+	return b[0] == 1, b[1:], nil
+}
+
+// DecodeTime decodes a field written by AppendTime, in UTC.
+// DecodeTime ถอดรหัส field ที่เขียนด้วย AppendTime
+func DecodeTime(b []byte) (time.Time, []byte, error) {
+	sec, rest, err := DecodeInt64(b)
+	if err != nil || len(rest) < 4 {
+		return time.Time{}, b, ErrKeyEncoding
+	}
+	nsec := binary.BigEndian.Uint32(rest)
+	return time.Unix(sec, int64(nsec)).UTC(), rest[4:], nil
+}
+
+// DecodeString decodes a field written by AppendString.
+// DecodeString ถอดรหัส field ที่เขียนด้วย AppendString
+func DecodeString(b []byte) (string, []byte, error) {
+	v, rest, err := DecodeBytes(b)
+	return string(v), rest, err
+}
+
+// DecodeBytes decodes a field written by AppendBytes into a new slice.
+// DecodeBytes ถอดรหัส field ที่เขียนด้วย AppendBytes
+func DecodeBytes(b []byte) ([]byte, []byte, error) {
+	var out []byte
+	for rest := b; ; {
+		i := bytes.IndexByte(rest, 0)
+		if i < 0 || i+1 == len(rest) {
+			return nil, b, ErrKeyEncoding
+		}
+		out = append(out, rest[:i]...)
+		switch rest[i+1] {
+		case terminator[1]:
+			if out == nil {
+				out = []byte{}
+			}
+			return out, rest[i+2:], nil
+		case escapedZero[1]:
+			out = append(out, 0)
+			rest = rest[i+2:]
+		default:
+			return nil, b, ErrKeyEncoding
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package skiplist
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+// composite is a key with one field of every encoded type.
+type composite struct {
+	s   string
+	i   int64
+	f   float64
+	t   time.Time
+	ok  bool
+	u   uint64
+	raw []byte
+}
+
+func (c composite) encode() []byte {
+	b := AppendString(nil, c.s)
+	b = AppendInt64(b, c.i)
+	b = AppendFloat64(b, c.f)
+	b = AppendTime(b, c.t)
+	b = AppendBool(b, c.ok)
+	b = AppendUint64(b, c.u)
+	return AppendBytes(b, c.raw)
+}
+
+func compareComposite(a, b composite) int {
+	return cmp.Or(
+		cmp.Compare(a.s, b.s),
+		cmp.Compare(a.i, b.i),
+		CompareTotalOrder(a.f, b.f),
+		a.t.Compare(b.t),
+		cmp.Compare(b2i(a.ok), b2i(b.ok)),
+		cmp.Compare(a.u, b.u),
+		bytes.Compare(a.raw, b.raw),
+	)
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestKeyEncodingOrder(t *testing.T) {
+	strs := []string{"", "a", "a\x00", "a\x00b", "ab", "\x00", "\xff", "b"}
+	ints := []int64{math.MinInt64, -1, 0, 1, math.MaxInt64}
+	floats := []float64{math.Inf(-1), -1.5, math.Copysign(0, -1), 0, 2.5, math.Inf(1)}
+	times := []time.Time{time.Unix(-100, 5), time.Unix(0, 0), time.Unix(0, 999), time.Unix(1e9, 1)}
+	var keys []composite
+	for i := 0; i < 2000; i++ {
+		keys = append(keys, composite{
+			s:   strs[rand.IntN(len(strs))],
+			i:   ints[rand.IntN(len(ints))],
+			f:   floats[rand.IntN(len(floats))],
+			t:   times[rand.IntN(len(times))],
+			ok:  rand.IntN(2) == 1,
+			u:   uint64(rand.IntN(3)) << 62,
+			raw: []byte(strs[rand.IntN(len(strs))]),
+		})
+	}
+	for i := 1; i < len(keys); i++ {
+		a, b := keys[i-1], keys[i]
+		if got, want := bytes.Compare(a.encode(), b.encode()), compareComposite(a, b); got != want {
+			t.Fatalf("encoded %+v vs %+v compare %d, fields compare %d", a, b, got, want)
+		}
+	}
+
+	// A bytes list orders the encoded keys like the fields.
+	sl := NewBytes[composite]()
+	for _, k := range keys {
+		sl.Insert(k.encode(), k)
+	}
+	var got []composite
+	sl.Range(func(_ []byte, v composite) bool { got = append(got, v); return true })
+	if !slices.IsSortedFunc(got, compareComposite) {
+		t.Error("bytes order of the encoded keys differs from the field order")
+	}
+}
+
+func TestKeyEncodingRoundTrip(t *testing.T) {
+	want := composite{s: "x\x00y", i: -42, f: -0.25, t: time.Unix(1700000000, 123).UTC(), ok: true, u: 7, raw: []byte{0, 0, 1}}
+	b := want.encode()
+
+	var got composite
+	var err error
+	if got.s, b, err = DecodeString(b); err != nil {
+		t.Fatal(err)
+	}
+	if got.i, b, err = DecodeInt64(b); err != nil {
+		t.Fatal(err)
+	}
+	if got.f, b, err = DecodeFloat64(b); err != nil {
+		t.Fatal(err)
+	}
+	if got.t, b, err = DecodeTime(b); err != nil {
+		t.Fatal(err)
+	}
+	if got.ok, b, err = DecodeBool(b); err != nil {
+		t.Fatal(err)
+	}
+	if got.u, b, err = DecodeUint64(b); err != nil {
+		t.Fatal(err)
+	}
+	if got.raw, b, err = DecodeBytes(b); err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 0 || compareComposite(got, want) != 0 || !got.t.Equal(want.t) {
+		t.Errorf("decoded %+v with %d bytes left, want %+v", got, len(b), want)
+	}
+
+	for _, bad := range [][]byte{nil, {'a'}, {'a', 0}, {'a', 0, 7}} {
+		if _, rest, err := DecodeString(bad); !errors.Is(err, ErrKeyEncoding) || !bytes.Equal(rest, bad) {
+			t.Errorf("DecodeString(%q) = %v, %q", bad, err, rest)
+		}
+	}
+	if _, _, err := DecodeBool([]byte{2}); !errors.Is(err, ErrKeyEncoding) {
+		t.Errorf("DecodeBool(2) = %v", err)
+	}
+	if _, _, err := DecodeTime(AppendInt64(nil, 1)); !errors.Is(err, ErrKeyEncoding) {
+		t.Errorf("DecodeTime(truncated) = %v", err)
+	}
+}
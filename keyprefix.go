@@ -0,0 +1,58 @@
+package skiplist
+
+import "encoding/binary"
+
+// WithKeyPrefix caches a fixed-size prefix of every key in its node. During a
+// descent the cached prefixes are compared first, and the comparator is only
+// called when the prefixes are equal. For long keys that differ early (e.g.
+// random identifiers or hashes) this removes most comparator calls.
+//
+// prefix must preserve the key order: whenever compare(a, b) < 0, prefix(a)
+// must be less than or equal to prefix(b). StringKeyPrefix and BytesKeyPrefix
+// satisfy this for the natural order of strings and byte slices.
+// WithKeyPrefix เก็บ prefix ขนาดคงที่ของ key ไว้ในโหนดเพื่อลดการเรียก comparator
+// ฟังก์ชัน prefix ต้องรักษาลำดับของ key
+func WithKeyPrefix[K any, V any](prefix func(K) uint64) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.keyPrefix = prefix
+	}
+}
+
+// StringKeyPrefix returns the first eight bytes of s as a big-endian integer,
+// padded with zeros. It is an order-preserving prefix for WithKeyPrefix.
+// StringKeyPrefix คืนค่า 8 byte แรกของ s ในรูปแบบ big-endian
+func StringKeyPrefix(s string) uint64 {
+	var b [8]byte
+	copy(b[:], s)
+	return binary.BigEndian.Uint64(b[:])
+}
+
+// BytesKeyPrefix returns the first eight bytes of k as a big-endian integer,
+// padded with zeros. It is an order-preserving prefix for WithKeyPrefix.
+// BytesKeyPrefix คืนค่า 8 byte แรกของ k ในรูปแบบ big-endian
+func BytesKeyPrefix(k []byte) uint64 {
+	var b [8]byte
+	copy(b[:], k)
+	return binary.BigEndian.Uint64(b[:])
+}
+
+// prefixOf returns the cached prefix for key, or 0 when WithKeyPrefix is not
+// used. Since every node then also holds 0, prefixes never decide a comparison.
+func (sl *SkipList[K, V]) prefixOf(key K) uint64 {
+	if sl.keyPrefix == nil {
+		return 0
+	}
+	return sl.keyPrefix(key)
+}
+
+// compareNode compares the key of n with key, whose prefix is kp. The
+// comparator is only called when the prefixes are equal.
+func (sl *SkipList[K, V]) compareNode(n *node[K, V], key K, kp uint64) int {
+	if n.prefix != kp {
+		if n.prefix < kp {
+			return -1
+		}
+		return 1
+	}
+	return sl.compare(n.key, key)
+}
@@ -0,0 +1,82 @@
+package skiplist
+
+import (
+	"cmp"
+	"fmt"
+	"testing"
+)
+
+func TestKeyPrefixHelpers(t *testing.T) {
+	keys := []string{"", "\x00", "a", "a\x00", "ab", "abcdefgh", "abcdefgh\x00", "abcdefgi", "b", "\xff\xff"}
+	for _, a := range keys {
+		for _, b := range keys {
+			if a < b && StringKeyPrefix(a) > StringKeyPrefix(b) {
+				t.Errorf("StringKeyPrefix(%q) > StringKeyPrefix(%q)", a, b)
+			}
+			if StringKeyPrefix(a) != BytesKeyPrefix([]byte(a)) {
+				t.Errorf("StringKeyPrefix(%q) != BytesKeyPrefix(%q)", a, a)
+			}
+		}
+	}
+}
+
+func TestWithKeyPrefix(t *testing.T) {
+	for _, setup := range getTestSetups[string, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			calls := 0
+			compare := func(a, b string) int {
+				calls++
+				return cmp.Compare(a, b)
+			}
+			sl := setup.constructor(compare, WithKeyPrefix[string, int](StringKeyPrefix))
+
+			// Half of the keys share their first eight bytes, so both the
+			// prefix and the comparator paths are exercised.
+			for i := 0; i < 500; i++ {
+				sl.Insert(fmt.Sprintf("%08x", i*7919), i)
+				sl.Insert(fmt.Sprintf("shared--%08x", i), i)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < 500; i++ {
+				if n, ok := sl.Search(fmt.Sprintf("%08x", i*7919)); !ok || n.Value() != i {
+					t.Fatalf("Search(%08x) = %v, %v", i*7919, n, ok)
+				}
+				if n, ok := sl.Search(fmt.Sprintf("shared--%08x", i)); !ok || n.Value() != i {
+					t.Fatalf("Search(shared--%08x) = %v, %v", i, n, ok)
+				}
+			}
+			if n, ok := sl.Successor("shared--00000001"); !ok || n.Key() != "shared--00000002" {
+				t.Errorf("Successor() = %v, %v", n, ok)
+			}
+			if n, ok := sl.Predecessor("shared--00000000"); !ok || n.Key() >= "shared--" {
+				t.Errorf("Predecessor() = %v, %v", n, ok)
+			}
+			if r := sl.Rank("shared--00000000"); r != 500 {
+				t.Errorf("Rank() = %d, want 500", r)
+			}
+			if !sl.Delete("shared--00000010") || sl.Delete("shared--00000010") {
+				t.Error("Delete() of a shared-prefix key did not behave as expected")
+			}
+			if n, ok := sl.PopMax(); !ok || n.Key() != "shared--000001f3" {
+				t.Errorf("PopMax() = %v, %v", n, ok)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			// The descent for a key whose prefix differs from every stored
+			// prefix is decided by prefixes alone; only Search's final
+			// equality check calls the comparator.
+			calls = 0
+			if _, ok := sl.Search("0000000g"); ok {
+				t.Error("Search() found an absent key")
+			}
+			if calls != 1 {
+				t.Errorf("Search called the comparator %d times, want 1", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package skiplist
+
+import (
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+// latencyReservoirSize is the number of latencies kept per operation type
+// by WithLatencySampling.
+const latencyReservoirSize = 1024
+
+// LatencySummary summarizes the latencies sampled for one operation type
+// (see WithLatencySampling). The percentiles are computed over the
+// reservoir, a uniform random sample of at most 1024 of the sampled
+// operations.
+// LatencySummary คือสรุประยะเวลาของ operation ประเภทหนึ่งที่ถูกสุ่มเก็บไว้
+type LatencySummary struct {
+	// Sampled is the number of operations sampled since the list was
+	// created, and Samples the number of latencies in the reservoir.
+	Sampled uint64 `json:"sampled"`
+	Samples int    `json:"samples"`
+
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// WithLatencySampling measures the latency of a random fraction rate of the
+// traced operations (see Tracer) and keeps, per operation type, a uniform
+// random sample of at most 1024 of all the latencies sampled since the list
+// was created (reservoir sampling, not a window of the latest ones), so that
+// tail latencies can be monitored without instrumenting every call site:
+// Stats reports their percentiles in Latency, and LatencySamples returns the
+// raw reservoir of an operation type. Latencies include the time spent
+// waiting for the lock, as in TraceInfo.Duration. Operations not sampled
+// cost one random number; a rate of 0.01 keeps the overhead negligible on
+// hot paths. It panics unless 0 < rate <= 1.
+// WithLatencySampling สุ่มวัดระยะเวลาของ operation ตามสัดส่วน rate และเก็บไว้แยกตามประเภทเพื่อดู tail latency ผ่าน Stats
+func WithLatencySampling[K any, V any](rate float64) Option[K, V] {
+	if !(rate > 0 && rate <= 1) {
+		panic("skiplist: latency sampling rate must be in (0, 1]")
+	}
+	return func(sl *SkipList[K, V]) {
+		sl.latency = &latencySampler{rate: rate}
+	}
+}
+
+// latencySampler holds the reservoirs of WithLatencySampling. Sampled read
+// operations run concurrently under the read lock, so each reservoir has a
+// mutex of its own.
+type latencySampler struct {
+	rate       float64
+	reservoirs [len(opNames)]latencyReservoir
+}
+
+// latencyReservoir keeps a uniform sample of the latencies of one operation
+// type with reservoir sampling (Algorithm R).
+type latencyReservoir struct {
+	mu      syncMutex
+	seen    uint64
+	samples []time.Duration
+}
+
+// sample reports whether the operation about to start is measured.
+func (s *latencySampler) sample() bool {
+	return s.rate >= 1 || rand.Float64() < s.rate
+}
+
+// record adds the latency d of a sampled operation of type op.
+func (s *latencySampler) record(op Op, d time.Duration) {
+	r := &s.reservoirs[op]
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seen++
+	if len(r.samples) < latencyReservoirSize {
+		r.samples = append(r.samples, d)
+	} else if i := rand.Uint64N(r.seen); i < latencyReservoirSize {
+		r.samples[i] = d
+	}
+}
+
+// summaries returns the summary of every operation type with samples, by
+// name, or nil if there is none.
+func (s *latencySampler) summaries() map[string]LatencySummary {
+	var m map[string]LatencySummary
+	for op := range s.reservoirs {
+		r := &s.reservoirs[op]
+		r.mu.Lock()
+		seen, sorted := r.seen, slices.Clone(r.samples)
+		r.mu.Unlock()
+		if len(sorted) == 0 {
+			continue
+		}
+		slices.Sort(sorted)
+		at := func(q float64) time.Duration {
+			return sorted[min(len(sorted)-1, int(q*float64(len(sorted))))]
+		}
+		if m == nil {
+			m = make(map[string]LatencySummary)
+		}
+		m[Op(op).String()] = LatencySummary{
+			Sampled: seen,
+			Samples: len(sorted),
+			P50:     at(0.50),
+			P90:     at(0.90),
+			P99:     at(0.99),
+			Max:     sorted[len(sorted)-1],
+		}
+	}
+	return m
+}
+
+// LatencySamples returns a copy of the reservoir of latencies sampled for
+// op, in no particular order, or nil without WithLatencySampling.
+// LatencySamples คืนค่าสำเนาของระยะเวลาที่สุ่มเก็บไว้สำหรับ operation ประเภท op
+func (sl *SkipList[K, V]) LatencySamples(op Op) []time.Duration {
+	if sl.latency == nil || int(op) >= len(sl.latency.reservoirs) {
+		return nil
+	}
+	r := &sl.latency.reservoirs[op]
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.samples)
+}
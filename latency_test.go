@@ -0,0 +1,56 @@
+package skiplist
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWithLatencySampling(t *testing.T) {
+	sl := New[int, int](WithLatencySampling[int, int](1))
+	for i := 0; i < 3000; i++ {
+		sl.Insert(i, i)
+	}
+	for i := 0; i < 10; i++ {
+		sl.Search(i)
+	}
+
+	st := sl.Stats()
+	ins, ok := st.Latency["Insert"]
+	if !ok || ins.Sampled != 3000 || ins.Samples != latencyReservoirSize {
+		t.Fatalf("Latency[Insert] = %+v, %v", ins, ok)
+	}
+	if !(0 < ins.P50 && ins.P50 <= ins.P90 && ins.P90 <= ins.P99 && ins.P99 <= ins.Max) {
+		t.Errorf("Insert percentiles out of order: %+v", ins)
+	}
+	if s := st.Latency["Search"]; s.Sampled != 10 || s.Samples != 10 {
+		t.Errorf("Latency[Search] = %+v", s)
+	}
+	if _, ok := st.Latency["Delete"]; ok {
+		t.Error("Latency reports an operation that never ran")
+	}
+	if n := len(sl.LatencySamples(OpSearch)); n != 10 {
+		t.Errorf("LatencySamples(OpSearch) has %d samples, want 10", n)
+	}
+	if _, err := json.Marshal(st); err != nil {
+		t.Fatal(err)
+	}
+
+	// A low rate samples a fraction of the operations.
+	sl = New[int, int](WithLatencySampling[int, int](0.1))
+	for i := 0; i < 10000; i++ {
+		sl.Search(i)
+	}
+	if s := sl.Stats().Latency["Search"]; s.Sampled < 500 || s.Sampled > 1500 {
+		t.Errorf("rate 0.1 sampled %d of 10000 operations", s.Sampled)
+	}
+
+	if New[int, int]().Stats().Latency != nil || New[int, int]().LatencySamples(OpInsert) != nil {
+		t.Error("latencies reported without WithLatencySampling")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("WithLatencySampling(0) did not panic")
+		}
+	}()
+	WithLatencySampling[int, int](0)
+}
@@ -0,0 +1,155 @@
+package skiplist
+
+import "slices"
+
+// Layered is a read view over an active skiplist and the frozen lists
+// detached from it by Rotate, so that readers see every entry while the frozen
+// lists are being flushed. Lookups consult the active list first, then the
+// frozen lists from the newest to the oldest; the first layer holding a key
+// shadows the older ones.
+//
+// A Layered view does not observe deletions across layers: a key deleted from
+// the active list stays visible if an older frozen list holds it. Stores that
+// need deletes to shadow older layers write a tombstone value instead.
+//
+// A Layered view is safe for concurrent use. Rotate the active list through
+// the view (Layered.Rotate) and release frozen lists through it
+// (Layered.Release): both swap the set of layers under the lock of the view,
+// which Search, Range and RangeQuery hold for their whole duration and Seek
+// for its positioning, so a concurrent rotation never hides an entry from
+// them. Rotating the active list directly leaves the detached entries out of
+// the view.
+//
+// Layered คือมุมมองสำหรับอ่านที่รวม skiplist ที่ใช้งานอยู่กับ frozen list ที่แยกออกมาด้วย Rotate
+// การค้นหาจะตรวจสอบ list ที่ใช้งานอยู่ก่อน แล้วจึงตรวจสอบ frozen list จากใหม่ไปเก่า
+type Layered[K any, V any] struct {
+	mu     syncRWMutex
+	layers []*SkipList[K, V] // newest first
+	gen    uint64            // incremented when layers changes
+}
+
+// NewLayered creates a view over active and the frozen lists, which must be
+// given from the newest to the oldest. All lists must order keys the same way.
+// NewLayered สร้างมุมมองจาก active และ frozen list (เรียงจากใหม่ไปเก่า)
+func NewLayered[K any, V any](active *SkipList[K, V], frozen ...*FrozenSkipList[K, V]) *Layered[K, V] {
+	layers := make([]*SkipList[K, V], 0, 1+len(frozen))
+	layers = append(layers, active)
+	for _, f := range frozen {
+		layers = append(layers, f.sl)
+	}
+	return &Layered[K, V]{layers: layers}
+}
+
+// Rotate rotates the active list (see SkipList.Rotate) and adds the frozen
+// list to the view as its newest frozen layer, in one step for the readers
+// of the view.
+// Rotate เรียก Rotate ของ list ที่ใช้งานอยู่และเพิ่ม frozen list เข้าในมุมมองในขั้นตอนเดียว
+func (l *Layered[K, V]) Rotate() *FrozenSkipList[K, V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f := l.layers[0].Rotate()
+	l.layers = slices.Insert(l.layers, 1, f.sl)
+	l.gen++
+	return f
+}
+
+// Release removes the frozen list f from the view, then releases it (see
+// FrozenSkipList.Release). Flush f first: its entries are no longer visible
+// through the view. Iterators of the view opened before Release must not be
+// used afterwards, since they may still walk the nodes of f.
+// Release นำ frozen list f ออกจากมุมมองแล้วจึงปล่อยหน่วยความจำคืน
+func (l *Layered[K, V]) Release(f *FrozenSkipList[K, V]) {
+	l.mu.Lock()
+	for i := 1; i < len(l.layers); i++ {
+		if l.layers[i] == f.sl {
+			l.layers = slices.Delete(l.layers, i, i+1)
+			l.gen++
+			break
+		}
+	}
+	l.mu.Unlock()
+	f.Release()
+}
+
+// Search returns the node of key in the newest layer that holds it.
+// Search ค้นหา key ใน layer ที่ใหม่ที่สุดที่มี key นั้นอยู่
+func (l *Layered[K, V]) Search(key K) (INode[K, V], bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, sl := range l.layers {
+		if n, ok := sl.Search(key); ok {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// Range iterates over the merged entries of all layers in ascending key order
+// until f returns false. Each key is visited once, with its newest value.
+// Range วนลูปไปตามรายการที่รวมจากทุก layer ตามลำดับ key จนกว่า f จะคืนค่า false
+func (l *Layered[K, V]) Range(f func(key K, value V) bool) {
+	defer rethrowCallbackPanic("Range")
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	it := l.iterators()
+	for it.Next() {
+		if !f(it.Key(), it.Value()) {
+			return
+		}
+	}
+}
+
+// RangeQuery iterates over the merged entries whose key is between start and
+// end (inclusive) in ascending key order until f returns false.
+// RangeQuery วนลูปไปตามรายการที่รวมจากทุก layer ที่ key อยู่ระหว่าง start และ end
+func (l *Layered[K, V]) RangeQuery(start, end K, f func(key K, value V) bool) {
+	defer rethrowCallbackPanic("RangeQuery")
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	it := l.iterators(WithEnd[K, V](end))
+	for ok := it.Seek(start); ok; ok = it.Next() {
+		if !f(it.Key(), it.Value()) {
+			return
+		}
+	}
+}
+
+// NewIterator creates an iterator over the merged entries of all layers. Like
+// Iterator, it is positioned before the first entry and each step locks the
+// layers only briefly, so writes to the active list may interleave with the
+// iteration. Seek merges the layers the view holds at the time of the call.
+// Layered.Release invalidates every iterator of the view opened before it:
+// the nodes of the released list may be reused by the next Rotate, so such
+// iterators must not be used afterwards.
+// NewIterator สร้าง iterator ที่รวมรายการจากทุก layer ตามลำดับ key
+func (l *Layered[K, V]) NewIterator() *MergeIterator[K, V] {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	m := l.iterators()
+	m.view, m.gen = l, l.gen
+	return m
+}
+
+// iterators merges new iterators over the current layers. The caller must
+// hold a lock of l.
+func (l *Layered[K, V]) iterators(opts ...IteratorOption[K, V]) *MergeIterator[K, V] {
+	its := make([]*Iterator[K, V], len(l.layers))
+	for i, sl := range l.layers {
+		its[i] = sl.NewIterator(opts...)
+	}
+	return NewMergeIterator(l.layers[0].compare, its...)
+}
+
+// pin read-locks l for a Seek of m and, when the layers have changed since m
+// was built, replaces the inputs of m with iterators over the current layers.
+// Iterators already started keep their position in the nodes detached by a
+// rotation, but a Seek looks keys up from the list headers and would miss
+// the layers added since.
+func (l *Layered[K, V]) pin(m *MergeIterator[K, V]) {
+	l.mu.RLock()
+	if m.gen != l.gen {
+		fresh := l.iterators()
+		m.its, m.keys, m.gen = fresh.its, fresh.keys, l.gen
+		m.heap = make([]int, 0, len(m.its))
+	}
+}
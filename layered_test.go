@@ -0,0 +1,147 @@
+package skiplist
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLayered(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			for _, k := range []int{1, 3, 5, 7} {
+				sl.Insert(k, "old")
+			}
+			older := sl.Rotate()
+			for _, k := range []int{2, 3, 6} {
+				sl.Insert(k, "mid")
+			}
+			newer := sl.Rotate()
+			for _, k := range []int{3, 4, 7} {
+				sl.Insert(k, "new")
+			}
+			view := NewLayered(sl, newer, older)
+
+			for _, tt := range []struct {
+				key  int
+				want string
+			}{{1, "old"}, {2, "mid"}, {3, "new"}, {5, "old"}, {6, "mid"}, {7, "new"}} {
+				if n, ok := view.Search(tt.key); !ok || n.Value() != tt.want {
+					t.Errorf("Search(%d) = %v, %v, want %s", tt.key, n, ok, tt.want)
+				}
+			}
+			if _, ok := view.Search(8); ok {
+				t.Error("Search(8) found a missing key")
+			}
+
+			var keys []int
+			var values []string
+			view.Range(func(k int, v string) bool {
+				keys = append(keys, k)
+				values = append(values, v)
+				return true
+			})
+			if want := []int{1, 2, 3, 4, 5, 6, 7}; !slices.Equal(keys, want) {
+				t.Errorf("Range keys = %v, want %v", keys, want)
+			}
+			if want := []string{"old", "mid", "new", "new", "old", "mid", "new"}; !slices.Equal(values, want) {
+				t.Errorf("Range values = %v, want %v", values, want)
+			}
+
+			keys = keys[:0]
+			view.RangeQuery(3, 6, func(k int, _ string) bool {
+				keys = append(keys, k)
+				return k < 5
+			})
+			if want := []int{3, 4, 5}; !slices.Equal(keys, want) {
+				t.Errorf("RangeQuery keys = %v, want %v", keys, want)
+			}
+
+			it := view.NewIterator()
+			if !it.Seek(4) || it.Key() != 4 || !it.Next() || it.Key() != 5 {
+				t.Error("Seek(4) then Next did not return 4 and 5")
+			}
+			for it.Next() {
+			}
+			if it.Next() {
+				t.Error("Next on an exhausted iterator returned true")
+			}
+		})
+	}
+}
+
+func TestLayered_Empty(t *testing.T) {
+	view := NewLayered(New[int, int]())
+	it := view.NewIterator()
+	if it.Next() {
+		t.Fatal("Next on an empty view returned true")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Key on an exhausted iterator did not panic")
+		}
+	}()
+	it.Key()
+}
+
+func TestLayered_Rotate(t *testing.T) {
+	sl := New[int, int]()
+	view := NewLayered(sl)
+	sl.Insert(1, 1)
+	it := view.NewIterator()
+	f := view.Rotate()
+	sl.Insert(2, 2)
+
+	// A Seek after the rotation merges the frozen layer.
+	if !it.Seek(0) || it.Key() != 1 || !it.Next() || it.Key() != 2 || it.Next() {
+		t.Error("Seek after Rotate did not see both layers")
+	}
+	if n, ok := view.Search(1); !ok || n.Value() != 1 {
+		t.Errorf("Search(1) after Rotate = %v, %v", n, ok)
+	}
+	view.Release(f)
+	if _, ok := view.Search(1); ok {
+		t.Error("Search(1) found an entry of a released layer")
+	}
+	if _, ok := view.Search(2); !ok {
+		t.Error("Search(2) lost an entry of the active list")
+	}
+}
+
+func TestLayered_ConcurrentRotate(t *testing.T) {
+	skipWithoutSync(t)
+	// Every key inserted before a Search starts must be found, whichever
+	// layer holds it by then.
+	const n = 20000
+	sl := New[int, int]()
+	view := NewLayered(sl)
+	var inserted atomic.Int64
+	inserted.Store(-1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for k := 0; k < n; k++ {
+			sl.Insert(k, k)
+			inserted.Store(int64(k))
+			if k%100 == 99 {
+				view.Rotate()
+			}
+		}
+	}()
+	for missed := 0; ; {
+		select {
+		case <-done:
+			if missed > 0 {
+				t.Fatalf("Search missed %d inserted keys", missed)
+			}
+			return
+		default:
+		}
+		if k := int(inserted.Load()); k >= 0 {
+			if _, ok := view.Search(k); !ok {
+				missed++
+			}
+		}
+	}
+}
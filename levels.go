@@ -0,0 +1,79 @@
+package skiplist
+
+// expectedLevels returns the number of levels a list of n entries is
+// expected to use with P = 1/4: the smallest L with 4^L >= n, at least 1.
+func expectedLevels(n int) int {
+	levels := 1
+	for span := 4; span < n && levels < MaxLevel; span *= 4 {
+		levels++
+	}
+	return levels
+}
+
+// CompactLevels lowers the top of the list to the height expected for its
+// current length, plus one level of slack, and returns the number of levels
+// removed. Delete only drops a level once it is empty, so after a mass
+// deletion a few tall survivors keep every descent starting from levels
+// sized for the former population. Compaction truncates the links of those
+// nodes above the new top; the entries, their order and their ranks are
+// unchanged. DeleteRange and ClearIncremental compact automatically when
+// the list is more than two levels taller than expected; CompactLevels does
+// it on demand, e.g. after many single deletes. Stats reports the number of
+// compactions.
+// CompactLevels ลดจำนวนชั้นของ skiplist ให้เหมาะกับจำนวนรายการที่เหลืออยู่
+func (sl *SkipList[K, V]) CompactLevels() int {
+	tr := sl.traceStart(OpCompactLevels)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	return sl.compactLevels(expectedLevels(sl.length) + 1)
+}
+
+// compactLevelsAfterRemoval compacts the list when it is more than two
+// levels taller than expected after a bulk removal. The caller must hold
+// the write lock.
+func (sl *SkipList[K, V]) compactLevelsAfterRemoval() {
+	if expected := expectedLevels(sl.length); sl.level+1 > expected+2 {
+		sl.compactLevels(expected + 1)
+	}
+}
+
+// compactLevels removes the levels from keep up, truncating the links of
+// the nodes taller than keep levels, and returns the number of levels
+// removed. The caller must hold the write lock.
+func (sl *SkipList[K, V]) compactLevels(keep int) int {
+	if sl.level+1 <= keep {
+		return 0
+	}
+	for n := sl.header.forward[keep]; n != nil; {
+		next := n.forward[keep]
+		clear(n.forward[keep:])
+		n.forward, n.span = n.forward[:keep], n.span[:keep]
+		if n.wspan != nil {
+			n.wspan = n.wspan[:keep]
+		}
+		if n.hspan != nil {
+			n.hspan = n.hspan[:keep]
+		}
+		if n.back != nil {
+			clear(n.back[keep:])
+			n.back = n.back[:keep]
+		}
+		n = next
+	}
+	h := sl.header
+	clear(h.forward[keep : sl.level+1])
+	clear(h.span[keep : sl.level+1])
+	if h.wspan != nil {
+		clear(h.wspan[keep : sl.level+1])
+	}
+	if h.hspan != nil {
+		clear(h.hspan[keep : sl.level+1])
+	}
+	removed := sl.level + 1 - keep
+	sl.level = keep - 1
+	sl.levelCompactions++
+	return removed
+}
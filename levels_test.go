@@ -0,0 +1,56 @@
+package skiplist
+
+import "testing"
+
+func TestCompactLevels(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil, WithBidirectionalLevels[int, int](), WithWeights(func(k, v int) int { return 1 }))
+			for i := 0; i < 100000; i++ {
+				sl.Insert(i, i)
+			}
+			before := sl.Stats().Levels
+			// Keep the ten entries from the first node of the top level, so
+			// that a tall survivor holds the levels up.
+			k := sl.header.forward[sl.level].key
+
+			sl.DeleteRange(k+10, 99999)
+			sl.DeleteRange(-1, k-1)
+			st := sl.Stats()
+			if st.Levels > expectedLevels(10)+1 || st.LevelCompactions == 0 {
+				t.Errorf("after DeleteRange: %d levels (was %d), %d compactions", st.Levels, before, st.LevelCompactions)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if err := sl.CheckSpans(); err != nil {
+				t.Fatal(err)
+			}
+			if sl.Rank(k+7) != 7 || sl.WeightedRank(k+7) != 7 {
+				t.Errorf("Rank(k+7) = %d, WeightedRank(k+7) = %d", sl.Rank(k+7), sl.WeightedRank(k+7))
+			}
+			it := sl.NewIterator(WithReverse[int, int]())
+			if !it.Next() || !it.Skip(5) || it.Key() != k+4 {
+				t.Errorf("backward Skip landed on %v", it.Key())
+			}
+
+			// The list keeps working, and reuses the truncated nodes.
+			for i := 0; i < 5000; i++ {
+				sl.Insert(-1-i, i)
+			}
+			for i := 0; i < 5000; i++ {
+				sl.Delete(-1 - i)
+			}
+			sl.CompactLevels()
+			if err := sl.CheckSpans(); err != nil {
+				t.Fatal(err)
+			}
+			if sl.Len() != 10 || sl.Stats().Levels > expectedLevels(10)+1 {
+				t.Errorf("after CompactLevels: Len() = %d, %d levels", sl.Len(), sl.Stats().Levels)
+			}
+			if sl.CompactLevels() != 0 {
+				t.Error("CompactLevels removed levels from a compact list")
+			}
+		})
+	}
+}
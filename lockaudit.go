@@ -0,0 +1,74 @@
+package skiplist
+
+import (
+	"log"
+	"time"
+)
+
+// LockHold describes an operation that held the lock of a skiplist for
+// longer than the threshold given to WithLockAudit.
+// LockHold คือข้อมูลของ operation ที่ถือ lock นานเกินกว่าที่กำหนด
+type LockHold struct {
+	Op Op
+	// Keys is the number of entries the operation returned, visited or
+	// modified, as in TraceInfo.
+	Keys int
+	// Write is true if the operation held the write lock, and false if it
+	// held the read lock, which also stalls writers.
+	Write bool
+	// Held is the time from the acquisition of the lock until the operation
+	// finished, including the callbacks run under the lock. For a scan that
+	// yields the lock (see WithYieldEvery), it is the longest of its holds.
+	Held time.Duration
+}
+
+// WithLockAudit measures how long every traced operation (see Tracer) holds
+// the lock of the skiplist and calls fn with the operations that held it for
+// longer than threshold, to find the scans and batch operations that stall
+// writers. If fn is nil, such operations are logged with the log package.
+//
+// Range scans and batch operations are reported under the Op of the
+// operation they are built on, e.g. BulkLoad as OpInsert and Rotate as
+// OpClear. Like Tracer.End, fn is called while the lock is still held and must
+// not call back into the same skiplist. The audit costs two clock readings per
+// operation, so it is meant for debugging.
+// WithLockAudit วัดระยะเวลาที่แต่ละ operation ถือ lock และเรียก fn เมื่อเกิน threshold
+func WithLockAudit[K any, V any](threshold time.Duration, fn func(LockHold)) Option[K, V] {
+	if fn == nil {
+		fn = logLockHold
+	}
+	return func(sl *SkipList[K, V]) {
+		sl.lockAudit = &lockAudit{threshold: threshold, fn: fn}
+	}
+}
+
+// lockAudit holds the settings of WithLockAudit.
+type lockAudit struct {
+	threshold time.Duration
+	fn        func(LockHold)
+}
+
+// check reports t, which finished at now, if it held the lock too long.
+func (a *lockAudit) check(t *opTrace, now time.Time) {
+	if held := max(t.held, now.Sub(t.lockedAt)); held > a.threshold {
+		a.fn(LockHold{Op: t.op, Keys: t.keys, Write: t.op.writes(), Held: held})
+	}
+}
+
+func logLockHold(h LockHold) {
+	lock := "read"
+	if h.Write {
+		lock = "write"
+	}
+	log.Printf("skiplist: %s held the %s lock for %v (%d keys)", h.Op, lock, h.Held, h.Keys)
+}
+
+// writes reports whether operations traced as o take the write lock.
+func (o Op) writes() bool {
+	switch o {
+	case OpInsert, OpDelete, OpClear, OpPopMin, OpPopMax, OpDeleteMin, OpDeleteMax,
+		OpDeleteRange, OpRotate, OpMove, OpBulkLoad, OpCompactLevels:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package skiplist
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithLockAudit(t *testing.T) {
+	var holds []LockHold
+	sl := New[int, int](WithLockAudit[int, int](2*time.Millisecond, func(h LockHold) { holds = append(holds, h) }))
+	for i := 0; i < 10; i++ {
+		sl.Insert(i, i)
+	}
+	sl.Search(5)
+	if len(holds) != 0 {
+		t.Fatalf("fast operations reported: %+v", holds)
+	}
+
+	sl.RangeQuery(2, 4, func(int, int) bool {
+		time.Sleep(time.Millisecond)
+		return true
+	})
+	i := 100
+	sl.BulkLoad(func() (int, int, bool) {
+		time.Sleep(time.Millisecond)
+		i++
+		return i, i, i <= 103
+	})
+	if len(holds) != 2 {
+		t.Fatalf("got %d reports, want 2: %+v", len(holds), holds)
+	}
+	if h := holds[0]; h.Op != OpRangeQuery || h.Keys != 3 || h.Write || h.Held < 3*time.Millisecond {
+		t.Errorf("RangeQuery reported as %+v", h)
+	}
+	if h := holds[1]; h.Op != OpBulkLoad || h.Keys != 3 || !h.Write || h.Held < 3*time.Millisecond {
+		t.Errorf("BulkLoad reported as %+v", h)
+	}
+}
+
+func TestWithLockAuditLog(t *testing.T) {
+	var buf bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&buf)
+
+	sl := New[int, int](WithLockAudit[int, int](0, nil), WithTracer[int, int](&recordingTracer{}))
+	sl.Range(func(int, int) bool { return true })
+	sl.Clear()
+	if out := buf.String(); !strings.Contains(out, "skiplist: Range held the read lock for ") ||
+		!strings.Contains(out, "skiplist: Clear held the write lock for ") {
+		t.Errorf("log output = %q", out)
+	}
+}
@@ -0,0 +1,101 @@
+package skiplist
+
+import "sync/atomic"
+
+// adaptiveUpgradeAfter is the number of reads that, in the exclusive mode of
+// WithAdaptiveLocking, have to wait for another read before the list switches
+// to its read-write lock.
+const adaptiveUpgradeAfter = 16
+
+// WithAdaptiveLocking starts the list with a plain mutex, which is cheaper
+// than a read-write lock when uncontended, and switches to the read-write
+// lock for good once reads are seen waiting for other reads, i.e. once
+// several goroutines read the list concurrently. Embedded single-goroutine
+// users then pay for the cheaper lock, and servers get shared reads after
+// the first few contended ones. In the mutex mode, readers exclude each
+// other: RangeIterator and long scans block concurrent readers until the
+// switch, and a read method called from the callback of a scan deadlocks
+// (with a read-write lock, it only does so when a writer is waiting). Stats
+// reports the current mode.
+// WithAdaptiveLocking เริ่มต้นด้วย mutex ธรรมดา แล้วเปลี่ยนเป็น read-write lock เมื่อพบการอ่านพร้อมกัน
+func WithAdaptiveLocking[K any, V any]() Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.mutex.exclusive.Store(true)
+	}
+}
+
+// listLock is the lock of a skiplist. Its zero value is a read-write lock.
+// In the exclusive mode of WithAdaptiveLocking, readers and writers take mu
+// instead, until the switch to rw, which is permanent.
+//
+// The switch is made by a reader holding mu, and later callers of a lock
+// method that find the mode changed once they get mu release it and take rw.
+// A goroutine holding mu is thus the only one that can change the mode, and
+// the unlock methods can tell from the mode which lock their caller holds.
+type listLock struct {
+	rw         syncRWMutex
+	mu         syncMutex
+	exclusive  atomic.Bool  // true while readers and writers take mu
+	reading    atomic.Bool  // mu is held by a reader
+	contention atomic.Int32 // reads that waited for another read on mu
+	// onUnlock is called by Unlock before the write lock is released (see
+	// WithPublishedStats).
+	onUnlock func()
+}
+
+func (l *listLock) Lock() {
+	if l.exclusive.Load() {
+		l.mu.Lock()
+		if l.exclusive.Load() {
+			return
+		}
+		l.mu.Unlock()
+	}
+	l.rw.Lock()
+}
+
+func (l *listLock) Unlock() {
+	if l.onUnlock != nil {
+		l.onUnlock()
+	}
+	if l.exclusive.Load() {
+		l.mu.Unlock()
+		return
+	}
+	l.rw.Unlock()
+}
+
+func (l *listLock) RLock() {
+	if l.exclusive.Load() {
+		if !l.mu.TryLock() {
+			shared := l.reading.Load()
+			l.mu.Lock()
+			if shared && l.exclusive.Load() && l.contention.Add(1) >= adaptiveUpgradeAfter {
+				l.exclusive.Store(false)
+			}
+		}
+		if l.exclusive.Load() {
+			l.reading.Store(true)
+			return
+		}
+		l.mu.Unlock()
+	}
+	l.rw.RLock()
+}
+
+func (l *listLock) RUnlock() {
+	if l.exclusive.Load() {
+		l.reading.Store(false)
+		l.mu.Unlock()
+		return
+	}
+	l.rw.RUnlock()
+}
+
+// mode returns the name of the lock currently taken, for Stats.
+func (l *listLock) mode() string {
+	if l.exclusive.Load() {
+		return "mutex"
+	}
+	return "rwmutex"
+}
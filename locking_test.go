@@ -0,0 +1,100 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// skipWithoutSync skips tests of concurrent behavior in skiplist_nosync
+// builds.
+func skipWithoutSync(t *testing.T) {
+	t.Helper()
+	if !concurrent {
+		t.Skip("skiplist_nosync build")
+	}
+}
+
+func TestWithAdaptiveLocking(t *testing.T) {
+	skipWithoutSync(t)
+	sl := New[int, int](WithAdaptiveLocking[int, int]())
+	for i := 0; i < 100; i++ {
+		sl.Insert(i, i)
+	}
+	// Uncontended use and writes waiting for reads keep the mutex.
+	for i := 0; i < 2*adaptiveUpgradeAfter; i++ {
+		it := sl.RangeIterator(0, 10)
+		done := make(chan struct{})
+		go func() {
+			sl.Insert(1000, 0)
+			close(done)
+		}()
+		time.Sleep(time.Millisecond)
+		it.Close()
+		<-done
+	}
+	if got := sl.Stats().Locking; got != "mutex" {
+		t.Fatalf("Locking = %q without concurrent reads, want mutex", got)
+	}
+
+	// Reads waiting for reads switch to the read-write lock.
+	for i := 0; sl.Stats().Locking == "mutex"; i++ {
+		if i == 1000 {
+			t.Fatal("no switch to the read-write lock")
+		}
+		it := sl.RangeIterator(0, 10)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sl.Search(5)
+		}()
+		time.Sleep(time.Millisecond)
+		it.Close()
+		wg.Wait()
+	}
+
+	// Readers now share the lock.
+	it := sl.RangeIterator(0, 10)
+	defer it.Close()
+	done := make(chan bool)
+	go func() {
+		_, ok := sl.Search(5)
+		done <- ok
+	}()
+	select {
+	case ok := <-done:
+		if !ok {
+			t.Error("Search(5) failed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("read blocked by another read after the switch")
+	}
+}
+
+func TestWithAdaptiveLockingConcurrent(t *testing.T) {
+	skipWithoutSync(t)
+	sl := New[int, int](WithAdaptiveLocking[int, int]())
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				sl.Insert(g*1000+i, i)
+				sl.Search(i)
+				sl.Range(func(int, int) bool { return false })
+				if i%50 == 0 {
+					sl.Delete(g*1000 + i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if sl.Len() != 8*490 {
+		t.Errorf("Len() = %d, want %d", sl.Len(), 8*490)
+	}
+}
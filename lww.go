@@ -0,0 +1,204 @@
+package skiplist
+
+import "time"
+
+// lwwStamp is the last-writer-wins timestamp of a key. Deleted keys keep a
+// tombstone stamp so that a merge cannot resurrect them with an older write.
+type lwwStamp struct {
+	ts      uint64
+	deleted bool
+}
+
+// WithLWW attaches a logical timestamp to every entry and enables MergeLWW.
+// Insert, Delete, PopMin, PopMax and Clear stamp the affected keys with the
+// value returned by clock; deletes leave a tombstone stamp behind. If clock is
+// nil, the wall clock in nanoseconds is used.
+//
+// For replicas to converge, timestamps must be unique across replicas, e.g. a
+// hybrid logical clock with a replica ID in the low bits. When two writes carry
+// the same timestamp, a delete wins over a value; two values with the same
+// timestamp keep whichever was applied first.
+//
+// WithLWW แนบ timestamp แบบ logical ให้กับทุกรายการ เพื่อใช้ในการรวมข้อมูลแบบ last-writer-wins
+func WithLWW[K any, V any](clock func() uint64) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		if clock == nil {
+			clock = func() uint64 { return uint64(time.Now().UnixNano()) }
+		}
+		sl.lwwClock = clock
+		sl.lww = NewWithComparator[K, lwwStamp](sl.compare)
+	}
+}
+
+// stampLWW records the timestamp of a write to key. It uses the timestamp set
+// by the current operation (lwwTS) or, if none, the clock.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) stampLWW(key K, deleted bool) {
+	ts := sl.lwwTS
+	if ts == 0 {
+		ts = sl.lwwClock()
+	}
+	sl.lww.insert(key, lwwStamp{ts: ts, deleted: deleted})
+}
+
+// lwwStampOf returns the stamp recorded for key. The caller must hold a lock.
+func (sl *SkipList[K, V]) lwwStampOf(key K) (lwwStamp, bool) {
+	if n := sl.lww.findGreaterOrEqual(key); n != nil && sl.compare(n.key, key) == 0 {
+		return n.value, true
+	}
+	return lwwStamp{}, false
+}
+
+// wins reports whether a write stamped s should replace the state stamped cur.
+func (s lwwStamp) wins(cur lwwStamp) bool {
+	return s.ts > cur.ts || (s.ts == cur.ts && s.deleted && !cur.deleted)
+}
+
+func (sl *SkipList[K, V]) mustLWW() {
+	if sl.lww == nil {
+		panic("skiplist: last-writer-wins API used without WithLWW")
+	}
+}
+
+// InsertWithTimestamp inserts or updates key with an explicit timestamp.
+// The write is applied only if ts is newer than the timestamp already recorded
+// for key (including a tombstone); it returns true if it was applied.
+// InsertWithTimestamp panics if the skiplist was not created with WithLWW.
+// InsertWithTimestamp เพิ่มหรืออัปเดต key พร้อม timestamp ที่กำหนด
+// จะเขียนก็ต่อเมื่อ ts ใหม่กว่า timestamp เดิมของ key เท่านั้น
+func (sl *SkipList[K, V]) InsertWithTimestamp(key K, value V, ts uint64) bool {
+	sl.mustLWW()
+	tr := sl.traceStart(OpInsert)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	return sl.applyLWW(key, value, lwwStamp{ts: ts})
+}
+
+// DeleteWithTimestamp deletes key with an explicit timestamp, leaving a tombstone.
+// The delete is applied only if ts is newer than the timestamp already recorded
+// for key; it returns true if it was applied.
+// DeleteWithTimestamp panics if the skiplist was not created with WithLWW.
+// DeleteWithTimestamp ลบ key พร้อม timestamp ที่กำหนด
+func (sl *SkipList[K, V]) DeleteWithTimestamp(key K, ts uint64) bool {
+	sl.mustLWW()
+	tr := sl.traceStart(OpDelete)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	var zero V
+	return sl.applyLWW(key, zero, lwwStamp{ts: ts, deleted: true})
+}
+
+// applyLWW applies a stamped write if it wins over the current state of key.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) applyLWW(key K, value V, s lwwStamp) bool {
+	sl.mustNotBeFrozen()
+	if cur, ok := sl.lwwStampOf(key); ok && !s.wins(cur) {
+		return false
+	}
+	sl.lwwTS = s.ts
+	if s.deleted {
+		sl.delete(key)
+	} else {
+		sl.insert(key, value)
+	}
+	sl.lwwTS = 0
+	// The key may have been absent locally, in which case no hook ran.
+	sl.lww.insert(key, s)
+	return true
+}
+
+// Timestamp returns the timestamp of the last write to key and whether that
+// write was a delete. It returns false if no write to key has been recorded.
+// Timestamp panics if the skiplist was not created with WithLWW.
+// Timestamp คืนค่า timestamp ของการเขียนล่าสุดของ key
+func (sl *SkipList[K, V]) Timestamp(key K) (ts uint64, deleted bool, ok bool) {
+	sl.mustLWW()
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	s, ok := sl.lwwStampOf(key)
+	return s.ts, s.deleted, ok
+}
+
+// MergeLWW merges the entries and tombstones of other into sl, resolving each
+// key by last-writer-wins on its timestamp. Merging is commutative, associative
+// and idempotent, so replicas that exchange their state converge.
+// It returns the number of keys whose state changed in sl.
+// Both lists must have been created with WithLWW; MergeLWW panics otherwise.
+// other is read under its own read lock before sl is locked, so two lists can
+// safely be merged into each other concurrently.
+//
+// MergeLWW รวมข้อมูลและ tombstone จาก other เข้ามาใน sl โดยตัดสินแต่ละ key ด้วย timestamp
+// คืนค่าจำนวน key ที่มีการเปลี่ยนแปลง
+func (sl *SkipList[K, V]) MergeLWW(other *SkipList[K, V]) int {
+	sl.mustLWW()
+	other.mustLWW()
+	if other == sl {
+		return 0
+	}
+
+	type record struct {
+		key   K
+		value V
+		stamp lwwStamp
+	}
+	var records []record
+	other.mutex.RLock()
+	live := other.header.forward[0]
+	for s := other.lww.header.forward[0]; s != nil; s = s.forward[0] {
+		rec := record{key: s.key, stamp: s.value}
+		if !s.value.deleted {
+			// Live keys are a subset of the stamped keys, in the same order.
+			for live != nil && other.compare(live.key, s.key) < 0 {
+				live = live.forward[0]
+			}
+			if live != nil && other.compare(live.key, s.key) == 0 {
+				rec.value = live.value
+			}
+		}
+		records = append(records, rec)
+	}
+	other.mutex.RUnlock()
+
+	tr := sl.traceStart(OpInsert)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	for _, rec := range records {
+		if sl.applyLWW(rec.key, rec.value, rec.stamp) {
+			tr.keys++
+		}
+	}
+	return tr.keys
+}
+
+// PurgeTombstones forgets the tombstones of keys deleted at or before ts and
+// returns how many were removed. After purging, a merge with a replica that
+// still holds an older value for such a key can resurrect it, so only purge
+// tombstones that every replica has already observed.
+// PurgeTombstones panics if the skiplist was not created with WithLWW.
+// PurgeTombstones ลบ tombstone ที่มี timestamp ไม่เกิน ts
+func (sl *SkipList[K, V]) PurgeTombstones(ts uint64) int {
+	sl.mustLWW()
+	sl.mutex.Lock()
+	defer sl.mutex.Unlock()
+
+	var purge []K
+	for s := sl.lww.header.forward[0]; s != nil; s = s.forward[0] {
+		if s.value.deleted && s.value.ts <= ts {
+			purge = append(purge, s.key)
+		}
+	}
+	for _, key := range purge {
+		sl.lww.delete(key)
+	}
+	return len(purge)
+}
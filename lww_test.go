@@ -0,0 +1,111 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+)
+
+// counterClock returns a clock yielding start, start+step, start+2*step, ...
+func counterClock(start, step uint64) func() uint64 {
+	var mu sync.Mutex
+	next := start
+	return func() uint64 {
+		mu.Lock()
+		defer mu.Unlock()
+		ts := next
+		next += step
+		return ts
+	}
+}
+
+func lwwContents(sl *SkipList[int, string]) map[int]string {
+	m := make(map[int]string)
+	sl.Range(func(k int, v string) bool {
+		m[k] = v
+		return true
+	})
+	return m
+}
+
+func TestMergeLWW_Converges(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			// Odd and even timestamps keep the two replicas' stamps unique.
+			a := setup.constructor(nil, WithLWW[int, string](counterClock(1, 2)))
+			b := setup.constructor(nil, WithLWW[int, string](counterClock(2, 2)))
+
+			a.Insert(1, "a1") // ts 1
+			a.Insert(2, "a2") // ts 3
+			b.Insert(2, "b2") // ts 2
+			b.Insert(3, "b3") // ts 4
+			b.Insert(1, "b1") // ts 6
+			a.Delete(3)       // ts 5, tombstone for a key a has never seen
+			a.Insert(4, "a4") // ts 7
+			b.Delete(4)       // ts 8, likewise
+
+			if n := a.MergeLWW(b); n != 2 {
+				t.Errorf("a.MergeLWW(b) changed %d keys, want 2", n)
+			}
+			b.MergeLWW(a)
+
+			want := map[int]string{1: "b1", 2: "a2"}
+			for name, sl := range map[string]*SkipList[int, string]{"a": a, "b": b} {
+				got := lwwContents(sl)
+				if len(got) != len(want) {
+					t.Fatalf("replica %s contents %v, want %v", name, got, want)
+				}
+				for k, v := range want {
+					if got[k] != v {
+						t.Errorf("replica %s key %d = %q, want %q", name, k, got[k], v)
+					}
+				}
+			}
+
+			// Merging again is a no-op.
+			if n := a.MergeLWW(b); n != 0 {
+				t.Errorf("repeated merge changed %d keys", n)
+			}
+
+			if ts, deleted, ok := a.Timestamp(4); !ok || !deleted || ts != 8 {
+				t.Errorf("Timestamp(4) = %d, %v, %v; want 8, true, true", ts, deleted, ok)
+			}
+		})
+	}
+}
+
+func TestLWW_ExplicitTimestamps(t *testing.T) {
+	sl := New[int, string](WithLWW[int, string](nil))
+	if !sl.InsertWithTimestamp(1, "new", 10) {
+		t.Fatal("first write should apply")
+	}
+	if sl.InsertWithTimestamp(1, "old", 5) {
+		t.Error("older write should be rejected")
+	}
+	if sl.DeleteWithTimestamp(1, 9) {
+		t.Error("older delete should be rejected")
+	}
+	if !sl.DeleteWithTimestamp(1, 10) {
+		t.Error("delete with an equal timestamp should win over a value")
+	}
+	if sl.InsertWithTimestamp(1, "stale", 10) {
+		t.Error("write with the tombstone's timestamp should be rejected")
+	}
+	if sl.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0", sl.Len())
+	}
+	if n := sl.PurgeTombstones(10); n != 1 {
+		t.Errorf("PurgeTombstones removed %d, want 1", n)
+	}
+	if _, _, ok := sl.Timestamp(1); ok {
+		t.Error("tombstone should be gone after PurgeTombstones")
+	}
+}
+
+func TestLWW_Disabled(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MergeLWW without WithLWW should panic")
+		}
+	}()
+	New[int, int]().MergeLWW(New[int, int]())
+}
@@ -0,0 +1,243 @@
+package skiplist
+
+import "time"
+
+// MaintenanceTask is a background job run by StartMaintenance, such as a TTL
+// sweep or a periodic purge. A run of the task is split into slices: each
+// call to Step performs at most budget units of work (typically entries
+// visited) under a single lock acquisition and reports whether the run is
+// complete. The task keeps its own cursor between slices.
+// MaintenanceTask คืองานเบื้องหลังที่ทำงานเป็นช่วงสั้นๆ (slice) เพื่อไม่ให้ถือ lock นานเกินไป
+type MaintenanceTask[K any, V any] struct {
+	Name string
+	// Interval is the time between the end of a run and the start of the
+	// next one. The first run starts when maintenance is started.
+	Interval time.Duration
+	// Step runs one slice of work and returns true when the run is complete.
+	Step func(sl *SkipList[K, V], budget int) (done bool)
+}
+
+// MaintenanceOptions configures StartMaintenance.
+// MaintenanceOptions กำหนดค่าของ StartMaintenance
+type MaintenanceOptions[K any, V any] struct {
+	Tasks []MaintenanceTask[K, V]
+	// SliceBudget is the budget passed to every Step (default 256).
+	SliceBudget int
+	// SlicesPerSecond caps the number of slices run per second across all
+	// tasks (default 100), which bounds the share of lock time taken from
+	// foreground operations.
+	SlicesPerSecond float64
+}
+
+// Maintenance controls the background jobs started by StartMaintenance.
+// All methods are safe for concurrent use.
+// Maintenance ควบคุมงานเบื้องหลังที่เริ่มด้วย StartMaintenance
+type Maintenance struct {
+	mu     syncMutex
+	paused bool
+	slices uint64
+	// running is held while a slice runs, so that Pause can wait for it.
+	running syncMutex
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	once    syncOnce
+}
+
+// StartMaintenance starts a goroutine running opts.Tasks in the background.
+// The scheduler runs one slice at a time, picking among the due tasks the one
+// that has waited longest, and waits 1/SlicesPerSecond between two slices so
+// that foreground operations get the lock most of the time. Call Stop on the
+// returned Maintenance to end it.
+// It panics if a task has no Step, and in a skiplist_nosync build, which has
+// no background goroutines.
+//
+// StartMaintenance เริ่ม goroutine ที่รันงานเบื้องหลังโดยจำกัดอัตราการทำงาน
+// เพื่อไม่ให้แย่ง lock จากการทำงานหลัก ต้องเรียก Stop เมื่อเลิกใช้งาน
+func (sl *SkipList[K, V]) StartMaintenance(opts MaintenanceOptions[K, V]) *Maintenance {
+	if !concurrent {
+		panic("skiplist: StartMaintenance is not available in a skiplist_nosync build")
+	}
+	for _, t := range opts.Tasks {
+		if t.Step == nil {
+			panic("skiplist: maintenance task without Step")
+		}
+	}
+	budget := opts.SliceBudget
+	if budget <= 0 {
+		budget = 256
+	}
+	rate := opts.SlicesPerSecond
+	if rate <= 0 {
+		rate = 100
+	}
+	m := &Maintenance{
+		wake: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	tasks := make([]MaintenanceTask[K, V], len(opts.Tasks))
+	copy(tasks, opts.Tasks)
+	go m.loop(len(tasks), time.Duration(float64(time.Second)/rate), func(i int) (bool, time.Duration) {
+		return tasks[i].Step(sl, budget), tasks[i].Interval
+	})
+	return m
+}
+
+// loop schedules the slices of n tasks; step runs one slice of task i and
+// returns whether its run is complete and the interval of the task.
+func (m *Maintenance) loop(n int, gap time.Duration, step func(i int) (bool, time.Duration)) {
+	defer close(m.done)
+	// due[i] is the time at which task i is next due.
+	due := make([]time.Time, n)
+	now := time.Now()
+	for i := range due {
+		due[i] = now
+	}
+	for {
+		if m.isPaused() {
+			select {
+			case <-m.wake:
+				continue
+			case <-m.stop:
+				return
+			}
+		}
+
+		next := -1
+		for i := range due {
+			if next < 0 || due[i].Before(due[next]) {
+				next = i
+			}
+		}
+		if next < 0 {
+			<-m.stop
+			return
+		}
+		if wait := time.Until(due[next]); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-m.wake:
+				timer.Stop()
+			case <-m.stop:
+				timer.Stop()
+				return
+			}
+			continue
+		}
+
+		m.running.Lock()
+		if m.isPaused() {
+			m.running.Unlock()
+			continue
+		}
+		done, interval := step(next)
+		m.mu.Lock()
+		m.slices++
+		m.mu.Unlock()
+		m.running.Unlock()
+		if done {
+			due[next] = time.Now().Add(interval)
+		} else {
+			// Queue behind the other due tasks.
+			due[next] = time.Now()
+		}
+
+		timer := time.NewTimer(gap)
+		select {
+		case <-timer.C:
+		case <-m.stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (m *Maintenance) isPaused() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.paused
+}
+
+// Pause stops scheduling new slices and waits for the running one, if any,
+// to finish. Runs in progress continue where they stopped on Resume.
+// Pause หยุดการรัน slice ใหม่ และรอให้ slice ที่กำลังทำงานอยู่เสร็จสิ้น
+func (m *Maintenance) Pause() {
+	m.mu.Lock()
+	m.paused = true
+	m.mu.Unlock()
+	m.running.Lock()
+	defer m.running.Unlock()
+}
+
+// Resume resumes scheduling after Pause.
+// Resume กลับมารัน slice ต่อหลังจาก Pause
+func (m *Maintenance) Resume() {
+	m.mu.Lock()
+	m.paused = false
+	m.mu.Unlock()
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Slices returns the number of slices run so far.
+// Slices คืนค่าจำนวน slice ที่รันไปแล้ว
+func (m *Maintenance) Slices() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.slices
+}
+
+// Stop ends the maintenance goroutine, waiting for the running slice to
+// finish. Stop is idempotent.
+// Stop หยุด goroutine ของงานเบื้องหลัง โดยรอให้ slice ที่กำลังทำงานอยู่เสร็จสิ้น
+func (m *Maintenance) Stop() {
+	m.once.Do(func() { close(m.stop) })
+	<-m.done
+}
+
+// SweepTask returns a task that deletes the entries for which expired returns
+// true, e.g. the entries whose TTL has passed. A run walks the list in key
+// order, budget entries per slice, each slice under one write lock; keys
+// inserted behind the cursor during a run are checked in the next run.
+// expired is called with the write lock held and must not call back into the
+// skiplist.
+// SweepTask คืนค่างานที่ลบรายการที่หมดอายุ โดยไล่ตามลำดับ key ทีละ budget รายการ
+func SweepTask[K any, V any](name string, interval time.Duration, expired func(key K, value V) bool) MaintenanceTask[K, V] {
+	var cursor K
+	resume := false
+	return MaintenanceTask[K, V]{
+		Name:     name,
+		Interval: interval,
+		Step: func(sl *SkipList[K, V], budget int) bool {
+			tr := sl.traceStart(OpDelete)
+			sl.mutex.Lock()
+			tr.locked()
+			defer sl.mutex.Unlock()
+			defer sl.traceEnd(&tr)
+
+			n := sl.header.forward[0]
+			if resume {
+				n = sl.findGreaterOrEqual(cursor)
+			}
+			for visited := 0; n != nil && visited < budget; visited++ {
+				// Read the successor first: deleting may recycle n.
+				next := n.forward[0]
+				if expired(n.key, n.value) {
+					sl.delete(n.key)
+					tr.keys++
+				}
+				n = next
+			}
+			if n == nil {
+				resume = false
+				return true
+			}
+			cursor, resume = n.key, true
+			return false
+		},
+	}
+}
@@ -0,0 +1,117 @@
+package skiplist
+
+import (
+	"testing"
+	"time"
+)
+
+// waitFor polls cond until it holds or a generous deadline passes.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMaintenance_Sweep(t *testing.T) {
+	skipWithoutSync(t)
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			for i := 0; i < 1000; i++ {
+				sl.Insert(i, i)
+			}
+			m := sl.StartMaintenance(MaintenanceOptions[int, int]{
+				Tasks: []MaintenanceTask[int, int]{
+					SweepTask("evens", time.Hour, func(k, _ int) bool { return k%2 == 0 }),
+				},
+				SliceBudget:     50,
+				SlicesPerSecond: 10000,
+			})
+			defer m.Stop()
+
+			waitFor(t, "the sweep", func() bool { return sl.Len() == 500 })
+			if n := m.Slices(); n < 20 {
+				t.Errorf("sweep of 1000 entries with a budget of 50 ran in %d slices", n)
+			}
+			if _, ok := sl.Search(500); ok {
+				t.Error("expired key 500 survived the sweep")
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestMaintenance_PauseResume(t *testing.T) {
+	skipWithoutSync(t)
+	sl := New[int, int]()
+	runs := 0
+	m := sl.StartMaintenance(MaintenanceOptions[int, int]{
+		Tasks: []MaintenanceTask[int, int]{{
+			Name: "count",
+			Step: func(*SkipList[int, int], int) bool {
+				runs++
+				return true
+			},
+		}},
+		SlicesPerSecond: 1000,
+	})
+	defer m.Stop()
+
+	waitFor(t, "a first slice", func() bool { return m.Slices() > 0 })
+	m.Pause()
+	paused := m.Slices()
+	time.Sleep(20 * time.Millisecond)
+	if got := m.Slices(); got != paused {
+		t.Fatalf("%d slices ran while paused", got-paused)
+	}
+	if uint64(runs) != paused {
+		t.Errorf("Step ran %d times, Slices() = %d", runs, paused)
+	}
+	m.Resume()
+	waitFor(t, "a slice after Resume", func() bool { return m.Slices() > paused })
+
+	m.Stop()
+	m.Stop() // idempotent
+}
+
+func TestMaintenance_Fairness(t *testing.T) {
+	skipWithoutSync(t)
+	sl := New[int, int]()
+	var a, b int
+	busy := func(count *int) func(*SkipList[int, int], int) bool {
+		return func(*SkipList[int, int], int) bool {
+			*count++
+			return false // never completes, always due
+		}
+	}
+	m := sl.StartMaintenance(MaintenanceOptions[int, int]{
+		Tasks: []MaintenanceTask[int, int]{
+			{Name: "a", Step: busy(&a)},
+			{Name: "b", Step: busy(&b)},
+		},
+		SlicesPerSecond: 10000,
+	})
+	waitFor(t, "20 slices", func() bool { return m.Slices() >= 20 })
+	m.Stop()
+	if d := a - b; d < -1 || d > 1 {
+		t.Errorf("tasks ran %d and %d slices, want alternating", a, b)
+	}
+}
+
+func TestMaintenance_NilStep(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("StartMaintenance with a nil Step did not panic")
+		}
+	}()
+	New[int, int]().StartMaintenance(MaintenanceOptions[int, int]{
+		Tasks: []MaintenanceTask[int, int]{{Name: "broken"}},
+	})
+}
@@ -0,0 +1,172 @@
+package skiplist
+
+// MergeIterator performs a k-way merge of forward iterators, e.g. over the
+// shards of a partitioned store, the layers of a Layered view, or the inputs
+// of a compaction. It yields every key once, in ascending order of compare.
+// When several iterators hold a key, the entry of the iterator given first
+// shadows the others, so iterators must be given from the newest to the
+// oldest source.
+//
+// The merge keeps a binary heap of the iterators that still have entries, so
+// each step costs O(log k) comparisons.
+//
+// MergeIterator รวม iterator หลายตัวแบบ k-way merge ตามลำดับ key
+// เมื่อ key ซ้ำกัน จะคืนค่าจาก iterator ที่ระบุก่อน (ใหม่กว่า)
+type MergeIterator[K any, V any] struct {
+	compare Comparator[K]
+	its     []*Iterator[K, V]
+	keys    []K   // current key of each input iterator
+	heap    []int // inputs positioned on an entry; the smallest (key, index) first
+	started bool
+
+	view *Layered[K, V] // the view of an iterator from Layered.NewIterator
+	gen  uint64         // the generation of the layers of view merged by its
+}
+
+// NewMergeIterator creates an iterator merging its with compare, which must
+// order keys like the lists of the iterators. The iterators must be forward
+// iterators positioned before their first entry (new or Reset); the merge
+// takes ownership of them and advances them itself. Like Iterator, the merge
+// is positioned before the first entry and a call to Next is required.
+// It panics if an iterator was created with WithReverse.
+//
+// NewMergeIterator สร้าง iterator ที่รวม its ตามลำดับของ compare
+// iterator ทุกตัวต้องวนไปข้างหน้าและยังไม่ได้เริ่มวนลูป
+func NewMergeIterator[K any, V any](compare Comparator[K], its ...*Iterator[K, V]) *MergeIterator[K, V] {
+	if compare == nil {
+		panic("skiplist: comparator cannot be nil")
+	}
+	for _, it := range its {
+		if it.reverse {
+			panic("skiplist: NewMergeIterator does not support reverse iterators")
+		}
+	}
+	return &MergeIterator[K, V]{
+		compare: compare,
+		its:     its,
+		keys:    make([]K, len(its)),
+		heap:    make([]int, 0, len(its)),
+	}
+}
+
+// Next advances to the next merged entry and reports whether there is one.
+// Next เลื่อนไปยังรายการถัดไป และคืนค่า true หากมีรายการ
+func (m *MergeIterator[K, V]) Next() bool {
+	if !m.started {
+		m.started = true
+		for i, it := range m.its {
+			if it.Next() {
+				m.keys[i] = it.Key()
+				m.heap = append(m.heap, i)
+			}
+		}
+		m.init()
+		return len(m.heap) > 0
+	}
+	if len(m.heap) == 0 {
+		return false
+	}
+	// Move every input past the current key, including the shadowed ones.
+	key := m.keys[m.heap[0]]
+	for len(m.heap) > 0 && m.compare(m.keys[m.heap[0]], key) == 0 {
+		i := m.heap[0]
+		if m.its[i].Next() {
+			m.keys[i] = m.its[i].Key()
+			m.down(0)
+		} else {
+			m.pop()
+		}
+	}
+	return len(m.heap) > 0
+}
+
+// Seek moves to the first merged entry whose key is greater than or equal to
+// key and reports whether there is one.
+// Seek เลื่อนไปยังรายการแรกที่มี key เท่ากับหรือมากกว่า key ที่กำหนด
+func (m *MergeIterator[K, V]) Seek(key K) bool {
+	if m.view != nil {
+		m.view.pin(m)
+		defer m.view.mu.RUnlock()
+	}
+	m.started = true
+	m.heap = m.heap[:0]
+	for i, it := range m.its {
+		if it.Seek(key) {
+			m.keys[i] = it.Key()
+			m.heap = append(m.heap, i)
+		}
+	}
+	m.init()
+	return len(m.heap) > 0
+}
+
+// Key returns the key of the current entry. It panics if the iterator is not
+// positioned on an entry.
+// Key คืนค่า key ของรายการปัจจุบัน
+func (m *MergeIterator[K, V]) Key() K {
+	if len(m.heap) == 0 {
+		panic("skiplist: Key() called on exhausted or invalid iterator")
+	}
+	return m.keys[m.heap[0]]
+}
+
+// Value returns the value of the current entry, taken from the first iterator
+// holding its key. It panics if the iterator is not positioned on an entry.
+// Value คืนค่า value ของรายการปัจจุบันจาก iterator แรกที่มี key นั้น
+func (m *MergeIterator[K, V]) Value() V {
+	if len(m.heap) == 0 {
+		panic("skiplist: Value() called on exhausted or invalid iterator")
+	}
+	return m.its[m.heap[0]].Value()
+}
+
+// Close closes every input iterator, releasing the locks held by iterators
+// obtained from RangeIterator.
+// Close ปิด iterator ทุกตัวที่นำมารวม
+func (m *MergeIterator[K, V]) Close() {
+	for _, it := range m.its {
+		it.Close()
+	}
+}
+
+// less orders inputs by current key, then by position, so that the first
+// input holding a key comes out on top and shadows the others.
+func (m *MergeIterator[K, V]) less(a, b int) bool {
+	if c := m.compare(m.keys[a], m.keys[b]); c != 0 {
+		return c < 0
+	}
+	return a < b
+}
+
+func (m *MergeIterator[K, V]) init() {
+	for i := len(m.heap)/2 - 1; i >= 0; i-- {
+		m.down(i)
+	}
+}
+
+func (m *MergeIterator[K, V]) pop() {
+	last := len(m.heap) - 1
+	m.heap[0] = m.heap[last]
+	m.heap = m.heap[:last]
+	if last > 0 {
+		m.down(0)
+	}
+}
+
+func (m *MergeIterator[K, V]) down(i int) {
+	h := m.heap
+	for {
+		least := i
+		if l := 2*i + 1; l < len(h) && m.less(h[l], h[least]) {
+			least = l
+		}
+		if r := 2*i + 2; r < len(h) && m.less(h[r], h[least]) {
+			least = r
+		}
+		if least == i {
+			return
+		}
+		h[i], h[least] = h[least], h[i]
+		i = least
+	}
+}
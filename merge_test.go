@@ -0,0 +1,102 @@
+package skiplist
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestMergeIterator(t *testing.T) {
+	a, b, c := New[int, string](), New[int, string](), New[int, string]()
+	for _, k := range []int{1, 4, 7} {
+		a.Insert(k, "a")
+	}
+	for _, k := range []int{2, 4, 8} {
+		b.Insert(k, "b")
+	}
+	for _, k := range []int{1, 2, 3, 9} {
+		c.Insert(k, "c")
+	}
+
+	m := NewMergeIterator(cmp.Compare[int], a.NewIterator(), b.NewIterator(), c.NewIterator())
+	var got []string
+	for m.Next() {
+		got = append(got, string(rune('0'+m.Key()))+m.Value())
+	}
+	if want := []string{"1a", "2b", "3c", "4a", "7a", "8b", "9c"}; !slices.Equal(got, want) {
+		t.Errorf("merged entries = %v, want %v", got, want)
+	}
+	if m.Next() {
+		t.Error("Next on an exhausted merge returned true")
+	}
+
+	m = NewMergeIterator(cmp.Compare[int], c.NewIterator(), a.NewIterator())
+	if !m.Seek(4) || m.Key() != 4 || m.Value() != "a" {
+		t.Fatal("Seek(4) did not land on 4 from the second iterator")
+	}
+	if !m.Next() || m.Key() != 7 {
+		t.Error("Next after Seek(4) did not return 7")
+	}
+	if m.Seek(10) {
+		t.Error("Seek past the end returned true")
+	}
+
+	empty := NewMergeIterator[int, string](cmp.Compare[int])
+	if empty.Next() {
+		t.Error("Next on a merge of no iterators returned true")
+	}
+}
+
+func TestMergeIterator_Panics(t *testing.T) {
+	assertPanics := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s did not panic", name)
+			}
+		}()
+		f()
+	}
+	sl := New[int, int]()
+	assertPanics("nil comparator", func() { NewMergeIterator[int, int](nil, sl.NewIterator()) })
+	assertPanics("reverse iterator", func() { NewMergeIterator(cmp.Compare[int], sl.NewIterator(WithReverse[int, int]())) })
+	assertPanics("Key before Next", func() { NewMergeIterator(cmp.Compare[int], sl.NewIterator()).Key() })
+}
+
+// TestMergeIterator_Randomized merges many shards and checks the result
+// against a map filled with the first shard holding each key.
+func TestMergeIterator_Randomized(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	const shards = 13
+	want := map[int]int{}
+	its := make([]*Iterator[int, int], shards)
+	for s := 0; s < shards; s++ {
+		sl := New[int, int]()
+		for i := 0; i < 200; i++ {
+			k := r.IntN(1000)
+			sl.Insert(k, s)
+			// Shards are filled in order, so the first one holding a key wins.
+			if _, ok := want[k]; !ok {
+				want[k] = s
+			}
+		}
+		its[s] = sl.NewIterator()
+	}
+
+	m := NewMergeIterator(cmp.Compare[int], its...)
+	prev, n := -1, 0
+	for m.Next() {
+		if m.Key() <= prev {
+			t.Fatalf("key %d after %d", m.Key(), prev)
+		}
+		if m.Value() != want[m.Key()] {
+			t.Fatalf("key %d has value %d, want %d", m.Key(), m.Value(), want[m.Key()])
+		}
+		prev = m.Key()
+		n++
+	}
+	if n != len(want) {
+		t.Errorf("merge returned %d keys, want %d", n, len(want))
+	}
+}
@@ -0,0 +1,239 @@
+package skiplist
+
+// WithMerkle gives every entry a 64-bit hash computed by hash from its key and
+// value. Alongside the count spans used by Rank, each link then also records
+// the sum of the hashes of the entries it skips, an overlay similar to a
+// Merkle tree: the hash of any key range (RangeHash, Digest) is computed in
+// O(log n) and kept up to date by every insert, update and delete.
+//
+// Two replicas can then reconcile a large list over the network by exchanging
+// range digests and only descending into the ranges that differ, see
+// FindDivergence.
+//
+// The hash of a range is the wrapping sum of the hashes of its entries, so
+// hash must mix the key and the value well (e.g. a 64-bit FNV or xxHash of
+// both); it must be deterministic and identical on all replicas. It is called
+// with the write lock held and must not call back into the skiplist.
+// WithMerkle กำหนด hash ให้แต่ละรายการ เพื่อให้คำนวณ hash ของช่วง key ใดๆ ได้ใน O(log n)
+func WithMerkle[K any, V any](hash func(K, V) uint64) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.merkle = hash
+	}
+}
+
+// RangeDigest summarizes the entries of a key range: their number and the
+// sum of their hashes. The range covers the keys from Start (inclusive) to
+// End, which is exclusive if Open is true and inclusive otherwise.
+// RangeDigest สรุปข้อมูลของช่วง key: จำนวนรายการและผลรวม hash ของรายการในช่วง
+type RangeDigest[K any] struct {
+	Start K
+	End   K
+	Open  bool
+	Count int
+	Hash  uint64
+}
+
+// RangeHash returns the hash of the entries whose key is between start and end
+// (inclusive) in O(log n). An empty range hashes to 0.
+// It panics if the skiplist was not created with WithMerkle.
+// RangeHash คืนค่า hash ของรายการที่ key อยู่ระหว่าง start และ end (รวมทั้งสองค่า)
+func (sl *SkipList[K, V]) RangeHash(start, end K) uint64 {
+	return sl.Digest(start, end, false).Hash
+}
+
+// Digest returns the digest of the entries whose key is between start and end,
+// end being exclusive if open is true, in O(log n).
+// It panics if the skiplist was not created with WithMerkle.
+// Digest คืนค่า RangeDigest ของรายการที่ key อยู่ระหว่าง start และ end
+func (sl *SkipList[K, V]) Digest(start, end K, open bool) RangeDigest[K] {
+	sl.mustMerkle()
+	tr := sl.traceStart(OpCountRange)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	lo, hi := sl.rankBounds(start, end, open)
+	tr.keys = hi - lo
+	return RangeDigest[K]{
+		Start: start,
+		End:   end,
+		Open:  open,
+		Count: hi - lo,
+		Hash:  sl.hashPrefix(hi) - sl.hashPrefix(lo),
+	}
+}
+
+// SplitDigest splits the range of d into at most parts consecutive ranges
+// holding about the same number of entries of sl, and returns their digests.
+// The ranges tile the range of d: the first one starts at d.Start, the last
+// one ends at d.End, and every other one ends (exclusively) where the next one
+// starts, so that a replica can compute the digests of the same bounds.
+// A range holding no entry is returned as a single digest.
+// It panics if the skiplist was not created with WithMerkle.
+// SplitDigest แบ่งช่วงของ d เป็นช่วงย่อยที่มีจำนวนรายการใกล้เคียงกัน และคืนค่า digest ของแต่ละช่วง
+func (sl *SkipList[K, V]) SplitDigest(d RangeDigest[K], parts int) []RangeDigest[K] {
+	sl.mustMerkle()
+	tr := sl.traceStart(OpCountRange)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	lo, hi := sl.rankBounds(d.Start, d.End, d.Open)
+	count := hi - lo
+	tr.keys = count
+	parts = max(1, min(parts, count))
+
+	out := make([]RangeDigest[K], parts)
+	from, fromHash := lo, sl.hashPrefix(lo)
+	for k := range out {
+		to := lo + count*(k+1)/parts
+		toHash := sl.hashPrefix(to)
+		part := RangeDigest[K]{Start: d.Start, End: d.End, Open: d.Open, Count: to - from, Hash: toHash - fromHash}
+		if k > 0 {
+			part.Start = out[k-1].End
+		}
+		if k < parts-1 {
+			part.End, part.Open = sl.getByRank(to).key, true
+		}
+		out[k] = part
+		from, fromHash = to, toHash
+	}
+	return out
+}
+
+// FindDivergence locates the key ranges in which sl and a replica differ,
+// by descending from the range between start and end (inclusive) into the
+// sub-ranges whose digests differ. remote must return the digest of the bounds
+// of its argument on the replica, e.g. by calling replica.Digest(d.Start,
+// d.End, d.Open) over the network. Differing ranges are split into parts
+// ranges with SplitDigest; once a differing range holds at most leaf entries
+// of sl, f is called with its local digest, in ascending key order, and the
+// caller exchanges the entries of that range. The descent stops when f
+// returns false or remote returns an error, which is then returned.
+//
+// Each digest is computed under its own read lock, so writes during the
+// descent may produce spurious or stale differences. It panics if parts is
+// less than 2 or if the skiplist was not created with WithMerkle.
+//
+// FindDivergence ค้นหาช่วง key ที่ข้อมูลของ sl และ replica ไม่ตรงกัน
+// โดยไล่ลงไปเฉพาะช่วงย่อยที่ digest ไม่ตรงกัน
+func (sl *SkipList[K, V]) FindDivergence(start, end K, parts, leaf int, remote func(RangeDigest[K]) (RangeDigest[K], error), f func(RangeDigest[K]) bool) error {
+	if parts < 2 {
+		panic("skiplist: FindDivergence needs at least 2 parts")
+	}
+	sl.mustMerkle()
+	defer rethrowCallbackPanic("FindDivergence")
+	// A range of one entry cannot be split any further.
+	leaf = max(leaf, 1)
+	_, err := sl.descend(sl.Digest(start, end, false), parts, leaf, remote, f)
+	return err
+}
+
+// descend implements FindDivergence for the range of d. It reports whether
+// the descent should continue.
+func (sl *SkipList[K, V]) descend(d RangeDigest[K], parts, leaf int, remote func(RangeDigest[K]) (RangeDigest[K], error), f func(RangeDigest[K]) bool) (bool, error) {
+	r, err := remote(d)
+	if err != nil {
+		return false, err
+	}
+	if r.Count == d.Count && r.Hash == d.Hash {
+		return true, nil
+	}
+	if d.Count <= leaf {
+		return f(d), nil
+	}
+	for _, sub := range sl.SplitDigest(d, parts) {
+		if ok, err := sl.descend(sub, parts, leaf, remote, f); !ok || err != nil {
+			return ok, err
+		}
+	}
+	return true, nil
+}
+
+func (sl *SkipList[K, V]) mustMerkle() {
+	if sl.merkle == nil {
+		panic("skiplist: range hash API used without WithMerkle")
+	}
+}
+
+// rankBounds returns the ranks of the first entry in the range between start
+// and end (end exclusive if open) and of the first entry after it.
+// The caller must hold a lock.
+func (sl *SkipList[K, V]) rankBounds(start, end K, open bool) (lo, hi int) {
+	lo = sl.rank(start, false)
+	hi = sl.rank(end, !open)
+	return lo, max(lo, hi)
+}
+
+// hashPrefix returns the sum of the hashes of the first r entries.
+// The caller must hold a lock.
+func (sl *SkipList[K, V]) hashPrefix(r int) uint64 {
+	var h uint64
+	traversed := 0
+	current := sl.header
+	for i := sl.level; i >= 0; i-- {
+		for current.forward[i] != nil && traversed+current.span[i] <= r {
+			traversed += current.span[i]
+			h += current.hspan[i]
+			current = current.forward[i]
+		}
+	}
+	return h
+}
+
+// linkHashes sets the hash spans of n, just linked by insert on the update
+// path recorded in sl.hranks, and of the links around it. h is the hash of n.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) linkHashes(n *node[K, V], update []INode[K, V], h uint64) {
+	n.sizeHSpan()
+	level := len(n.forward)
+	for i := 0; i < level; i++ {
+		prev := update[i].(*node[K, V])
+		// newSpan is the hash from prev up to and including n.
+		newSpan := sl.hranks[0] - sl.hranks[i] + h
+		n.hspan[i] = prev.hspan[i] + h - newSpan
+		prev.hspan[i] = newSpan
+	}
+	for i := level; i <= sl.level; i++ {
+		update[i].(*node[K, V]).hspan[i] += h
+	}
+	sl.hashes += h
+}
+
+// unlinkHashes removes the hash of n, about to be unlinked by deleteNode,
+// from the hash spans of the update path. h is the hash of n.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) unlinkHashes(n *node[K, V], update []INode[K, V], h uint64) {
+	for i := 0; i <= sl.level; i++ {
+		prev := update[i].(*node[K, V])
+		if prev.forward[i] == n {
+			prev.hspan[i] += n.hspan[i] - h
+		} else if prev.forward[i] != nil {
+			prev.hspan[i] -= h
+		}
+	}
+	sl.hashes -= h
+}
+
+// rehash adjusts the links of the update path that cover an entry whose hash
+// changes from old to h. The caller must hold the write lock.
+func (sl *SkipList[K, V]) rehash(update []INode[K, V], old, h uint64) {
+	for i := 0; i <= sl.level; i++ {
+		if prev := update[i].(*node[K, V]); prev.forward[i] != nil {
+			prev.hspan[i] += h - old
+		}
+	}
+	sl.hashes += h - old
+}
+
+// sizeHSpan sizes the hash spans of n to the level of n, reusing the backing
+// array left by a pool allocator when it is large enough.
+func (n *node[K, V]) sizeHSpan() {
+	if level := len(n.forward); cap(n.hspan) < level {
+		n.hspan = make([]uint64, level)
+	} else {
+		n.hspan = n.hspan[:level]
+	}
+}
@@ -0,0 +1,190 @@
+package skiplist
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func testEntryHash(k, v int) uint64 {
+	return mix64(uint64(k)<<32 ^ uint64(uint32(v)))
+}
+
+func TestMerkle_RangeHash(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(7, 8))
+			sl := setup.constructor(nil, WithMerkle(testEntryHash))
+			model := map[int]int{}
+
+			check := func(step int) {
+				t.Helper()
+				if err := sl.CheckSpans(); err != nil {
+					t.Fatalf("step %d: %v", step, err)
+				}
+				for j := 0; j < 20; j++ {
+					lo, hi := r.IntN(550)-25, r.IntN(550)-25
+					var want uint64
+					for k, v := range model {
+						if k >= lo && k <= hi {
+							want += testEntryHash(k, v)
+						}
+					}
+					if got := sl.RangeHash(lo, hi); got != want {
+						t.Fatalf("step %d: RangeHash(%d, %d) = %x, want %x", step, lo, hi, got, want)
+					}
+				}
+			}
+
+			for step := 0; step < 1500; step++ {
+				switch op := r.IntN(10); {
+				case op < 6:
+					k, v := r.IntN(500), r.IntN(1000)
+					sl.Insert(k, v)
+					model[k] = v
+				case op == 6:
+					if n, ok := sl.PopMin(); ok {
+						delete(model, n.Key())
+					}
+				default:
+					k := r.IntN(500)
+					sl.Delete(k)
+					delete(model, k)
+				}
+				if step%100 == 0 {
+					check(step)
+				}
+			}
+			check(-1)
+
+			if err := sl.MigrateAllocator(); err != nil {
+				t.Fatal(err)
+			}
+			check(-2)
+			sl.Clear()
+			if got := sl.RangeHash(0, 500); got != 0 {
+				t.Errorf("RangeHash after Clear = %x, want 0", got)
+			}
+		})
+	}
+}
+
+func TestMerkle_BulkLoadAndDigests(t *testing.T) {
+	sl := New(WithMerkle(testEntryHash))
+	sl.Insert(0, 0)
+	i := 0
+	if _, err := sl.BulkLoad(func() (int, int, bool) {
+		i += 2
+		return i, i, i <= 400
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sl.CheckSpans(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := sl.Digest(10, 20, true) // 10, 12, ..., 18
+	if d.Count != 5 || d.Hash != sl.RangeHash(10, 18) {
+		t.Errorf("Digest(10, 20, open) = %+v, want 5 entries hashing like RangeHash(10, 18)", d)
+	}
+	if d := sl.Digest(20, 10, false); d.Count != 0 || d.Hash != 0 {
+		t.Errorf("Digest of an inverted range = %+v, want empty", d)
+	}
+
+	whole := sl.Digest(-5, 1000, false)
+	parts := sl.SplitDigest(whole, 4)
+	if len(parts) != 4 || parts[0].Start != -5 || parts[3].End != 1000 || parts[3].Open {
+		t.Fatalf("SplitDigest bounds = %+v", parts)
+	}
+	var count int
+	var hash uint64
+	for k, p := range parts {
+		if k > 0 && p.Start != parts[k-1].End {
+			t.Errorf("part %d starts at %d, previous ends at %d", k, p.Start, parts[k-1].End)
+		}
+		if got := sl.Digest(p.Start, p.End, p.Open); got != p {
+			t.Errorf("part %d = %+v, Digest of its bounds = %+v", k, p, got)
+		}
+		count += p.Count
+		hash += p.Hash
+	}
+	if count != whole.Count || hash != whole.Hash {
+		t.Errorf("parts sum to %d entries and hash %x, want %d and %x", count, hash, whole.Count, whole.Hash)
+	}
+	if parts := sl.SplitDigest(sl.Digest(1, 1, false), 4); len(parts) != 1 || parts[0].Count != 0 {
+		t.Errorf("SplitDigest of an empty range = %+v", parts)
+	}
+}
+
+func TestMerkle_FindDivergence(t *testing.T) {
+	local := New(WithMerkle(testEntryHash))
+	replica := New(WithMerkle(testEntryHash))
+	for i := 0; i < 5000; i++ {
+		local.Insert(i, i)
+		replica.Insert(i, i)
+	}
+	replica.Insert(1234, -1)   // changed value
+	replica.Delete(3000)       // missing on the replica
+	replica.Insert(4999999, 0) // only on the replica, past the local keys
+
+	calls := 0
+	remote := func(d RangeDigest[int]) (RangeDigest[int], error) {
+		calls++
+		return replica.Digest(d.Start, d.End, d.Open), nil
+	}
+	var found []int
+	err := local.FindDivergence(0, 5000000, 4, 8, remote, func(d RangeDigest[int]) bool {
+		if d.Count > 8 {
+			t.Errorf("leaf range %+v holds more than 8 entries", d)
+		}
+		local.RangeQuery(d.Start, d.End, func(k, v int) bool {
+			if d.Open && k == d.End {
+				return false
+			}
+			if n, ok := replica.Search(k); !ok || n.Value() != v {
+				found = append(found, k)
+			}
+			return true
+		})
+		if d.Count == 0 || !d.Open {
+			found = append(found, -1) // a range where the replica has extra keys
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1234, 3000, -1}; !slices.Equal(found, want) {
+		t.Errorf("divergent keys = %v, want %v", found, want)
+	}
+	if calls > 100 {
+		t.Errorf("FindDivergence made %d remote calls for 3 differences in 5000 entries", calls)
+	}
+
+	errRemote := errors.New("unreachable")
+	err = local.FindDivergence(0, 5000000, 4, 8, func(RangeDigest[int]) (RangeDigest[int], error) {
+		return RangeDigest[int]{}, errRemote
+	}, func(RangeDigest[int]) bool { return true })
+	if !errors.Is(err, errRemote) {
+		t.Errorf("FindDivergence returned %v, want the remote error", err)
+	}
+}
+
+func TestMerkle_Panics(t *testing.T) {
+	assertPanics := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s did not panic", name)
+			}
+		}()
+		f()
+	}
+	plain := New[int, int]()
+	assertPanics("RangeHash without WithMerkle", func() { plain.RangeHash(0, 1) })
+	sl := New(WithMerkle(testEntryHash))
+	assertPanics("FindDivergence with one part", func() {
+		sl.FindDivergence(0, 1, 1, 1, nil, nil)
+	})
+}
@@ -0,0 +1,220 @@
+package skiplist
+
+import "errors"
+
+// ErrMigrationInProgress is returned by MigrateAllocator when another
+// migration of the same skiplist has not finished yet.
+var ErrMigrationInProgress = errors.New("skiplist: allocator migration already in progress")
+
+// migrateBatch is the number of nodes MigrateAllocator copies per hold of
+// the read lock, and migrateAttempts the number of batched copies it starts
+// before it copies under the write lock.
+const (
+	migrateBatch    = 1024
+	migrateAttempts = 3
+)
+
+// MigrateAllocator moves all live entries into memory obtained from a new
+// allocator configured by opts, e.g. from the default pool into an arena
+// (WithArena), from an arena back into a pool (no arena option), or into an
+// arena of a different size. Only allocator-related options (including
+// WithGrowthObserver, which otherwise carries over) are taken into account;
+// any other option is ignored. Migrating into an arena created with
+// WithFixedArena that is too small fails with ErrArenaFull and leaves the list
+// unchanged.
+//
+// The nodes are copied incrementally, in batches of 1024 under the read lock,
+// which is released between batches so that writers make progress during the
+// migration; readers are served throughout. The copy is swapped in under a
+// short write lock. A write that slips in between two batches invalidates
+// the copy, which then starts over; after three copies invalidated this way,
+// the last one is made under the write lock, blocking writers for a full
+// copy, so that the migration finishes under a steady stream of writes.
+//
+// Node handles (INode) and iterators obtained before the migration keep
+// referring to the old nodes: they stay safe to read but no longer observe
+// updates made to the list.
+//
+// MigrateAllocator ย้ายข้อมูลทั้งหมดไปยังหน่วยความจำจาก allocator ใหม่ที่กำหนดด้วย opts
+// การคัดลอกทำทีละชุดภายใต้ read lock และปล่อย lock ระหว่างชุด ผู้อ่านและผู้เขียนจึงยังทำงานได้ระหว่างการย้าย
+func (sl *SkipList[K, V]) MigrateAllocator(opts ...Option[K, V]) error {
+	if !sl.migrating.CompareAndSwap(false, true) {
+		return ErrMigrationInProgress
+	}
+	defer sl.migrating.Store(false)
+
+	// Collect the allocator configuration on a scratch list so that the
+	// options are validated exactly as in NewWithComparator.
+	cfg := &SkipList[K, V]{growth: sl.growth}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var m *migration[K, V]
+	for attempt := 0; attempt < migrateAttempts && m == nil; attempt++ {
+		var err error
+		if m, err = sl.migrateBatches(cfg); err != nil {
+			return err
+		}
+	}
+
+	sl.mutex.Lock()
+	defer sl.mutex.Unlock()
+	if m == nil || !m.current() {
+		m = sl.newMigration(cfg)
+		if err := m.step(0); err != nil {
+			return err
+		}
+	}
+	if sl.secureWipe {
+		wipeValues(sl.header.forward[0])
+	}
+	sl.header = m.header
+	sl.level = m.level
+	sl.allocator = m.alloc
+	sl.epoch++
+	sl.arenaInitialSize = cfg.arenaInitialSize
+	sl.arenaGrowthFactor = cfg.arenaGrowthFactor
+	sl.arenaGrowthBytes = cfg.arenaGrowthBytes
+	sl.arenaGrowthThreshold = cfg.arenaGrowthThreshold
+	sl.arenaNodePadding = cfg.arenaNodePadding
+	sl.arenaAlignment = cfg.arenaAlignment
+	sl.arenaHugePages = cfg.arenaHugePages
+	sl.arenaFixed = cfg.arenaFixed
+	sl.growth = cfg.growth
+	// An arena handed back by a FrozenSkipList has the old settings.
+	sl.spare = nil
+	if sl.ids != nil {
+		sl.reindexIDs()
+	}
+	// Drop references to the old nodes kept by the update path and hot caches.
+	clear(sl.updateCache)
+	if sl.hot != nil {
+		sl.hot.reset()
+	}
+	return nil
+}
+
+// migrateBatches copies the list in batches of migrateBatch nodes, taking
+// the read lock for each batch. It returns a nil migration if a write
+// invalidated the copy.
+func (sl *SkipList[K, V]) migrateBatches(cfg *SkipList[K, V]) (*migration[K, V], error) {
+	sl.mutex.RLock()
+	m := sl.newMigration(cfg)
+	for {
+		if !m.current() {
+			sl.mutex.RUnlock()
+			return nil, nil
+		}
+		if err := m.step(migrateBatch); err != nil || m.old == nil {
+			sl.mutex.RUnlock()
+			return m, err
+		}
+		sl.mutex.RUnlock()
+		sl.mutex.RLock()
+	}
+}
+
+// migration is a copy of the nodes of sl into memory obtained from a new
+// allocator, built by MigrateAllocator. The copy keeps the exact same
+// topology (levels and spans) as the original, so no rebalancing or rank
+// recomputation is needed.
+type migration[K any, V any] struct {
+	sl     *SkipList[K, V]
+	alloc  nodeAllocator[K, V]
+	header *node[K, V]
+	level  int
+
+	// version, compactions and tagWrites identify the state of sl being
+	// copied.
+	version     uint64
+	compactions int
+	tagWrites   uint64
+
+	old  *node[K, V]           // the next node of sl to copy, nil when done
+	prev *node[K, V]           // the last node copied
+	last [MaxLevel]*node[K, V] // the last node copied with a pointer at each level
+}
+
+// newMigration starts a copy of the nodes of sl into a new allocator built
+// from cfg. The caller must hold at least the read lock.
+func (sl *SkipList[K, V]) newMigration(cfg *SkipList[K, V]) *migration[K, V] {
+	m := &migration[K, V]{
+		sl:          sl,
+		alloc:       cfg.newAllocator(),
+		level:       sl.level,
+		version:     sl.version,
+		compactions: sl.levelCompactions,
+		tagWrites:   sl.tagWrites.Load(),
+		old:         sl.header.forward[0],
+	}
+	m.header = &node[K, V]{
+		forward: make([]*node[K, V], MaxLevel),
+		span:    make([]int, MaxLevel),
+	}
+	copy(m.header.span, sl.header.span)
+	if sl.weight != nil {
+		m.header.wspan = make([]int, MaxLevel)
+		copy(m.header.wspan, sl.header.wspan)
+	}
+	if sl.merkle != nil {
+		m.header.hspan = make([]uint64, MaxLevel)
+		copy(m.header.hspan, sl.header.hspan)
+	}
+	m.prev = m.header
+	for i := range m.last {
+		m.last[i] = m.header
+	}
+	return m
+}
+
+// current reports whether sl has not changed since the copy started, so
+// that the copy can go on or be swapped in. The caller must hold at least
+// the read lock.
+func (m *migration[K, V]) current() bool {
+	return m.sl.version == m.version && m.sl.levelCompactions == m.compactions &&
+		m.sl.tagWrites.Load() == m.tagWrites
+}
+
+// step copies up to n nodes, or all the remaining nodes if n <= 0. It
+// returns ErrArenaFull if the entries do not fit in a fixed-size arena. The
+// caller must hold at least the read lock.
+func (m *migration[K, V]) step(n int) error {
+	sl := m.sl
+	for copied := 0; m.old != nil && (n <= 0 || copied < n); copied++ {
+		old := m.old
+		level := len(old.forward)
+		c := m.alloc.Get(level)
+		if c == nil {
+			return ErrArenaFull
+		}
+		c.key = old.key
+		c.prefix = old.prefix
+		c.value = old.value
+		c.gen = old.gen
+		c.tag.Store(old.tag.Load())
+		copy(c.span, old.span)
+		if sl.weight != nil {
+			c.sizeWSpan()
+			copy(c.wspan, old.wspan)
+		}
+		if sl.merkle != nil {
+			c.sizeHSpan()
+			copy(c.hspan, old.hspan)
+		}
+		if sl.backLinks {
+			c.sizeBack()
+		}
+		for i := 0; i < level; i++ {
+			m.last[i].forward[i] = c
+			if sl.backLinks {
+				c.back[i] = m.last[i]
+			}
+			m.last[i] = c
+		}
+		c.backward = m.prev
+		m.prev = c
+		m.old = old.forward[0]
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestMigrateAllocator(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			const n = 2000
+			for i := 0; i < n; i++ {
+				sl.Insert(i*2, i)
+			}
+
+			targets := []struct {
+				name  string
+				opts  []Option[int, int]
+				arena bool
+			}{
+				{"ToArena", []Option[int, int]{WithArena[int, int](4096), WithArenaGrowthFactor[int, int](2)}, true},
+				{"ToPool", nil, false},
+				{"ToResizedArena", []Option[int, int]{WithArena[int, int](1 << 20)}, true},
+			}
+
+			for _, target := range targets {
+				if err := sl.MigrateAllocator(target.opts...); err != nil {
+					t.Fatalf("%s: MigrateAllocator returned %v", target.name, err)
+				}
+				if _, ok := sl.allocator.(*arenaAllocator[int, int]); ok != target.arena {
+					t.Fatalf("%s: arena allocator = %v, want %v", target.name, ok, target.arena)
+				}
+				if sl.Len() != n {
+					t.Fatalf("%s: Len() = %d, want %d", target.name, sl.Len(), n)
+				}
+				for i := 0; i < n; i++ {
+					node, ok := sl.GetByRank(i)
+					if !ok || node.Key() != i*2 || node.Value() != i {
+						t.Fatalf("%s: GetByRank(%d) = %v, %v", target.name, i, node, ok)
+					}
+					if r := sl.Rank(i * 2); r != i {
+						t.Fatalf("%s: Rank(%d) = %d, want %d", target.name, i*2, r, i)
+					}
+				}
+
+				// The migrated list must remain fully writable.
+				sl.Insert(-1, -1)
+				if !sl.Delete(-1) {
+					t.Fatalf("%s: Delete(-1) after migration failed", target.name)
+				}
+				it := sl.NewIterator(WithReverse[int, int]())
+				count := 0
+				for it.Next() {
+					if it.Key() != (n-1-count)*2 {
+						t.Fatalf("%s: reverse iteration got %d at step %d", target.name, it.Key(), count)
+					}
+					count++
+				}
+				if count != n {
+					t.Fatalf("%s: reverse iteration visited %d entries, want %d", target.name, count, n)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateAllocator_ConcurrentReadersAndWriters(t *testing.T) {
+	sl := New[int, int]()
+	for i := 0; i < 1000; i++ {
+		sl.Insert(i, i)
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if _, ok := sl.Search(500); !ok {
+				t.Error("Search(500) failed during migration")
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 1000; i < 1500; i++ {
+			sl.Insert(i, i)
+		}
+	}()
+
+	for i := 0; i < 5; i++ {
+		var opts []Option[int, int]
+		if i%2 == 0 {
+			opts = append(opts, WithArena[int, int](8192))
+		}
+		if err := sl.MigrateAllocator(opts...); err != nil {
+			t.Fatalf("MigrateAllocator returned %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	if sl.Len() != 1500 {
+		t.Fatalf("Len() = %d, want 1500", sl.Len())
+	}
+	for i := 0; i < 1500; i++ {
+		if r := sl.Rank(i); r != i {
+			t.Fatalf("Rank(%d) = %d", i, r)
+		}
+	}
+}
+
+func TestMigrateAllocator_InProgress(t *testing.T) {
+	sl := New[int, int]()
+	sl.migrating.Store(true)
+	if err := sl.MigrateAllocator(); err != ErrMigrationInProgress {
+		t.Fatalf("expected ErrMigrationInProgress, got %v", err)
+	}
+}
+
+func TestMigrateAllocator_Batches(t *testing.T) {
+	sl := New[int, int]()
+	for i := 0; i < 3*migrateBatch+10; i++ {
+		sl.Insert(i, i)
+	}
+	cfg := &SkipList[int, int]{}
+	WithArena[int, int](1 << 16)(cfg)
+
+	// A batched copy releases the lock between batches, and a write in
+	// between invalidates it.
+	m, err := sl.migrateBatches(cfg)
+	if err != nil || m == nil || m.old != nil {
+		t.Fatalf("migrateBatches = %v, %v on an idle list", m, err)
+	}
+	sl.mutex.RLock()
+	m = sl.newMigration(cfg)
+	if err := m.step(migrateBatch); err != nil || m.old == nil || !m.current() {
+		t.Fatalf("step(%d) = %v, done = %v", migrateBatch, err, m.old == nil)
+	}
+	sl.mutex.RUnlock()
+	sl.Insert(-1, -1)
+	sl.mutex.RLock()
+	if m.current() {
+		t.Error("copy still current after an insert")
+	}
+	sl.mutex.RUnlock()
+	sl.CompactLevels()
+	sl.mutex.RLock()
+	m = sl.newMigration(cfg)
+	sl.mutex.RUnlock()
+	sl.Insert(-1, -2) // an update
+	sl.mutex.RLock()
+	if m.current() {
+		t.Error("copy still current after an update")
+	}
+	sl.mutex.RUnlock()
+
+	// Tags are set under the read lock without a new version, and still
+	// invalidate the copy.
+	tagged := New[int, int](WithEntryTags[int, int]())
+	tagged.Insert(1, 1)
+	tagged.mutex.RLock()
+	m = tagged.newMigration(cfg)
+	tagged.mutex.RUnlock()
+	n, _ := tagged.Search(1)
+	tagged.SetTag(n, 7)
+	tagged.mutex.RLock()
+	if m.current() {
+		t.Error("copy still current after SetTag")
+	}
+	tagged.mutex.RUnlock()
+
+	if err := sl.MigrateAllocator(WithArena[int, int](1 << 16)); err != nil {
+		t.Fatal(err)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sl.CheckSpans(); err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := sl.Search(-1); !ok || n.Value() != -2 || sl.Len() != 3*migrateBatch+11 {
+		t.Errorf("after MigrateAllocator: Search(-1) = %v, %v, Len() = %d", n, ok, sl.Len())
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package skiplist
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile reads the first size bytes of f in memory, where memory mapping
+// is not supported.
+func mapFile(f *os.File, size int) ([]byte, error) {
+	b := make([]byte, size)
+	if _, err := io.ReadFull(f, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// unmapFile is a no-op where memory mapping is not supported.
+func unmapFile([]byte) error { return nil }
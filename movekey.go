@@ -0,0 +1,76 @@
+package skiplist
+
+import "unsafe"
+
+// MoveKey moves the entry of key from sl to dst, replacing an entry already
+// present at key in dst, and reports whether key was present in sl. Both
+// write locks are held for the whole move, taken in a fixed order so that
+// concurrent moves between the same two lists in opposite directions cannot
+// deadlock: no reader of either list ever sees the key in both lists or in
+// neither, which makes it a building block for re-sharding keys one at a
+// time. The hooks and other options of each list observe a delete in sl and
+// an insert (or update) in dst. Moving a key to sl itself only reports
+// whether it is present.
+// It panics, leaving both lists unchanged, if dst rejects the entry, e.g.
+// with ErrArenaFull, ErrFrozen or an error wrapping ErrInvalidKey. A hook of
+// either list that panics once the entry is written to dst does not stop
+// the move: the panic propagates after the entry has left sl, so the key
+// never ends up in both lists.
+// MoveKey ย้ายรายการของ key จาก sl ไปยัง dst แบบ atomic โดยถือ lock ของทั้งสอง list
+func (sl *SkipList[K, V]) MoveKey(dst *SkipList[K, V], key K) bool {
+	if dst == sl {
+		_, ok := sl.Search(key)
+		return ok
+	}
+	tr := sl.traceStart(OpMove)
+	dtr := dst.traceStart(OpMove)
+	if uintptr(unsafe.Pointer(sl)) < uintptr(unsafe.Pointer(dst)) {
+		sl.mutex.Lock()
+		dst.mutex.Lock()
+		defer sl.mutex.Unlock()
+		defer dst.mutex.Unlock()
+	} else {
+		dst.mutex.Lock()
+		sl.mutex.Lock()
+		defer dst.mutex.Unlock()
+		defer sl.mutex.Unlock()
+	}
+	tr.locked()
+	dtr.locked()
+	defer sl.traceEnd(&tr)
+	defer dst.traceEnd(&dtr)
+
+	n := sl.findGreaterOrEqual(key)
+	if n == nil || sl.compare(n.key, key) != 0 {
+		return false
+	}
+	sl.mustNotBeFrozen()
+	// dst is written first: if it rejects the entry, sl still holds it.
+	// A hook panic raised after the write is held until sl is updated.
+	if p := dst.insertMoved(n.key, n.value); p != nil {
+		sl.delete(key)
+		panic(p)
+	}
+	sl.delete(key)
+	tr.keys, dtr.keys = 1, 1
+	return true
+}
+
+// insertMoved inserts the entry moved by MoveKey and returns the panic
+// raised by a callback, such as a hook, after the entry was written. Panics
+// raised before, which leave sl unchanged, propagate. The caller must hold
+// the write lock.
+func (sl *SkipList[K, V]) insertMoved(key K, value V) (p *CallbackPanic) {
+	version := sl.version
+	defer func() {
+		if r := recover(); r != nil {
+			cp, ok := r.(*CallbackPanic)
+			if !ok || sl.version == version {
+				panic(r)
+			}
+			p = cp
+		}
+	}()
+	sl.insert(key, value)
+	return nil
+}
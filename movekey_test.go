@@ -0,0 +1,102 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestMoveKey(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			var deleted, inserted []int
+			src := setup.constructor(nil, WithHooks(Hooks[int, string]{
+				OnDelete: func(k int, _ string) { deleted = append(deleted, k) },
+			}))
+			dst := setup.constructor(nil, WithHooks(Hooks[int, string]{
+				OnInsert: func(k int, _ string) { inserted = append(inserted, k) },
+			}))
+			for i := 0; i < 10; i++ {
+				src.Insert(i, "src")
+			}
+			dst.Insert(5, "dst")
+			inserted = nil
+
+			if !src.MoveKey(dst, 3) || !src.MoveKey(dst, 5) || src.MoveKey(dst, 42) {
+				t.Fatal("MoveKey did not report whether the key was present")
+			}
+			if _, ok := src.Search(3); ok || src.Len() != 8 {
+				t.Errorf("source still holds 3, Len() = %d", src.Len())
+			}
+			if n, ok := dst.Search(5); !ok || n.Value() != "src" || dst.Len() != 2 {
+				t.Errorf("dst.Search(5) = %v, %v, Len() = %d", n, ok, dst.Len())
+			}
+			if len(deleted) != 2 || len(inserted) != 1 || inserted[0] != 3 {
+				t.Errorf("hooks saw deletes %v and inserts %v", deleted, inserted)
+			}
+			if !src.MoveKey(src, 4) || src.MoveKey(src, 3) {
+				t.Error("MoveKey to the same list did not report presence")
+			}
+
+			dst.Freeze()
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Error("MoveKey to a frozen list did not panic")
+					}
+				}()
+				src.MoveKey(dst, 7)
+			}()
+			if _, ok := src.Search(7); !ok {
+				t.Error("failed MoveKey removed the entry from the source")
+			}
+		})
+	}
+}
+
+func TestMoveKeyHookPanic(t *testing.T) {
+	// A hook panicking once the entry is in dst still completes the move.
+	src := New[int, int]()
+	dst := New[int, int](WithHooks(Hooks[int, int]{
+		OnInsert: func(int, int) { panic("boom") },
+	}))
+	src.Insert(1, 1)
+	func() {
+		defer func() {
+			if _, ok := recover().(*CallbackPanic); !ok {
+				t.Error("MoveKey did not propagate the hook panic")
+			}
+		}()
+		src.MoveKey(dst, 1)
+	}()
+	if _, ok := src.Search(1); ok || dst.Len() != 1 {
+		t.Errorf("after a hook panic: source holds 1 = %v, dst Len() = %d", ok, dst.Len())
+	}
+	if err := src.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMoveKeyConcurrent(t *testing.T) {
+	skipWithoutSync(t)
+	a, b := New[int, int](), New[int, int]()
+	for i := 0; i < 100; i++ {
+		a.Insert(i, i)
+		b.Insert(i+100, i)
+	}
+	var wg sync.WaitGroup
+	for _, p := range [][2]*SkipList[int, int]{{a, b}, {b, a}} {
+		wg.Add(1)
+		go func(from, to *SkipList[int, int]) {
+			defer wg.Done()
+			for round := 0; round < 20; round++ {
+				for i := 0; i < 200; i++ {
+					from.MoveKey(to, i)
+				}
+			}
+		}(p[0], p[1])
+	}
+	wg.Wait()
+	if a.Len()+b.Len() != 200 {
+		t.Errorf("%d + %d entries after the moves, want 200", a.Len(), b.Len())
+	}
+}
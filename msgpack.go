@@ -0,0 +1,603 @@
+package skiplist
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// MsgpackCodec encodes snapshots as MessagePack so they can be read by
+// non-Go consumers. A snapshot is a single array holding one [key, value]
+// array per entry, in ascending key order:
+//
+//	[[k1, v1], [k2, v2], ...]
+//
+// Keys and values are mapped by reflection: booleans, integers, floats,
+// strings, []byte (bin), slices and arrays (array), maps (map), structs (map of
+// exported field names, renamed or skipped with a `msgpack:"name"` or
+// `msgpack:"-"` tag) and pointers (the pointed-to value, or nil).
+//
+// MsgpackCodec เข้ารหัส snapshot เป็น MessagePack เพื่อให้โปรแกรมภาษาอื่นอ่านได้
+type MsgpackCodec[K any, V any] struct{}
+
+type msgpackEntryEncoder[K any, V any] struct {
+	w   *bufio.Writer
+	buf []byte
+}
+
+type msgpackEntryDecoder[K any, V any] struct {
+	r *bufio.Reader
+}
+
+// NewEncoder implements Codec.
+func (MsgpackCodec[K, V]) NewEncoder(w io.Writer) EntryEncoder[K, V] {
+	return &msgpackEntryEncoder[K, V]{w: bufio.NewWriter(w)}
+}
+
+// NewDecoder implements Codec.
+func (MsgpackCodec[K, V]) NewDecoder(r io.Reader) EntryDecoder[K, V] {
+	return &msgpackEntryDecoder[K, V]{r: bufio.NewReader(r)}
+}
+
+func (e *msgpackEntryEncoder[K, V]) WriteHeader(count int) error {
+	e.buf = appendMsgpackArrayHeader(e.buf[:0], count)
+	_, err := e.w.Write(e.buf)
+	return err
+}
+
+func (e *msgpackEntryEncoder[K, V]) WriteEntry(key K, value V) error {
+	buf := appendMsgpackArrayHeader(e.buf[:0], 2)
+	buf, err := appendMsgpack(buf, reflect.ValueOf(&key).Elem())
+	if err != nil {
+		return err
+	}
+	if buf, err = appendMsgpack(buf, reflect.ValueOf(&value).Elem()); err != nil {
+		return err
+	}
+	e.buf = buf
+	_, err = e.w.Write(buf)
+	return err
+}
+
+func (e *msgpackEntryEncoder[K, V]) Close() error { return e.w.Flush() }
+
+func (d *msgpackEntryDecoder[K, V]) ReadHeader() (int, error) {
+	return readMsgpackLen(d.r, msgpackArray)
+}
+
+func (d *msgpackEntryDecoder[K, V]) ReadEntry() (key K, value V, err error) {
+	n, err := readMsgpackLen(d.r, msgpackArray)
+	if err == nil && n != 2 {
+		err = fmt.Errorf("skiplist: msgpack entry has %d elements, want 2", n)
+	}
+	if err == nil {
+		err = decodeMsgpack(d.r, reflect.ValueOf(&key).Elem())
+	}
+	if err == nil {
+		err = decodeMsgpack(d.r, reflect.ValueOf(&value).Elem())
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return key, value, err
+}
+
+// errMsgpackType is wrapped by decoding errors caused by a type mismatch.
+var errMsgpackType = errors.New("skiplist: msgpack type mismatch")
+
+func appendMsgpackArrayHeader(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xdc), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, 0xdd), uint32(n))
+	}
+}
+
+func appendMsgpackMapHeader(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xde), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, 0xdf), uint32(n))
+	}
+}
+
+func appendMsgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+func appendMsgpackBytes(b []byte, p []byte) []byte {
+	switch n := len(p); {
+	case n <= math.MaxUint8:
+		b = append(b, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xc5), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xc6), uint32(n))
+	}
+	return append(b, p...)
+}
+
+func appendMsgpackUint(b []byte, u uint64) []byte {
+	switch {
+	case u < 128:
+		return append(b, byte(u))
+	case u <= math.MaxUint8:
+		return append(b, 0xcc, byte(u))
+	case u <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(u))
+	case u <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(u))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xcf), u)
+	}
+}
+
+func appendMsgpackInt(b []byte, i int64) []byte {
+	switch {
+	case i >= 0:
+		return appendMsgpackUint(b, uint64(i))
+	case i >= -32:
+		return append(b, byte(i))
+	case i >= math.MinInt8:
+		return append(b, 0xd0, byte(i))
+	case i >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(i))
+	case i >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(i))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(i))
+	}
+}
+
+// msgpackFieldName returns the encoded name of a struct field, or "" if the
+// field is skipped.
+func msgpackFieldName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	switch tag := f.Tag.Get("msgpack"); tag {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	default:
+		return tag
+	}
+}
+
+// appendMsgpack appends the MessagePack encoding of v to b.
+func appendMsgpack(b []byte, v reflect.Value) ([]byte, error) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return append(b, 0xc0), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendMsgpackInt(b, v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return appendMsgpackUint(b, v.Uint()), nil
+	case reflect.Float32:
+		return binary.BigEndian.AppendUint32(append(b, 0xca), math.Float32bits(float32(v.Float()))), nil
+	case reflect.Float64:
+		return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(v.Float())), nil
+	case reflect.String:
+		return appendMsgpackString(b, v.String()), nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return append(b, 0xc0), nil
+		}
+		return appendMsgpack(b, v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return append(b, 0xc0), nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return appendMsgpackBytes(b, v.Bytes()), nil
+		}
+		fallthrough
+	case reflect.Array:
+		b = appendMsgpackArrayHeader(b, v.Len())
+		var err error
+		for i := 0; i < v.Len(); i++ {
+			if b, err = appendMsgpack(b, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return append(b, 0xc0), nil
+		}
+		b = appendMsgpackMapHeader(b, v.Len())
+		var err error
+		for it := v.MapRange(); it.Next(); {
+			if b, err = appendMsgpack(b, it.Key()); err != nil {
+				return nil, err
+			}
+			if b, err = appendMsgpack(b, it.Value()); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case reflect.Struct:
+		t := v.Type()
+		n := 0
+		for i := 0; i < t.NumField(); i++ {
+			if msgpackFieldName(t.Field(i)) != "" {
+				n++
+			}
+		}
+		b = appendMsgpackMapHeader(b, n)
+		var err error
+		for i := 0; i < t.NumField(); i++ {
+			name := msgpackFieldName(t.Field(i))
+			if name == "" {
+				continue
+			}
+			b = appendMsgpackString(b, name)
+			if b, err = appendMsgpack(b, v.Field(i)); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("skiplist: msgpack cannot encode %s", v.Type())
+	}
+}
+
+// msgpack format families, used by readMsgpackLen.
+const (
+	msgpackArray = iota
+	msgpackMap
+	msgpackStr
+	msgpackBin
+)
+
+// readMsgpackLen reads the header of an array, map, str or bin value.
+func readMsgpackLen(r *bufio.Reader, family int) (int, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	return msgpackLen(r, c, family)
+}
+
+func msgpackLen(r *bufio.Reader, c byte, family int) (int, error) {
+	var size int // width of the length field, for the non-fix formats
+	switch family {
+	case msgpackArray:
+		switch {
+		case c&0xf0 == 0x90:
+			return int(c & 0x0f), nil
+		case c == 0xdc:
+			size = 2
+		case c == 0xdd:
+			size = 4
+		}
+	case msgpackMap:
+		switch {
+		case c&0xf0 == 0x80:
+			return int(c & 0x0f), nil
+		case c == 0xde:
+			size = 2
+		case c == 0xdf:
+			size = 4
+		}
+	case msgpackStr, msgpackBin:
+		switch {
+		case c&0xe0 == 0xa0:
+			return int(c & 0x1f), nil
+		case c == 0xd9 || c == 0xc4:
+			size = 1
+		case c == 0xda || c == 0xc5:
+			size = 2
+		case c == 0xdb || c == 0xc6:
+			size = 4
+		}
+	}
+	if size == 0 {
+		return 0, fmt.Errorf("%w: unexpected format byte 0x%02x", errMsgpackType, c)
+	}
+	u, err := readMsgpackUintN(r, size)
+	return int(u), err
+}
+
+func readMsgpackUintN(r *bufio.Reader, size int) (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[:size]); err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(buf[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(buf[:])), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(buf[:])), nil
+	default:
+		return binary.BigEndian.Uint64(buf[:]), nil
+	}
+}
+
+// decodeMsgpack decodes the next value from r into v.
+func decodeMsgpack(r *bufio.Reader, v reflect.Value) error {
+	c, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if c == 0xc0 {
+		v.SetZero()
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		r.UnreadByte()
+		return decodeMsgpack(r, v.Elem())
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return fmt.Errorf("%w: cannot decode into %s", errMsgpackType, v.Type())
+		}
+		r.UnreadByte()
+		x, err := decodeMsgpackAny(r)
+		if err != nil {
+			return err
+		}
+		if x != nil {
+			v.Set(reflect.ValueOf(x))
+		}
+		return nil
+	case reflect.Bool:
+		if c != 0xc2 && c != 0xc3 {
+			break
+		}
+		v.SetBool(c == 0xc3)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return decodeMsgpackNumber(r, c, v)
+	case reflect.String:
+		n, err := msgpackLen(r, c, msgpackStr)
+		if err != nil {
+			return err
+		}
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return err
+		}
+		v.SetString(string(buf))
+		return nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 && (c&0xe0 == 0xa0 || (c >= 0xc4 && c <= 0xc6) || (c >= 0xd9 && c <= 0xdb)) {
+			n, err := msgpackLen(r, c, msgpackBin)
+			if err != nil {
+				return err
+			}
+			buf := make([]byte, n)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return err
+			}
+			if v.Kind() == reflect.Slice {
+				v.SetBytes(buf)
+			} else {
+				v.SetZero()
+				reflect.Copy(v, reflect.ValueOf(buf))
+			}
+			return nil
+		}
+		n, err := msgpackLen(r, c, msgpackArray)
+		if err != nil {
+			return err
+		}
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), n, n))
+		} else {
+			v.SetZero()
+		}
+		for i := 0; i < n; i++ {
+			if i >= v.Len() {
+				if err := skipMsgpack(r); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := decodeMsgpack(r, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		n, err := msgpackLen(r, c, msgpackMap)
+		if err != nil {
+			return err
+		}
+		t := v.Type()
+		v.Set(reflect.MakeMapWithSize(t, n))
+		for i := 0; i < n; i++ {
+			key := reflect.New(t.Key()).Elem()
+			if err := decodeMsgpack(r, key); err != nil {
+				return err
+			}
+			if !key.Comparable() {
+				return fmt.Errorf("%w: map key of type %s is not comparable", errMsgpackType, key.Type())
+			}
+			elem := reflect.New(t.Elem()).Elem()
+			if err := decodeMsgpack(r, elem); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, elem)
+		}
+		return nil
+	case reflect.Struct:
+		n, err := msgpackLen(r, c, msgpackMap)
+		if err != nil {
+			return err
+		}
+		v.SetZero()
+		t := v.Type()
+		for i := 0; i < n; i++ {
+			var name string
+			if err := decodeMsgpack(r, reflect.ValueOf(&name).Elem()); err != nil {
+				return err
+			}
+			field := -1
+			for j := 0; j < t.NumField(); j++ {
+				if msgpackFieldName(t.Field(j)) == name {
+					field = j
+					break
+				}
+			}
+			if field < 0 {
+				if err := skipMsgpack(r); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := decodeMsgpack(r, v.Field(field)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: format byte 0x%02x into %s", errMsgpackType, c, v.Type())
+}
+
+// decodeMsgpackNumber decodes an integer or float whose format byte is c into v.
+func decodeMsgpackNumber(r *bufio.Reader, c byte, v reflect.Value) error {
+	var (
+		i       int64
+		u       uint64
+		f       float64
+		isFloat bool
+		signed  bool
+		err     error
+	)
+	switch {
+	case c < 0x80:
+		u = uint64(c)
+	case c >= 0xe0:
+		i, signed = int64(int8(c)), true
+	case c >= 0xcc && c <= 0xcf:
+		u, err = readMsgpackUintN(r, 1<<(c-0xcc))
+	case c >= 0xd0 && c <= 0xd3:
+		size := 1 << (c - 0xd0)
+		u, err = readMsgpackUintN(r, size)
+		shift := 64 - 8*size
+		i, signed = int64(u<<shift)>>shift, true
+	case c == 0xca:
+		u, err = readMsgpackUintN(r, 4)
+		f, isFloat = float64(math.Float32frombits(uint32(u))), true
+	case c == 0xcb:
+		u, err = readMsgpackUintN(r, 8)
+		f, isFloat = math.Float64frombits(u), true
+	default:
+		return fmt.Errorf("%w: format byte 0x%02x into %s", errMsgpackType, c, v.Type())
+	}
+	if err != nil {
+		return err
+	}
+	if signed && i >= 0 {
+		u, signed = uint64(i), false
+	}
+
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		switch {
+		case isFloat:
+		case signed:
+			f = float64(i)
+		default:
+			f = float64(u)
+		}
+		v.SetFloat(f)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !signed {
+			i = int64(u)
+		}
+		if isFloat || (!signed && u > math.MaxInt64) || v.OverflowInt(i) {
+			break
+		}
+		v.SetInt(i)
+		return nil
+	default:
+		if isFloat || signed || v.OverflowUint(u) {
+			break
+		}
+		v.SetUint(u)
+		return nil
+	}
+	return fmt.Errorf("%w: value does not fit in %s", errMsgpackType, v.Type())
+}
+
+// decodeMsgpackAny decodes the next value into its natural Go representation:
+// nil, bool, int64, uint64, float64, string, []byte, []any or map[any]any.
+func decodeMsgpackAny(r *bufio.Reader) (any, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	r.UnreadByte()
+
+	var target reflect.Value
+	switch {
+	case c == 0xc0:
+		r.ReadByte()
+		return nil, nil
+	case c == 0xc2 || c == 0xc3:
+		target = reflect.New(reflect.TypeOf(false))
+	case c < 0x80 || (c >= 0xcc && c <= 0xcf):
+		target = reflect.New(reflect.TypeOf(uint64(0)))
+	case c >= 0xe0 || (c >= 0xd0 && c <= 0xd3):
+		target = reflect.New(reflect.TypeOf(int64(0)))
+	case c == 0xca || c == 0xcb:
+		target = reflect.New(reflect.TypeOf(float64(0)))
+	case c&0xe0 == 0xa0 || (c >= 0xd9 && c <= 0xdb):
+		target = reflect.New(reflect.TypeOf(""))
+	case c >= 0xc4 && c <= 0xc6:
+		target = reflect.New(reflect.TypeOf([]byte(nil)))
+	case c&0xf0 == 0x90 || c == 0xdc || c == 0xdd:
+		target = reflect.New(reflect.TypeOf([]any(nil)))
+	case c&0xf0 == 0x80 || c == 0xde || c == 0xdf:
+		target = reflect.New(reflect.TypeOf(map[any]any(nil)))
+	default:
+		return nil, fmt.Errorf("%w: unsupported format byte 0x%02x", errMsgpackType, c)
+	}
+	if err := decodeMsgpack(r, target.Elem()); err != nil {
+		return nil, err
+	}
+	return target.Elem().Interface(), nil
+}
+
+// skipMsgpack discards the next value.
+func skipMsgpack(r *bufio.Reader) error {
+	_, err := decodeMsgpackAny(r)
+	return err
+}
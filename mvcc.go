@@ -0,0 +1,169 @@
+package skiplist
+
+// versionedValue is one entry in the history of a key kept in MVCC mode.
+type versionedValue[V any] struct {
+	version uint64
+	value   V
+	deleted bool // true for a tombstone written by a delete
+}
+
+// versionStore keeps the per-key history of a skiplist in MVCC mode.
+// It is an interface so that SkipList[K, V] does not structurally contain a
+// SkipList of another value type, which would be an infinite instantiation.
+type versionStore[K any, V any] interface {
+	record(key K, version uint64, value V, deleted bool)
+	searchAt(key K, version uint64) (V, bool)
+	rangeAt(version uint64, f func(key K, value V))
+	gc(version uint64) int
+}
+
+// historyList implements versionStore with a companion skiplist ordered by the
+// same comparator as the main list.
+type historyList[K any, V any] struct {
+	list *SkipList[K, []versionedValue[V]]
+}
+
+// WithMVCC enables multi-version mode. Every insert, update and delete appends
+// a (version, value) record to the history of the affected key, stamped with the
+// value of Version() after the modification. Historical states can then be read
+// with SearchAt and SnapshotAt, and pruned with GCVersionsBefore.
+//
+// The history is kept in a companion skiplist ordered by the same comparator,
+// so the regular read paths (Search, Range, Rank, ...) are not slowed down.
+// Writes pay for one extra descent into the companion list.
+//
+// WithMVCC เปิดโหมดหลายเวอร์ชัน ทุกการเพิ่ม แก้ไข และลบ จะบันทึกประวัติ (version, value)
+// ของ key นั้นไว้ เพื่อให้อ่านสถานะย้อนหลังได้ด้วย SearchAt และ SnapshotAt
+func WithMVCC[K any, V any]() Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.history = &historyList[K, V]{list: NewWithComparator[K, []versionedValue[V]](sl.compare)}
+	}
+}
+
+// record appends a history record for key. The caller must hold the write lock
+// of the main list, which orders records of concurrent writers.
+func (h *historyList[K, V]) record(key K, version uint64, value V, deleted bool) {
+	l := h.list
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var hist []versionedValue[V]
+	if n := l.findGreaterOrEqual(key); n != nil && l.compare(n.key, key) == 0 {
+		hist = n.value
+	}
+	l.insert(key, append(hist, versionedValue[V]{version: version, value: value, deleted: deleted}))
+}
+
+// visibleAt returns the record of hist visible at version, if any.
+func visibleAt[V any](hist []versionedValue[V], version uint64) (versionedValue[V], bool) {
+	for i := len(hist) - 1; i >= 0; i-- {
+		if hist[i].version <= version {
+			return hist[i], !hist[i].deleted
+		}
+	}
+	return versionedValue[V]{}, false
+}
+
+func (h *historyList[K, V]) searchAt(key K, version uint64) (V, bool) {
+	l := h.list
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if n := l.findGreaterOrEqual(key); n != nil && l.compare(n.key, key) == 0 {
+		if rec, ok := visibleAt(n.value, version); ok {
+			return rec.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+func (h *historyList[K, V]) rangeAt(version uint64, f func(key K, value V)) {
+	l := h.list
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	for n := l.header.forward[0]; n != nil; n = n.forward[0] {
+		if rec, ok := visibleAt(n.value, version); ok {
+			f(n.key, rec.value)
+		}
+	}
+}
+
+func (h *historyList[K, V]) gc(version uint64) int {
+	l := h.list
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	removed := 0
+	var forget []K
+	for n := l.header.forward[0]; n != nil; n = n.forward[0] {
+		hist := n.value
+		idx := -1
+		for i := len(hist) - 1; i >= 0; i-- {
+			if hist[i].version <= version {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			continue
+		}
+		if idx == len(hist)-1 && hist[idx].deleted {
+			// Nothing is visible at or after version: forget the key.
+			removed += len(hist)
+			forget = append(forget, n.key)
+			continue
+		}
+		if idx > 0 {
+			removed += idx
+			n.value = append(hist[:0:0], hist[idx:]...)
+		}
+	}
+
+	for _, key := range forget {
+		l.delete(key)
+	}
+	return removed
+}
+
+// mustHistory returns the MVCC history or panics if MVCC is disabled.
+func (sl *SkipList[K, V]) mustHistory() versionStore[K, V] {
+	if sl.history == nil {
+		panic("skiplist: multi-version API used without WithMVCC")
+	}
+	return sl.history
+}
+
+// SearchAt returns the value key had at the given version, as observed right
+// after the modification that produced that version. It returns false if the
+// key did not exist (or was deleted) at that version.
+// SearchAt panics if the skiplist was not created with WithMVCC.
+// SearchAt คืนค่า value ของ key ณ เวอร์ชันที่กำหนด คืนค่า false หาก key ไม่มีอยู่ในเวอร์ชันนั้น
+func (sl *SkipList[K, V]) SearchAt(key K, version uint64) (V, bool) {
+	return sl.mustHistory().searchAt(key, version)
+}
+
+// SnapshotAt returns a new, independent skiplist holding the entries that were
+// visible at the given version. The returned list uses the same comparator and
+// the default pool allocator, and does not have MVCC enabled.
+// SnapshotAt panics if the skiplist was not created with WithMVCC.
+// SnapshotAt คืนค่า skiplist ใหม่ที่มีข้อมูลตามสถานะ ณ เวอร์ชันที่กำหนด
+func (sl *SkipList[K, V]) SnapshotAt(version uint64) *SkipList[K, V] {
+	h := sl.mustHistory()
+	snap := NewWithComparator[K, V](sl.compare)
+	h.rangeAt(version, func(key K, value V) {
+		snap.insert(key, value)
+	})
+	return snap
+}
+
+// GCVersionsBefore discards history that can no longer be observed by readers
+// at version or later: for every key, all records older than the one visible at
+// version are dropped, and keys whose visible record is a tombstone with nothing
+// newer are forgotten entirely. It returns the number of records removed.
+// GCVersionsBefore panics if the skiplist was not created with WithMVCC.
+// GCVersionsBefore ลบประวัติที่เก่ากว่าเวอร์ชันที่กำหนดซึ่งไม่สามารถอ่านได้อีกแล้ว
+func (sl *SkipList[K, V]) GCVersionsBefore(version uint64) int {
+	return sl.mustHistory().gc(version)
+}
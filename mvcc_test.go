@@ -0,0 +1,84 @@
+package skiplist
+
+import "testing"
+
+func TestMVCC(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil, WithMVCC[int, string]())
+
+			sl.Insert(1, "a1")
+			v1 := sl.Version()
+			sl.Insert(2, "b1")
+			sl.Insert(1, "a2")
+			v2 := sl.Version()
+			sl.Delete(2)
+			v3 := sl.Version()
+			sl.PopMin()
+			v4 := sl.Version()
+			sl.Insert(3, "c1")
+
+			tests := []struct {
+				key     int
+				version uint64
+				want    string
+				ok      bool
+			}{
+				{1, 0, "", false},
+				{1, v1, "a1", true},
+				{2, v1, "", false},
+				{1, v2, "a2", true},
+				{2, v2, "b1", true},
+				{2, v3, "", false},
+				{1, v3, "a2", true},
+				{1, v4, "", false},
+				{3, v4, "", false},
+				{3, sl.Version(), "c1", true},
+			}
+			for _, tt := range tests {
+				got, ok := sl.SearchAt(tt.key, tt.version)
+				if ok != tt.ok || got != tt.want {
+					t.Errorf("SearchAt(%d, %d) = %q, %v; want %q, %v", tt.key, tt.version, got, ok, tt.want, tt.ok)
+				}
+			}
+
+			snap := sl.SnapshotAt(v2)
+			if snap.Len() != 2 {
+				t.Fatalf("SnapshotAt(v2).Len() = %d, want 2", snap.Len())
+			}
+			if n, ok := snap.Search(1); !ok || n.Value() != "a2" {
+				t.Errorf("SnapshotAt(v2) key 1 = %v, %v", n, ok)
+			}
+
+			// After GC at v3, reads at v3 and later must be unaffected.
+			removed := sl.GCVersionsBefore(v3)
+			if removed != 3 {
+				t.Errorf("GCVersionsBefore(v3) removed %d records, want 3", removed)
+			}
+			if got, ok := sl.SearchAt(1, v3); !ok || got != "a2" {
+				t.Errorf("SearchAt(1, v3) after GC = %q, %v", got, ok)
+			}
+			if _, ok := sl.SearchAt(2, v3); ok {
+				t.Error("SearchAt(2, v3) after GC should be false")
+			}
+
+			sl.Clear()
+			if _, ok := sl.SearchAt(3, sl.Version()); ok {
+				t.Error("SearchAt after Clear should be false")
+			}
+			if removed := sl.GCVersionsBefore(sl.Version()); removed != 4 {
+				t.Errorf("GCVersionsBefore after Clear removed %d records, want 4", removed)
+			}
+		})
+	}
+}
+
+func TestMVCC_Disabled(t *testing.T) {
+	sl := New[int, int]()
+	defer func() {
+		if recover() == nil {
+			t.Error("SearchAt without WithMVCC should panic")
+		}
+	}()
+	sl.SearchAt(1, 0)
+}
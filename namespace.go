@@ -0,0 +1,177 @@
+package skiplist
+
+// Namespace is a view of the entries of a list that share one prefix of a
+// composite key, as returned by PrefixScanner.Namespace. It lets one list
+// back many tenants: each tenant gets the Namespace of its prefix, and its
+// operations cannot reach the keys of another tenant. Insert panics on a key
+// outside the namespace, while Search and Delete treat such a key as absent.
+// A Namespace is safe for concurrent use like the list itself.
+//
+// Each Namespace keeps the number of its entries, counted when it is created
+// and maintained by its Insert, Delete and Clear, which lets it enforce a
+// quota (see SetQuota). Keep one Namespace per tenant and write through it:
+// entries added or removed through the list itself, or evicted by
+// WithCapacity, are not counted until Recount.
+//
+// Namespace คือมุมมองของรายการที่มี prefix ของ key แบบ composite เดียวกัน ใช้แยกข้อมูลของแต่ละ tenant ใน list เดียว
+type Namespace[K any, V any] struct {
+	sl     *SkipList[K, V]
+	lo, hi K
+	count  int // จำนวนรายการภายใน namespace ป้องกันด้วย sl.mutex
+	quota  int // จำนวนรายการสูงสุด (SetQuota), 0 = ไม่จำกัด
+}
+
+// Namespace returns the Namespace of the entries with the given prefix.
+// Namespace คืนค่ามุมมองของรายการที่มี prefix ตามที่กำหนด
+func (p *PrefixScanner[K, V, P]) Namespace(prefix P) *Namespace[K, V] {
+	lo, hi := p.bounds(prefix)
+	ns := &Namespace[K, V]{sl: p.sl, lo: lo, hi: hi}
+	ns.Recount()
+	return ns
+}
+
+// owns reports whether key is within the bounds of the namespace.
+func (ns *Namespace[K, V]) owns(key K) bool {
+	return ns.sl.compare(key, ns.lo) >= 0 && ns.sl.compare(key, ns.hi) <= 0
+}
+
+// Insert inserts or updates key as SkipList.Insert does. It panics if key
+// is outside the namespace, and with a *QuotaError if the namespace is full.
+// Insert เพิ่มหรืออัปเดต key โดยจะ panic หาก key อยู่นอก namespace หรือเกินโควตา
+func (ns *Namespace[K, V]) Insert(key K, value V) INode[K, V] {
+	n, err := ns.TryInsert(key, value)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// TryInsert is like Insert but returns, leaving the list unchanged, a
+// *QuotaError when key is new and the namespace holds as many entries as
+// its quota, and the errors of SkipList.TryInsert. Updating an existing key
+// is always allowed.
+// TryInsert ทำงานเหมือน Insert แต่คืนค่า error เมื่อเกินโควตาของ namespace
+func (ns *Namespace[K, V]) TryInsert(key K, value V) (INode[K, V], error) {
+	if !ns.owns(key) {
+		panic("skiplist: key outside namespace")
+	}
+	sl := ns.sl
+	tr := sl.traceStart(OpInsert)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	if ns.quota > 0 && ns.count >= ns.quota {
+		if n := sl.findGreaterOrEqual(key); n == nil || sl.compare(n.key, key) != 0 {
+			return nil, &QuotaError{Quota: ns.quota, Len: ns.count}
+		}
+	}
+	n, existed, err := sl.tryInsert(key, value)
+	if err != nil {
+		return nil, err
+	}
+	tr.keys = 1
+	if existed {
+		return n, nil
+	}
+	ns.count++
+	return nil, nil
+}
+
+// Search returns the entry of key, or false if it is absent or outside the
+// namespace.
+// Search ค้นหารายการของ key ภายใน namespace
+func (ns *Namespace[K, V]) Search(key K) (INode[K, V], bool) {
+	if !ns.owns(key) {
+		return nil, false
+	}
+	return ns.sl.Search(key)
+}
+
+// Delete removes key and reports whether it was found in the namespace.
+// Delete ลบ key ภายใน namespace และคืนค่าว่าพบหรือไม่
+func (ns *Namespace[K, V]) Delete(key K) bool {
+	if !ns.owns(key) {
+		return false
+	}
+	sl := ns.sl
+	tr := sl.traceStart(OpDelete)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	if sl.delete(key) {
+		tr.keys = 1
+		ns.count--
+		return true
+	}
+	if sl.lww != nil {
+		// A delete is a write in LWW mode even if the key is absent locally.
+		sl.stampLWW(key, true)
+	}
+	return false
+}
+
+// Len returns the number of entries in the namespace, as maintained by the
+// Namespace.
+// Len คืนค่าจำนวนรายการภายใน namespace
+func (ns *Namespace[K, V]) Len() int {
+	ns.sl.mutex.RLock()
+	defer ns.sl.mutex.RUnlock()
+	return ns.count
+}
+
+// Recount counts the entries of the namespace again, in O(log n) (O(n) with
+// WithoutRankTracking), after writes that bypassed it, and returns their
+// number.
+// Recount นับจำนวนรายการภายใน namespace ใหม่จาก list
+func (ns *Namespace[K, V]) Recount() int {
+	sl := ns.sl
+	sl.mutex.Lock()
+	defer sl.mutex.Unlock()
+
+	ns.count = 0
+	if sl.compare(ns.lo, ns.hi) <= 0 {
+		ns.count = sl.rank(ns.hi, true) - sl.rank(ns.lo, false)
+	}
+	return ns.count
+}
+
+// Range calls f for every entry of the namespace, in key order, until f
+// returns false.
+// Range วนลูปไปตามรายการทั้งหมดภายใน namespace จนกว่า f จะคืนค่า false
+func (ns *Namespace[K, V]) Range(f func(key K, value V) bool) {
+	ns.sl.RangeQuery(ns.lo, ns.hi, f)
+}
+
+// RangeQuery calls f for every entry of the namespace whose key is between
+// start and end (inclusive), until f returns false.
+// RangeQuery วนลูปไปตามรายการภายใน namespace ที่ key อยู่ระหว่าง start และ end
+func (ns *Namespace[K, V]) RangeQuery(start, end K, f func(key K, value V) bool) {
+	if ns.sl.compare(start, ns.lo) < 0 {
+		start = ns.lo
+	}
+	if ns.sl.compare(end, ns.hi) > 0 {
+		end = ns.hi
+	}
+	ns.sl.RangeQuery(start, end, f)
+}
+
+// Clear removes every entry of the namespace with a single DeleteRange, in
+// O(log n + k) for k entries, and returns the number of entries removed.
+// The entries of other namespaces are left untouched.
+// Clear ลบทุกรายการภายใน namespace ด้วย DeleteRange ครั้งเดียว
+func (ns *Namespace[K, V]) Clear() int {
+	sl := ns.sl
+	tr := sl.traceStart(OpDelete)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	tr.keys = sl.deleteRange(ns.lo, ns.hi)
+	ns.count = 0
+	return tr.keys
+}
@@ -0,0 +1,64 @@
+package skiplist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNamespace(t *testing.T) {
+	for _, setup := range getTestCustomKeySetups[userEvent, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(compareUserEvent)
+			p := NewPrefixScanner[userEvent, string, int](sl, userBounds)
+			a, b := p.Namespace(1), p.Namespace(2)
+			for ts := int64(1); ts <= 5; ts++ {
+				a.Insert(userEvent{1, ts}, "a")
+				b.Insert(userEvent{2, ts}, "b")
+			}
+
+			if a.Len() != 5 || b.Len() != 5 {
+				t.Fatalf("Len() = %d, %d, want 5, 5", a.Len(), b.Len())
+			}
+			if _, ok := a.Search(userEvent{2, 3}); ok {
+				t.Error("Search found another tenant's key")
+			}
+			if n, ok := b.Search(userEvent{2, 3}); !ok || n.Value() != "b" {
+				t.Errorf("Search(%v) = %v, %v", userEvent{2, 3}, n, ok)
+			}
+			if a.Delete(userEvent{2, 3}) || !b.Delete(userEvent{2, 3}) {
+				t.Error("Delete crossed namespaces")
+			}
+
+			var ts []int64
+			b.RangeQuery(userEvent{0, 0}, userEvent{2, 4}, func(k userEvent, _ string) bool {
+				ts = append(ts, k.TS)
+				return true
+			})
+			if !reflect.DeepEqual(ts, []int64{1, 2, 4}) {
+				t.Errorf("RangeQuery = %v, want [1 2 4]", ts)
+			}
+
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Error("Insert outside the namespace did not panic")
+					}
+				}()
+				a.Insert(userEvent{3, 1}, "a")
+			}()
+
+			if n := a.Clear(); n != 5 {
+				t.Errorf("Clear() = %d, want 5", n)
+			}
+			if a.Len() != 0 || sl.Len() != 4 {
+				t.Errorf("after Clear, Len() = %d and list Len() = %d, want 0 and 4", a.Len(), sl.Len())
+			}
+			b.Range(func(k userEvent, _ string) bool {
+				if k.UserID != 2 {
+					t.Errorf("Range yielded %v", k)
+				}
+				return true
+			})
+		})
+	}
+}
@@ -1,13 +1,17 @@
 package skiplist
 
 import (
-	"sync"
+	"errors"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
 type INode[K any, V any] interface {
 	Key() K
 	Value() V
+	// ID returns the stable ID of the entry, see SearchByID.
+	ID() uint64
 }
 
 // Node คือโหนดแต่ละตัวใน skiplist
@@ -17,6 +21,13 @@ type node[K any, V any] struct {
 	backward *node[K, V]   // ตัวชี้ไปยังโหนดก่อนหน้า (เฉพาะชั้น 0)
 	forward  []*node[K, V] // สไลซ์ของตัวชี้ไปยังโหนดถัดไปในแต่ละชั้น
 	span     []int         // span บอกจำนวนโหนดที่ข้ามไปในแต่ละชั้น
+	wspan    []int         // ผลรวมน้ำหนักของโหนดที่ข้ามไปในแต่ละชั้นเมื่อเปิดใช้ WithWeights
+	hspan    []uint64      // ผลรวม hash ของโหนดที่ข้ามไปในแต่ละชั้นเมื่อเปิดใช้ WithMerkle
+	back     []*node[K, V] // ตัวชี้ไปยังโหนดก่อนหน้าในแต่ละชั้นเมื่อเปิดใช้ WithBidirectionalLevels
+	prefix   uint64        // prefix ของ key ที่เก็บไว้เมื่อเปิดใช้ WithKeyPrefix (มิฉะนั้นเป็น 0)
+	gen      uint64        // generation ของรายการที่โหนดเก็บอยู่สำหรับ Handle (0 = ไม่ได้อยู่ใน list)
+	tag      atomic.Uint64 // metadata ของผู้ใช้เมื่อเปิดใช้ WithEntryTags
+	hits     uint32        // จำนวนครั้งที่ถูกค้นหาเมื่อเปิดใช้ WithHotCache (อ่านเขียนแบบ atomic)
 }
 
 func (n *node[K, V]) Key() K {
@@ -27,6 +38,15 @@ func (n *node[K, V]) Value() V {
 	return n.value
 }
 
+// ID returns the ID the list assigned to the entry when it was inserted:
+// IDs increase with every insertion of a new key, are kept when the value
+// of the key is replaced or the list is migrated to another allocator, and
+// are never reused by the list. It returns 0 once the entry is deleted.
+// ID คืนค่า ID ของรายการที่กำหนดให้ตอน insert (ไม่ซ้ำกันภายใน list เดียวกัน)
+func (n *node[K, V]) ID() uint64 {
+	return n.gen
+}
+
 // reset clears the node's data so it can be safely reused by an allocator.
 // It clears pointers to prevent memory leaks and resets slices while retaining
 // their underlying capacity for performance.
@@ -36,46 +56,162 @@ func (n *node[K, V]) Value() V {
 func (n *node[K, V]) reset() {
 	var zeroK K
 	var zeroV V
-	n.key, n.value, n.backward = zeroK, zeroV, nil
-	clear(n.span)
-	clear(n.forward)
+	n.key, n.value, n.backward, n.prefix, n.gen, n.hits = zeroK, zeroV, nil, 0, 0, 0
+	n.tag.Store(0)
+	clear(n.span[:cap(n.span)])
+	clear(n.wspan[:cap(n.wspan)])
+	clear(n.hspan[:cap(n.hspan)])
+	clear(n.back[:cap(n.back)])
+	clear(n.forward[:cap(n.forward)])
+}
+
+// --- Node Blocks ---
+
+// A node and its forward and span arrays are carved from one block, so that a
+// node costs a single allocation and a descent reads its links from the same
+// cache lines as its key. Blocks come in size classes of 1, 2, 4, 8 and
+// MaxLevel levels; a node of level L uses the smallest class holding L levels.
+// With P = 0.25, three nodes in four have a single level, so little is wasted.
+type (
+	nodeBlock1[K any, V any] struct {
+		node    node[K, V]
+		forward [1]*node[K, V]
+		span    [1]int
+	}
+	nodeBlock2[K any, V any] struct {
+		node    node[K, V]
+		forward [2]*node[K, V]
+		span    [2]int
+	}
+	nodeBlock4[K any, V any] struct {
+		node    node[K, V]
+		forward [4]*node[K, V]
+		span    [4]int
+	}
+	nodeBlock8[K any, V any] struct {
+		node    node[K, V]
+		forward [8]*node[K, V]
+		span    [8]int
+	}
+	nodeBlockMax[K any, V any] struct {
+		node    node[K, V]
+		forward [MaxLevel]*node[K, V]
+		span    [MaxLevel]int
+	}
+)
+
+// blockClasses is the number of node block size classes.
+const blockClasses = 5
+
+// blockClassShare is the expected fraction of nodes falling in each size
+// class, used to split the initial size of an arena between the classes.
+var blockClassShare = [blockClasses]float64{0.75, 0.1875, 0.0586, 0.0039, 0.0001}
+
+// blockClass returns the size class of a node of the given level.
+func blockClass(level int) int {
+	switch {
+	case level <= 1:
+		return 0
+	case level <= 2:
+		return 1
+	case level <= 4:
+		return 2
+	case level <= 8:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// carve links the node of the block to the block's arrays, sized for level.
+func (b *nodeBlock1[K, V]) carve(level int) *node[K, V] {
+	b.node.forward, b.node.span = b.forward[:level], b.span[:level]
+	return &b.node
+}
+
+func (b *nodeBlock2[K, V]) carve(level int) *node[K, V] {
+	b.node.forward, b.node.span = b.forward[:level], b.span[:level]
+	return &b.node
+}
+
+func (b *nodeBlock4[K, V]) carve(level int) *node[K, V] {
+	b.node.forward, b.node.span = b.forward[:level], b.span[:level]
+	return &b.node
+}
+
+func (b *nodeBlock8[K, V]) carve(level int) *node[K, V] {
+	b.node.forward, b.node.span = b.forward[:level], b.span[:level]
+	return &b.node
+}
+
+func (b *nodeBlockMax[K, V]) carve(level int) *node[K, V] {
+	b.node.forward, b.node.span = b.forward[:level], b.span[:level]
+	return &b.node
+}
+
+// blockPtr is satisfied by pointers to the node block types.
+type blockPtr[T any, K any, V any] interface {
+	*T
+	carve(level int) *node[K, V]
+}
+
+// newBlock allocates a block of size class c on the heap and returns its node,
+// with forward and span sliced to the full capacity of the class.
+func newBlock[K any, V any](c int) *node[K, V] {
+	switch c {
+	case 0:
+		return new(nodeBlock1[K, V]).carve(1)
+	case 1:
+		return new(nodeBlock2[K, V]).carve(2)
+	case 2:
+		return new(nodeBlock4[K, V]).carve(4)
+	case 3:
+		return new(nodeBlock8[K, V]).carve(8)
+	default:
+		return new(nodeBlockMax[K, V]).carve(MaxLevel)
+	}
 }
 
 // --- Node Allocator Abstraction ---
 
 // nodeAllocator defines the interface for memory allocation strategies for nodes.
 // This allows swapping between sync.Pool, memory arenas, or other strategies.
+// Get returns a zeroed node whose forward and span slices have length level.
 // nodeAllocator คือ interface สำหรับกลยุทธ์การจัดสรรหน่วยความจำสำหรับโหนด
 // ทำให้สามารถสลับระหว่าง sync.Pool, memory arena, หรือกลยุทธ์อื่นๆ ได้
 type nodeAllocator[K any, V any] interface {
-	Get() *node[K, V]
+	Get(level int) *node[K, V]
 	Put(*node[K, V])
 	Reset()
 }
 
 // --- sync.Pool Implementation ---
 
-// poolAllocator implements nodeAllocator using a sync.Pool.
+// poolAllocator implements nodeAllocator using one sync.Pool per block size
+// class.
 type poolAllocator[K any, V any] struct {
-	pool sync.Pool
+	pools [blockClasses]syncPool
 }
 
 func newPoolAllocator[K any, V any]() *poolAllocator[K, V] {
-	return &poolAllocator[K, V]{
-		pool: sync.Pool{
-			New: func() any { return &node[K, V]{} },
-		},
+	p := &poolAllocator[K, V]{}
+	for c := range p.pools {
+		p.pools[c].New = func() any { return newBlock[K, V](c) }
 	}
+	return p
 }
 
-func (p *poolAllocator[K, V]) Get() *node[K, V] {
-	return p.pool.Get().(*node[K, V])
+func (p *poolAllocator[K, V]) Get(level int) *node[K, V] {
+	n := p.pools[blockClass(level)].Get().(*node[K, V])
+	n.forward, n.span = n.forward[:level], n.span[:level]
+	return n
 }
 
 func (p *poolAllocator[K, V]) Put(n *node[K, V]) {
-	// Reset the node to clear its contents before returning it to the pool.
+	// Reset the node to clear its contents before returning it to the pool
+	// of its class, which is given by the capacity of its block.
 	n.reset()
-	p.pool.Put(n)
+	p.pools[blockClass(cap(n.forward))].Put(n)
 }
 
 func (p *poolAllocator[K, V]) Reset() {
@@ -86,37 +222,53 @@ func (p *poolAllocator[K, V]) Reset() {
 
 // --- Arena Implementation ---
 
-// arenaAllocator implements nodeAllocator using a memory arena.
+// ErrArenaFull is returned by TryInsert and BulkLoad when an arena created with
+// WithFixedArena has no room left for a new node.
+var ErrArenaFull = errors.New("skiplist: fixed-size arena is full")
+
+// arenaAllocator implements nodeAllocator using a memory arena. Each block
+// size class is carved from its own slab of chunks.
 type arenaAllocator[K any, V any] struct {
-	// chunks holds slices of nodes. Each chunk is a Go-managed slice of
-	// concrete `node` values so that the Go GC is aware of pointer fields
-	// inside nodes. This avoids storing Go pointers in raw byte buffers
-	// (which the GC would not scan) and prevents subtle memory corruption
-	// during long-running benchmarks and GC cycles.
-	chunks [][]node[K, V]
-	// current index within the last chunk
-	pos int
-	// next growth size (number of nodes to allocate for the next chunk)
-	nextChunkSize int
-	// nodeSize is the sizeof(node[K,V]) in bytes; used when interpreting
-	// growth bytes options.
-	nodeSize int
+	slabs [blockClasses]nodeSlab[K, V]
+	// limit is the budget in bytes of a fixed-size arena (0 = unbounded), and
+	// allocated the number of bytes held by the chunks of all slabs.
+	limit     int
+	allocated int
 	// growth strategy derived from ArenaOption args
 	growthFactor    float64
 	growthBytes     int
 	growthThreshold float64
+	// alignment is the address alignment of the blocks in bytes (0 = none),
+	// and hugePages whether large chunks are advised to use huge pages.
+	alignment int
+	hugePages bool
+	// observe is called after a chunk is added (see WithGrowthObserver).
+	observe func(ArenaGrowth)
+}
+
+// nodeSlab hands out the blocks of one size class.
+type nodeSlab[K any, V any] interface {
+	// get returns nil if the arena is fixed-size and full.
+	get(level int) *node[K, V]
+	// reset returns the number of bytes kept by the slab.
+	reset() int
+	// usage reports the number of chunks, and the capacity and number of
+	// blocks handed out since the last reset, both in blocks.
+	usage() (chunks, capacity, used int)
+	// free returns the number of blocks that can be handed out before the
+	// slab allocates memory, the first chunk, reserved by the initial size,
+	// counting as free until it is allocated. For a fixed-size arena, budget
+	// is the number of bytes not yet allocated by any slab; the first chunk
+	// is shrunk to it and its size deducted from it.
+	free(budget *int) int
+	// eachChunk calls f for every chunk with blocks handed out since the
+	// last reset, passing the address of its first slot, the size of a slot
+	// in bytes, the stride (see arenaSlab) and the number of slots handed
+	// out, every stride-th one holding a block whose node starts the slot.
+	eachChunk(f func(first unsafe.Pointer, slotSize, stride, slots int))
 }
 
 func newArenaAllocator[K any, V any](initialSize int, _opts ...ArenaOption) *arenaAllocator[K, V] {
-	// Determine how many nodes the initialSize (in bytes) can hold.
-	nodeSize := int(unsafe.Sizeof(node[K, V]{}))
-	if nodeSize <= 0 {
-		nodeSize = 1
-	}
-	count := initialSize / nodeSize
-	if count < 1 {
-		count = 1
-	}
 	// Apply provided ArenaOption funcs on a temporary Arena to extract
 	// growth parameters (growthFactor, growthBytes, growthThreshold).
 	var tmp Arena
@@ -128,37 +280,107 @@ func newArenaAllocator[K any, V any](initialSize int, _opts ...ArenaOption) *are
 	}
 
 	a := &arenaAllocator[K, V]{
-		chunks:          make([][]node[K, V], 0, 4),
-		pos:             0,
-		nextChunkSize:   count,
-		nodeSize:        nodeSize,
 		growthFactor:    tmp.growthFactor,
 		growthBytes:     tmp.growthBytes,
 		growthThreshold: tmp.growthThreshold,
+		alignment:       tmp.alignment,
+		hugePages:       tmp.hugePages,
 	}
-	a.grow() // allocate first chunk
+	if tmp.fixed {
+		a.limit = initialSize
+	}
+	a.slabs[0] = newArenaSlab[nodeBlock1[K, V], K, V](a, initialSize, blockClassShare[0], tmp.nodePadding)
+	a.slabs[1] = newArenaSlab[nodeBlock2[K, V], K, V](a, initialSize, blockClassShare[1], tmp.nodePadding)
+	a.slabs[2] = newArenaSlab[nodeBlock4[K, V], K, V](a, initialSize, blockClassShare[2], tmp.nodePadding)
+	a.slabs[3] = newArenaSlab[nodeBlock8[K, V], K, V](a, initialSize, blockClassShare[3], tmp.nodePadding)
+	a.slabs[4] = newArenaSlab[nodeBlockMax[K, V], K, V](a, initialSize, blockClassShare[4], tmp.nodePadding)
 	return a
 }
 
-// grow allocates a new chunk of nodes and appends it to chunks.
-func (a *arenaAllocator[K, V]) grow() {
+// Get returns nil if the arena is fixed-size and full. A fixed-size arena
+// never grows, so when the size class of level is exhausted the node is
+// carved from a larger class that still has room.
+func (a *arenaAllocator[K, V]) Get(level int) *node[K, V] {
+	for c := blockClass(level); c < blockClasses; c++ {
+		if n := a.slabs[c].get(level); n != nil || a.limit == 0 {
+			return n
+		}
+	}
+	return nil
+}
+
+func (a *arenaAllocator[K, V]) Put(n *node[K, V]) {
+	// No-op. Memory will be reclaimed on Reset().
+}
+
+func (a *arenaAllocator[K, V]) Reset() {
+	a.allocated = 0
+	for _, s := range a.slabs {
+		a.allocated += s.reset()
+	}
+}
+
+// arenaSlab carves blocks of type T from chunks of T values. Each chunk is a
+// Go-managed slice of concrete blocks so that the Go GC is aware of pointer
+// fields inside nodes. This avoids storing Go pointers in raw byte buffers
+// (which the GC would not scan) and prevents subtle memory corruption
+// during long-running benchmarks and GC cycles.
+type arenaSlab[T any, K any, V any, PT blockPtr[T, K, V]] struct {
+	arena  *arenaAllocator[K, V]
+	chunks [][]T
+	// current index within the last chunk
+	pos int
+	// next growth size (number of blocks to allocate for the next chunk)
+	nextChunkSize int
+	// blockSize is the sizeof(T) in bytes; used when interpreting growth
+	// bytes options.
+	blockSize int
+	// stride is the distance, in block slots, between two blocks handed out
+	// by get. It is greater than 1 when WithNodePadding is used; the skipped
+	// slots stay zero and serve as padding.
+	stride int
+}
+
+// newArenaSlab creates a slab whose first chunk holds the share of
+// initialSize bytes expected to be used by its size class. The chunk is only
+// allocated when the first block of the class is requested.
+func newArenaSlab[T any, K any, V any, PT blockPtr[T, K, V]](a *arenaAllocator[K, V], initialSize int, share float64, padding int) nodeSlab[K, V] {
+	var zero T
+	blockSize := int(unsafe.Sizeof(zero))
+	count := int(float64(initialSize) * share / float64(blockSize))
+	if count < 1 {
+		count = 1
+	}
+	stride := 1 + (padding+blockSize-1)/blockSize
+	if a.alignment > 0 {
+		stride = alignedStride(stride, blockSize, a.alignment)
+	}
+	return &arenaSlab[T, K, V, PT]{
+		arena:         a,
+		chunks:        make([][]T, 0, 4),
+		nextChunkSize: count,
+		blockSize:     blockSize,
+		stride:        stride,
+	}
+}
+
+// grow allocates a new chunk of blocks and appends it to chunks. A
+// fixed-size arena only allocates the first chunk, shrunk to the remaining
+// budget if needed; grow returns false once no chunk can be added.
+func (s *arenaSlab[T, K, V, PT]) grow() bool {
+	if s.arena.limit > 0 && len(s.chunks) > 0 {
+		return false
+	}
 	var size int
-	if len(a.chunks) == 0 {
-		size = a.nextChunkSize
+	if len(s.chunks) == 0 {
+		size = s.nextChunkSize
 	} else {
-		lastSize := len(a.chunks[len(a.chunks)-1])
-		if a.growthBytes > 0 {
-			// Interpret growthBytes as number of bytes to add; convert to node count
-			calc := a.growthBytes / a.nodeSize
-			if calc < 1 {
-				calc = 1
-			}
-			size = calc
-		} else if a.growthFactor > 1.0 {
-			size = int(float64(lastSize) * a.growthFactor)
-			if size < 1 {
-				size = 1
-			}
+		lastSize := len(s.chunks[len(s.chunks)-1])
+		if s.arena.growthBytes > 0 {
+			// Interpret growthBytes as number of bytes to add; convert to block count
+			size = s.arena.growthBytes / s.blockSize
+		} else if s.arena.growthFactor > 1.0 {
+			size = int(float64(lastSize) * s.arena.growthFactor)
 		} else {
 			// Default: double the previous chunk size
 			size = lastSize * 2
@@ -168,45 +390,142 @@ func (a *arenaAllocator[K, V]) grow() {
 	if size < 1 {
 		size = 1
 	}
+	extra := s.slack()
+	if s.arena.alignment > 0 {
+		size = max(size, (minAlignedChunk+s.blockSize-1)/s.blockSize-extra)
+	}
+	if a := s.arena; a.limit > 0 {
+		free := (a.limit-a.allocated)/s.blockSize - extra
+		if free < 1 {
+			return false
+		}
+		size = min(size, free)
+	}
 
-	chunk := make([]node[K, V], size)
-	a.chunks = append(a.chunks, chunk)
-	a.pos = 0
+	var start time.Time
+	if s.arena.observe != nil {
+		start = time.Now()
+	}
+	reason := GrowthChunkFull
+	if len(s.chunks) == 0 {
+		reason = GrowthFirstChunk
+	}
+	oldSize := s.arena.allocated
+	chunk := make([]T, size+extra)
+	if s.arena.hugePages && len(chunk)*s.blockSize >= hugePageSize {
+		adviseHugePages(chunkBytes(chunk))
+	}
+	if s.arena.alignment > 0 {
+		chunk = alignChunk(chunk, size, s.arena.alignment)
+	}
+	s.chunks = append(s.chunks, chunk)
+	s.arena.allocated += (size + extra) * s.blockSize
+	s.pos = 0
 	// Prepare nextChunkSize as current size (used if no previous chunks exist)
-	a.nextChunkSize = size
+	s.nextChunkSize = size
+	if s.arena.observe != nil {
+		s.arena.observe(ArenaGrowth{
+			OldSize:  oldSize,
+			NewSize:  s.arena.allocated,
+			Blocks:   (size + s.stride - 1) / s.stride,
+			Reason:   reason,
+			Duration: time.Since(start),
+		})
+	}
+	return true
 }
 
-func (a *arenaAllocator[K, V]) Get() *node[K, V] {
-	// Ensure we have at least one chunk.
-	if len(a.chunks) == 0 {
-		a.grow()
+func (s *arenaSlab[T, K, V, PT]) get(level int) *node[K, V] {
+	// Ensure we have at least one chunk, and grow if the current one is exhausted.
+	if len(s.chunks) == 0 || s.pos >= len(s.chunks[len(s.chunks)-1]) {
+		if !s.grow() {
+			return nil
+		}
 	}
-	// If current chunk is exhausted, grow.
-	if a.pos >= len(a.chunks[len(a.chunks)-1]) {
-		a.grow()
+	// Return the node of the next block in the current chunk.
+	chunk := s.chunks[len(s.chunks)-1]
+	b := &chunk[s.pos]
+	// Zero the block to ensure a valid Go zero-value (clears slice headers/pointers).
+	// clear avoids a zero temporary, which 32-bit targets move to the heap
+	// to align the atomic tag of the node.
+	clear(chunk[s.pos : s.pos+1])
+	s.pos += s.stride
+	return PT(b).carve(level)
+}
+
+func (s *arenaSlab[T, K, V, PT]) reset() int {
+	// Keep the first chunk but discard others to allow GC of extra memory.
+	if len(s.chunks) == 0 {
+		return 0
 	}
-	// Return pointer to next node in the current chunk.
-	last := &a.chunks[len(a.chunks)-1]
-	n := &(*last)[a.pos]
-	// Zero the node to ensure a valid Go zero-value (clears slice headers/pointers).
-	*n = node[K, V]{}
-	a.pos++
-	return n
+	s.chunks = s.chunks[:1]
+	s.pos = 0
+	// reset growth back to initial chunk size (length of first chunk)
+	s.nextChunkSize = len(s.chunks[0])
+	return (len(s.chunks[0]) + s.slack()) * s.blockSize
 }
 
-func (a *arenaAllocator[K, V]) Put(n *node[K, V]) {
-	// No-op. Memory will be reclaimed on Reset().
+// slack returns the number of blocks allocated with each chunk, beyond its
+// size, to move its first block to an aligned address (see
+// WithArenaAlignment).
+func (s *arenaSlab[T, K, V, PT]) slack() int {
+	if s.arena.alignment == 0 {
+		return 0
+	}
+	return alignedStride(1, s.blockSize, s.arena.alignment) - 1
 }
 
-func (a *arenaAllocator[K, V]) Reset() {
-	// Keep the first chunk but discard others to allow GC of extra memory.
-	if len(a.chunks) == 0 {
-		return
+func (s *arenaSlab[T, K, V, PT]) usage() (chunks, capacity, used int) {
+	// Chunks are measured in slots; with WithNodePadding only every
+	// stride-th slot holds a block.
+	slots := func(n int) int { return (n + s.stride - 1) / s.stride }
+	for i, c := range s.chunks {
+		capacity += slots(len(c))
+		if i < len(s.chunks)-1 {
+			used += slots(len(c))
+		}
 	}
-	first := a.chunks[0]
-	a.chunks = a.chunks[:1]
-	a.chunks[0] = first
-	a.pos = 0
-	// reset growth back to initial chunk size (length of first chunk)
-	a.nextChunkSize = len(first)
+	used += slots(s.pos)
+	return len(s.chunks), capacity, used
+}
+
+func (s *arenaSlab[T, K, V, PT]) free(budget *int) int {
+	slots := func(n int) int { return (n + s.stride - 1) / s.stride }
+	if len(s.chunks) == 0 {
+		size := s.nextChunkSize
+		if s.arena.limit > 0 {
+			size = min(size, max(*budget, 0)/s.blockSize-s.slack())
+			if size <= 0 {
+				return 0
+			}
+			*budget -= (size + s.slack()) * s.blockSize
+		}
+		return slots(size)
+	}
+	return slots(max(len(s.chunks[len(s.chunks)-1])-s.pos, 0))
+}
+
+func (s *arenaSlab[T, K, V, PT]) eachChunk(f func(first unsafe.Pointer, slotSize, stride, slots int)) {
+	for i, c := range s.chunks {
+		slots := len(c)
+		if i == len(s.chunks)-1 {
+			slots = min(s.pos, len(c))
+		}
+		if slots > 0 {
+			f(unsafe.Pointer(unsafe.SliceData(c)), s.blockSize, s.stride, slots)
+		}
+	}
+}
+
+// nodeUsage returns the number of blocks handed out since the last reset and
+// the number that can still be handed out before the arena allocates memory,
+// see Cap.
+func (a *arenaAllocator[K, V]) nodeUsage() (used, free int) {
+	budget := a.limit - a.allocated
+	for _, slab := range a.slabs {
+		_, _, u := slab.usage()
+		used += u
+		free += slab.free(&budget)
+	}
+	return used, free
 }
@@ -0,0 +1,62 @@
+package skiplist
+
+import "errors"
+
+// ErrNoRankTracking is returned by TryRank and TryGetByRank, and raised as a
+// panic by the other rank APIs, on a list created with WithoutRankTracking.
+var ErrNoRankTracking = errors.New("skiplist: rank API used without rank tracking")
+
+// WithoutRankTracking stops the list from maintaining the spans that record
+// how many entries each link skips, which every Insert and Delete otherwise
+// updates on all levels, for lists used as a plain ordered map that never
+// ask for ranks. The rank APIs (Rank, RankLT, RankLE, RevRank, CountLessThan,
+// CountGreaterThan, GetByRank, GetByRevRank, GetByRanks and the Rank of a
+// Cursor) then fail with
+// ErrNoRankTracking, and the operations that use ranks internally, such as
+// CountRange, KthInRange, Skip, SeekToRank, RangeParallel or ClearIncremental,
+// count by walking the bottom level in O(n) instead of O(log n).
+// It cannot be combined with WithMerkle, whose range hashes are located by
+// rank; New panics if both are given.
+// WithoutRankTracking ปิดการเก็บ span เพื่อให้ Insert และ Delete เร็วขึ้นเมื่อไม่ใช้ API เกี่ยวกับอันดับ
+func WithoutRankTracking[K any, V any]() Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.noRanks = true
+	}
+}
+
+func (sl *SkipList[K, V]) mustRanks() {
+	if sl.noRanks {
+		panic(ErrNoRankTracking)
+	}
+}
+
+// walkRank is rank for lists without rank tracking: it counts the entries
+// before key on the bottom level. The caller must hold a lock.
+func (sl *SkipList[K, V]) walkRank(key K, inclusive bool) int {
+	rank := 0
+	for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+		c := sl.compare(n.key, key)
+		if c > 0 || (c == 0 && !inclusive) {
+			break
+		}
+		rank++
+	}
+	return rank
+}
+
+// walkToRank is getByRank for lists without rank tracking: it walks the
+// bottom level from the nearest end. The caller must hold a lock.
+func (sl *SkipList[K, V]) walkToRank(rank int) *node[K, V] {
+	if rank >= sl.length/2 {
+		n := sl.last()
+		for i := sl.length - 1; i > rank; i-- {
+			n = n.backward
+		}
+		return n
+	}
+	n := sl.header.forward[0]
+	for ; rank > 0; rank-- {
+		n = n.forward[0]
+	}
+	return n
+}
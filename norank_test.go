@@ -0,0 +1,94 @@
+package skiplist
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestWithoutRankTracking(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			type change struct{ key, from, to int }
+			var changes []change
+			sl := setup.constructor(nil, WithoutRankTracking[int, int](), WithHooks(Hooks[int, int]{
+				OnRankChange: func(k, from, to int) { changes = append(changes, change{k, from, to}) },
+			}))
+			ref := setup.constructor(nil)
+
+			r := rand.New(rand.NewPCG(1, 2))
+			for i := 0; i < 3000; i++ {
+				k := r.IntN(1000)
+				if r.IntN(3) == 0 {
+					sl.Delete(k)
+					ref.Delete(k)
+				} else {
+					sl.Insert(k, k)
+					ref.Insert(k, k)
+				}
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			// Operations using ranks internally count by walking.
+			if got, want := sl.CountRange(100, 600), ref.CountRange(100, 600); got != want {
+				t.Errorf("CountRange = %d, want %d", got, want)
+			}
+			for _, k := range []int{0, 7, 100} {
+				got, ok := sl.KthInRange(200, 800, k)
+				want, _ := ref.KthInRange(200, 800, k)
+				if !ok || got.Key() != want.Key() {
+					t.Errorf("KthInRange(%d) = %v, want %v", k, got, want)
+				}
+			}
+			it, refIt := sl.NewIterator(), ref.NewIterator()
+			it.Next()
+			refIt.Next()
+			for _, k := range []int{1, 17, 200} {
+				if it.Skip(k) != refIt.Skip(k) || it.Key() != refIt.Key() {
+					t.Fatalf("Skip(%d) landed on %d, want %d", k, it.Key(), refIt.Key())
+				}
+			}
+
+			changes = nil
+			sl.Insert(-1, 0)
+			sl.Delete(-1)
+			if want := []change{{-1, -1, 0}, {-1, 0, -1}}; len(changes) != 2 || changes[0] != want[0] || changes[1] != want[1] {
+				t.Errorf("OnRankChange calls = %v, want %v", changes, want)
+			}
+
+			if _, err := sl.TryRank(5); !errors.Is(err, ErrNoRankTracking) {
+				t.Errorf("TryRank error = %v", err)
+			}
+			if _, err := sl.TryGetByRank(0); !errors.Is(err, ErrNoRankTracking) {
+				t.Errorf("TryGetByRank error = %v", err)
+			}
+			for name, f := range map[string]func(int) int{
+				"Rank":             sl.Rank,
+				"CountLessThan":    sl.CountLessThan,
+				"CountGreaterThan": sl.CountGreaterThan,
+			} {
+				func() {
+					defer func() {
+						if r := recover(); r != ErrNoRankTracking {
+							t.Errorf("%s panicked with %v", name, r)
+						}
+					}()
+					f(5)
+				}()
+			}
+
+			if n := sl.ClearIncremental(100); n != ref.Len() || !sl.IsEmpty() {
+				t.Errorf("ClearIncremental = %d, want %d", n, ref.Len())
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("WithoutRankTracking with WithMerkle did not panic")
+		}
+	}()
+	New(WithoutRankTracking[int, int](), WithMerkle(func(k, v int) uint64 { return uint64(k) }))
+}
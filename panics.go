@@ -0,0 +1,60 @@
+package skiplist
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Panics raised by functions supplied by the caller are contained as follows.
+//
+// Callbacks passed to an API (Range, RangeQuery, BulkLoad, GroupRange, ...)
+// and change hooks (see Hooks) are run with the skiplist in a consistent
+// state: read APIs do not modify it, and write APIs call hooks only once the
+// change they report is fully applied. If such a function panics, the lock
+// is released and the panic propagates to the caller of the API as a
+// *CallbackPanic, the list being left valid (Validate succeeds). A write that
+// changes several entries keeps the entries processed before the panic, and
+// hooks that had not run yet for them are skipped.
+//
+// Functions that shape the structure (the comparator and the functions of
+// WithWeights, WithMerkle and WithSizeFunc) are called before any node is linked or
+// unlinked, so a panic raised by them leaves the list unchanged. They run on
+// every operation and their panics are not wrapped.
+
+// CallbackPanic is the panic value propagated when a function supplied by
+// the caller panics inside an API of the package, see the policy above.
+// CallbackPanic คือค่าที่ถูก panic ต่อเมื่อ callback ของผู้ใช้ panic ภายใน API ของ package
+type CallbackPanic struct {
+	// API is the name of the API or hook running the callback, e.g. "Range"
+	// or "OnDelete".
+	API string
+	// Value is the value originally passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+func (p *CallbackPanic) Error() string {
+	return fmt.Sprintf("skiplist: panic in %s callback: %v", p.API, p.Value)
+}
+
+// Unwrap returns Value if it is an error, so that errors.Is and errors.As
+// see through the wrapper.
+func (p *CallbackPanic) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// rethrowCallbackPanic wraps a panic in progress into a *CallbackPanic for
+// api and panics again. It must be deferred directly. A panic already wrapped
+// by a nested API keeps its original API.
+func rethrowCallbackPanic(api string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if _, ok := r.(*CallbackPanic); ok {
+		panic(r)
+	}
+	panic(&CallbackPanic{API: api, Value: r, Stack: debug.Stack()})
+}
@@ -0,0 +1,165 @@
+package skiplist
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var errBoom = errors.New("boom")
+
+// catchPanic runs f and returns the value it panicked with, or nil.
+func catchPanic(f func()) (r any) {
+	defer func() { r = recover() }()
+	f()
+	return nil
+}
+
+// mustCallbackPanic checks that r is a *CallbackPanic raised by api.
+func mustCallbackPanic(t *testing.T, r any, api string) {
+	t.Helper()
+	p, ok := r.(*CallbackPanic)
+	if !ok {
+		t.Fatalf("recovered %v (%T), want a *CallbackPanic", r, r)
+	}
+	if p.API != api {
+		t.Errorf("API = %q, want %q", p.API, api)
+	}
+	if !errors.Is(p, errBoom) {
+		t.Errorf("errors.Is(%v, errBoom) = false", p)
+	}
+	if len(p.Stack) == 0 {
+		t.Error("Stack is empty")
+	}
+}
+
+func TestCallbackPanic_ReadAPIs(t *testing.T) {
+	sl := New[int, int]()
+	for i := 0; i < 100; i++ {
+		sl.Insert(i, i)
+	}
+	boom := func(int, int) bool { panic(errBoom) }
+	tests := []struct {
+		api string
+		f   func()
+	}{
+		{"Range", func() { sl.Range(boom) }},
+		{"RangeKeys", func() { sl.RangeKeys(func(int) bool { panic(errBoom) }) }},
+		{"RangeQuery", func() { sl.RangeQuery(10, 20, boom) }},
+		{"GroupRange", func() {
+			GroupRange(sl, 0, 99, func(k int) int { return k / 10 }, func(int, int, int) { panic(errBoom) })
+		}},
+		{"Range", func() { NewLayered(sl).Range(boom) }},
+	}
+	for _, tt := range tests {
+		mustCallbackPanic(t, catchPanic(tt.f), tt.api)
+		// The read lock was released.
+		sl.Insert(1000, 1000)
+		sl.Delete(1000)
+	}
+}
+
+func TestCallbackPanic_Hooks(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			var armed string
+			hook := func(name string) {
+				if armed == name {
+					panic(errBoom)
+				}
+			}
+			// Weights and range hashes make Validate check every span.
+			sl := setup.constructor(nil,
+				WithWeights[int, int](func(_, v int) int { return v }),
+				WithMerkle[int, int](func(k, _ int) uint64 { return mix64(uint64(k)) }),
+				WithHotCache[int, int](4),
+				WithHooks(Hooks[int, int]{
+					OnInsert:       func(int, int) { hook("OnInsert") },
+					OnUpdate:       func(int, int, int) { hook("OnUpdate") },
+					OnDelete:       func(int, int) { hook("OnDelete") },
+					OnBoundsChange: func(int, int, bool) { hook("OnBoundsChange") },
+				}))
+			for i := 1; i <= 50; i++ {
+				sl.Insert(i, i)
+				sl.Search(i)
+			}
+
+			steps := []struct {
+				hook string
+				op   func()
+				len  int
+			}{
+				{"OnInsert", func() { sl.Insert(100, 100) }, 51},
+				{"OnUpdate", func() { sl.Insert(100, 7) }, 51},
+				{"OnDelete", func() { sl.Delete(10) }, 50},
+				{"OnDelete", func() { sl.PopMin() }, 49},
+				{"OnBoundsChange", func() { sl.DeleteMax() }, 48},
+				{"OnDelete", func() { sl.Clear() }, 0},
+			}
+			for _, st := range steps {
+				armed = st.hook
+				mustCallbackPanic(t, catchPanic(st.op), st.hook)
+				armed = ""
+				if sl.Len() != st.len {
+					t.Errorf("after a panic in %s: Len() = %d, want %d", st.hook, sl.Len(), st.len)
+				}
+				if err := sl.Validate(); err != nil {
+					t.Fatalf("after a panic in %s: %v", st.hook, err)
+				}
+			}
+			if _, ok := sl.Search(10); ok {
+				t.Error("deleted key 10 is still found")
+			}
+			sl.Insert(1, 1)
+			if n, ok := sl.Search(1); !ok || n.Value() != 1 {
+				t.Errorf("Search(1) = %v, %v", n, ok)
+			}
+		})
+	}
+}
+
+func TestCallbackPanic_StructuralFunctions(t *testing.T) {
+	var armed bool
+	sl := New[int, int](WithMerkle[int, int](func(k, _ int) uint64 {
+		if armed {
+			panic("hash")
+		}
+		return mix64(uint64(k))
+	}))
+	for i := 0; i < 50; i++ {
+		sl.Insert(i, i)
+	}
+	before := sl.RangeHash(0, 100)
+
+	armed = true
+	for _, op := range []func(){
+		func() { sl.Insert(100, 100) },
+		func() { sl.Delete(10) },
+		func() { sl.BulkLoad(func() (int, int, bool) { return 200, 200, true }) },
+	} {
+		// Structural functions panic with their own value.
+		if r := catchPanic(op); r != "hash" {
+			if p, ok := r.(*CallbackPanic); !ok || p.Value != "hash" {
+				t.Fatalf("recovered %v, want the hash panic", r)
+			}
+		}
+	}
+	armed = false
+
+	if sl.Len() != 50 || sl.RangeHash(0, 100) != before {
+		t.Errorf("list changed by failed writes: Len() = %d", sl.Len())
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCallbackPanic_Error(t *testing.T) {
+	p := &CallbackPanic{API: "Range", Value: "oops"}
+	if !strings.Contains(p.Error(), "Range") || !strings.Contains(p.Error(), "oops") {
+		t.Errorf("Error() = %q", p.Error())
+	}
+	if p.Unwrap() != nil {
+		t.Errorf("Unwrap() = %v, want nil for a non-error value", p.Unwrap())
+	}
+}
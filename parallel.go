@@ -0,0 +1,87 @@
+package skiplist
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// RangeParallel calls f for every entry, splitting the list by rank into
+// workers contiguous segments of about the same size that are scanned
+// concurrently, each worker finding the start of its segment by span
+// arithmetic in O(log n). Within a segment, f is called in ascending key
+// order; across segments, calls are interleaved in no particular order. It
+// suits CPU-bound per-entry processing over tens of millions of entries.
+//
+// If f returns false, every worker stops after its current call. workers <= 0
+// uses runtime.GOMAXPROCS(0) workers. The whole scan holds the read lock, so
+// f must not write to the list, and must not read it either when
+// WithAdaptiveLocking has not switched to shared reads yet. A panic in f
+// stops the other workers and propagates to the caller as a *CallbackPanic.
+// In a skiplist_nosync build, the segments are scanned in turn by the
+// calling goroutine.
+//
+// RangeParallel แบ่ง skiplist ตาม rank เป็นช่วงต่อเนื่องและให้ worker หลายตัววนลูปแต่ละช่วงพร้อมกัน
+func (sl *SkipList[K, V]) RangeParallel(workers int, f func(key K, value V) bool) {
+	defer rethrowCallbackPanic("RangeParallel")
+	tr := sl.traceStart(OpRange)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, sl.length)
+	if workers == 0 {
+		return
+	}
+
+	var (
+		wg      syncWaitGroup
+		stop    atomic.Bool
+		visited atomic.Int64
+		once    syncOnce
+		failure *CallbackPanic
+	)
+	// scan runs the worker of segment w.
+	scan := func(w int) {
+		defer func() {
+			if r := recover(); r != nil {
+				stop.Store(true)
+				once.Do(func() {
+					failure = &CallbackPanic{API: "RangeParallel", Value: r, Stack: debug.Stack()}
+				})
+			}
+		}()
+		lo, hi := w*sl.length/workers, (w+1)*sl.length/workers
+		n := 0
+		defer func() { visited.Add(int64(n)) }()
+		for current := sl.getByRank(lo); n < hi-lo && !stop.Load(); current = current.forward[0] {
+			n++
+			if !f(current.key, current.value) {
+				stop.Store(true)
+			}
+		}
+	}
+	if !concurrent {
+		// Without goroutines, the segments are scanned one after the other.
+		for w := 0; w < workers && !stop.Load(); w++ {
+			scan(w)
+		}
+	} else {
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				scan(w)
+			}()
+		}
+	}
+	wg.Wait()
+	tr.keys = int(visited.Load())
+	if failure != nil {
+		panic(failure)
+	}
+}
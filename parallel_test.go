@@ -0,0 +1,69 @@
+package skiplist
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRangeParallel(t *testing.T) {
+	sl := New[int, int]()
+	const n = 10007
+	for i := 0; i < n; i++ {
+		sl.Insert(i, i*2)
+	}
+
+	for _, workers := range []int{0, 1, 3, 8, n + 5} {
+		var mu sync.Mutex
+		seen := make([]int, n)
+		var sum atomic.Int64
+		sl.RangeParallel(workers, func(k, v int) bool {
+			if v != k*2 {
+				t.Errorf("value of %d = %d", k, v)
+			}
+			mu.Lock()
+			seen[k]++
+			mu.Unlock()
+			sum.Add(int64(k))
+			return true
+		})
+		for k, c := range seen {
+			if c != 1 {
+				t.Fatalf("workers %d: key %d visited %d times", workers, k, c)
+			}
+		}
+		if sum.Load() != n*(n-1)/2 {
+			t.Errorf("workers %d: sum of keys = %d", workers, sum.Load())
+		}
+	}
+
+	// Returning false stops every worker early.
+	var calls atomic.Int64
+	sl.RangeParallel(4, func(k, _ int) bool {
+		calls.Add(1)
+		return false
+	})
+	if c := calls.Load(); c < 1 || c > 4 {
+		t.Errorf("stopped scan made %d calls, want at most one per worker", c)
+	}
+
+	// A panic in a worker reaches the caller.
+	boom := errors.New("boom")
+	func() {
+		defer func() {
+			p, ok := recover().(*CallbackPanic)
+			if !ok || p.API != "RangeParallel" || !errors.Is(p, boom) {
+				t.Errorf("recovered %v, want a *CallbackPanic wrapping boom", p)
+			}
+		}()
+		sl.RangeParallel(4, func(k, _ int) bool {
+			if k == n/2 {
+				panic(boom)
+			}
+			return true
+		})
+	}()
+	sl.Insert(-1, 0) // the read lock was released
+	New[int, int]().RangeParallel(4, func(int, int) bool { t.Error("called on an empty list"); return true })
+}
@@ -0,0 +1,116 @@
+package skiplist
+
+// PrefixScanner scans composite keys by a fixed prefix of the key tuple, e.g.
+// all entries with UserID == x ordered by timestamp for keys of type
+// struct{ UserID int; TS int64 }.
+//
+// The scanner relies on a user-provided bounds function that returns the
+// smallest and largest possible keys sharing a prefix (typically the prefix
+// combined with the minimum and maximum values of the remaining fields). All
+// keys with that prefix must sort between the two bounds, inclusive.
+//
+// PrefixScanner ใช้สำหรับค้นหา key แบบ composite ตาม prefix ที่กำหนด
+// โดยอาศัยฟังก์ชัน bounds ที่คืนค่า key ที่น้อยที่สุดและมากที่สุดของ prefix นั้น
+type PrefixScanner[K any, V any, P any] struct {
+	sl     *SkipList[K, V]
+	bounds func(prefix P) (lo, hi K)
+}
+
+// NewPrefixScanner creates a PrefixScanner over sl. bounds must not be nil.
+func NewPrefixScanner[K any, V any, P any](sl *SkipList[K, V], bounds func(prefix P) (lo, hi K)) *PrefixScanner[K, V, P] {
+	if bounds == nil {
+		panic("skiplist: prefix bounds function cannot be nil")
+	}
+	return &PrefixScanner[K, V, P]{sl: sl, bounds: bounds}
+}
+
+// Scan calls f for every entry with the given prefix, in key order, until f returns false.
+// Scan เรียก f สำหรับทุกรายการที่มี prefix ตามที่กำหนด เรียงตามลำดับ key
+func (p *PrefixScanner[K, V, P]) Scan(prefix P, f func(key K, value V) bool) {
+	defer rethrowCallbackPanic("Scan")
+	lo, hi := p.bounds(prefix)
+	p.sl.RangeQuery(lo, hi, f)
+}
+
+// Count returns the number of entries with the given prefix in O(log n).
+// Count คืนค่าจำนวนรายการที่มี prefix ตามที่กำหนด
+func (p *PrefixScanner[K, V, P]) Count(prefix P) int {
+	lo, hi := p.bounds(prefix)
+	sl := p.sl
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	if sl.compare(lo, hi) > 0 {
+		return 0
+	}
+	return sl.rank(hi, true) - sl.rank(lo, false)
+}
+
+// First returns the entry with the smallest key having the given prefix.
+// First คืนค่ารายการแรกที่มี prefix ตามที่กำหนด
+func (p *PrefixScanner[K, V, P]) First(prefix P) (INode[K, V], bool) {
+	lo, hi := p.bounds(prefix)
+	sl := p.sl
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	n := sl.findGreaterOrEqual(lo)
+	if n == nil || sl.compare(n.key, hi) > 0 {
+		return nil, false
+	}
+	return n, true
+}
+
+// Last returns the entry with the largest key having the given prefix.
+// Last คืนค่ารายการสุดท้ายที่มี prefix ตามที่กำหนด
+func (p *PrefixScanner[K, V, P]) Last(prefix P) (INode[K, V], bool) {
+	lo, hi := p.bounds(prefix)
+	sl := p.sl
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	r := sl.rank(hi, true)
+	if r == 0 {
+		return nil, false
+	}
+	n := sl.getByRank(r - 1)
+	if sl.compare(n.key, lo) < 0 {
+		return nil, false
+	}
+	return n, true
+}
+
+// Iterator returns a lock-holding iterator over the entries with the given
+// prefix, as returned by RangeIterator. The caller MUST call Close on it.
+// Iterator คืนค่า iterator สำหรับรายการที่มี prefix ตามที่กำหนด ต้องเรียก Close เมื่อใช้งานเสร็จ
+func (p *PrefixScanner[K, V, P]) Iterator(prefix P) *Iterator[K, V] {
+	lo, hi := p.bounds(prefix)
+	return p.sl.RangeIterator(lo, hi)
+}
+
+// GroupRange walks the entries whose key is between start and end
+// (inclusive) once, in key order under a single read lock, and calls agg with
+// the group of each entry as computed by groupOf, e.g. the prefix of a string
+// key or a field of a composite key. It replaces issuing one bounded
+// RangeQuery per group: agg typically accumulates into a map of per-group
+// aggregates. When groupOf returns a prefix of the key order, the entries of
+// each group are passed consecutively.
+//
+// groupOf and agg are called with the read lock held and must not modify sl.
+// GroupRange is a function rather than a method because Go methods cannot
+// have type parameters of their own.
+//
+// GroupRange วนลูปผ่านรายการในช่วง start ถึง end เพียงครั้งเดียว และเรียก agg พร้อมกลุ่มของแต่ละรายการ
+func GroupRange[K any, V any, G any](sl *SkipList[K, V], start, end K, groupOf func(K) G, agg func(group G, key K, value V)) {
+	defer rethrowCallbackPanic("GroupRange")
+	tr := sl.traceStart(OpRangeQuery)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	for n := sl.findGreaterOrEqual(start); n != nil && sl.compare(n.key, end) <= 0; n = n.forward[0] {
+		tr.keys++
+		agg(groupOf(n.key), n.key, n.value)
+	}
+}
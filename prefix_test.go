@@ -0,0 +1,110 @@
+package skiplist
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type userEvent struct {
+	UserID int
+	TS     int64
+}
+
+func compareUserEvent(a, b userEvent) int {
+	if c := cmp.Compare(a.UserID, b.UserID); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.TS, b.TS)
+}
+
+func userBounds(userID int) (userEvent, userEvent) {
+	return userEvent{userID, math.MinInt64}, userEvent{userID, math.MaxInt64}
+}
+
+func TestPrefixScanner(t *testing.T) {
+	for _, setup := range getTestCustomKeySetups[userEvent, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(compareUserEvent)
+			for _, e := range []userEvent{{1, 5}, {2, 30}, {2, 10}, {2, 20}, {3, 1}} {
+				sl.Insert(e, "")
+			}
+			p := NewPrefixScanner[userEvent, string, int](sl, userBounds)
+
+			var ts []int64
+			p.Scan(2, func(k userEvent, _ string) bool {
+				ts = append(ts, k.TS)
+				return true
+			})
+			if !reflect.DeepEqual(ts, []int64{10, 20, 30}) {
+				t.Errorf("Scan(2) = %v", ts)
+			}
+
+			if c := p.Count(2); c != 3 {
+				t.Errorf("Count(2) = %d, want 3", c)
+			}
+			if c := p.Count(4); c != 0 {
+				t.Errorf("Count(4) = %d, want 0", c)
+			}
+
+			if n, ok := p.First(2); !ok || n.Key().TS != 10 {
+				t.Errorf("First(2) = %v, %v", n, ok)
+			}
+			if n, ok := p.Last(2); !ok || n.Key().TS != 30 {
+				t.Errorf("Last(2) = %v, %v", n, ok)
+			}
+			if _, ok := p.First(0); ok {
+				t.Error("First(0) should not find anything")
+			}
+			if _, ok := p.Last(0); ok {
+				t.Error("Last(0) should not find anything")
+			}
+			if _, ok := p.Last(4); ok {
+				t.Error("Last(4) should not find anything")
+			}
+
+			it := p.Iterator(1)
+			count := 0
+			for it.Next() {
+				count++
+			}
+			it.Close()
+			if count != 1 {
+				t.Errorf("Iterator(1) visited %d entries, want 1", count)
+			}
+		})
+	}
+}
+
+func TestGroupRange(t *testing.T) {
+	sl := New[string, int]()
+	for k, v := range map[string]int{
+		"eu/de": 3, "eu/fr": 4, "us/ca": 10, "us/ny": 20, "us/tx": 30, "zz/xx": 100,
+	} {
+		sl.Insert(k, v)
+	}
+
+	sums := map[string]int{}
+	var order []string
+	GroupRange(sl, "eu/", "us/~", func(k string) string { return k[:2] }, func(g, _ string, v int) {
+		if len(order) == 0 || order[len(order)-1] != g {
+			order = append(order, g)
+		}
+		sums[g] += v
+	})
+	if want := map[string]int{"eu": 7, "us": 60}; !maps.Equal(sums, want) {
+		t.Errorf("group sums = %v, want %v", sums, want)
+	}
+	if want := []string{"eu", "us"}; !slices.Equal(order, want) {
+		t.Errorf("groups visited in order %v, want %v", order, want)
+	}
+
+	called := false
+	GroupRange(sl, "b", "a", func(k string) string { return k }, func(string, string, int) { called = true })
+	if called {
+		t.Error("GroupRange on an inverted range called agg")
+	}
+}
@@ -0,0 +1,288 @@
+package skiplist
+
+import (
+	"bufio"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// ProtobufCodec encodes snapshots as a stream of length-delimited protobuf
+// messages (the format of Java's writeDelimitedTo and of protodelim in Go),
+// one per entry in ascending key order, following this schema:
+//
+//	message Entry {
+//	  <key type>   key   = 1;
+//	  <value type> value = 2;
+//	}
+//
+// Go types map to protobuf scalar types as follows: bool → bool, signed
+// integers → int64, unsigned integers → uint64, float32 → float,
+// float64 → double, string → string and []byte → bytes. Any other type must
+// implement encoding.BinaryMarshaler (and encoding.BinaryUnmarshaler on its
+// pointer for Load) and is written as bytes; a generated protobuf message can
+// be stored this way by marshaling it as an embedded message.
+//
+// The stream carries no entry count, so an empty snapshot is an empty stream.
+//
+// ProtobufCodec เข้ารหัส snapshot เป็นลำดับของ protobuf message แบบมี length นำหน้า
+type ProtobufCodec[K any, V any] struct{}
+
+type protobufEntryEncoder[K any, V any] struct {
+	w   *bufio.Writer
+	msg []byte
+	buf []byte
+}
+
+type protobufEntryDecoder[K any, V any] struct {
+	r   *bufio.Reader
+	buf []byte
+}
+
+// NewEncoder implements Codec.
+func (ProtobufCodec[K, V]) NewEncoder(w io.Writer) EntryEncoder[K, V] {
+	return &protobufEntryEncoder[K, V]{w: bufio.NewWriter(w)}
+}
+
+// NewDecoder implements Codec.
+func (ProtobufCodec[K, V]) NewDecoder(r io.Reader) EntryDecoder[K, V] {
+	return &protobufEntryDecoder[K, V]{r: bufio.NewReader(r)}
+}
+
+func (e *protobufEntryEncoder[K, V]) WriteHeader(int) error { return nil }
+
+func (e *protobufEntryEncoder[K, V]) WriteEntry(key K, value V) error {
+	msg, err := appendProtobufField(e.msg[:0], 1, reflect.ValueOf(&key).Elem())
+	if err != nil {
+		return err
+	}
+	if msg, err = appendProtobufField(msg, 2, reflect.ValueOf(&value).Elem()); err != nil {
+		return err
+	}
+	e.msg = msg
+	e.buf = binary.AppendUvarint(e.buf[:0], uint64(len(msg)))
+	if _, err := e.w.Write(e.buf); err != nil {
+		return err
+	}
+	_, err = e.w.Write(msg)
+	return err
+}
+
+func (e *protobufEntryEncoder[K, V]) Close() error { return e.w.Flush() }
+
+func (d *protobufEntryDecoder[K, V]) ReadHeader() (int, error) { return -1, nil }
+
+func (d *protobufEntryDecoder[K, V]) ReadEntry() (key K, value V, err error) {
+	size, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return key, value, err
+	}
+	if cap(d.buf) < int(size) {
+		d.buf = make([]byte, size)
+	}
+	msg := d.buf[:size]
+	if _, err := io.ReadFull(d.r, msg); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return key, value, err
+	}
+
+	kv := reflect.ValueOf(&key).Elem()
+	vv := reflect.ValueOf(&value).Elem()
+	for len(msg) > 0 {
+		tag, n := binary.Uvarint(msg)
+		if n <= 0 {
+			return key, value, fmt.Errorf("skiplist: malformed protobuf entry")
+		}
+		msg = msg[n:]
+		num, wire := tag>>3, int(tag&7)
+		var field []byte
+		if field, msg, err = splitProtobufField(msg, wire); err != nil {
+			return key, value, err
+		}
+		switch num {
+		case 1:
+			err = decodeProtobufField(kv, wire, field)
+		case 2:
+			err = decodeProtobufField(vv, wire, field)
+		}
+		if err != nil {
+			return key, value, err
+		}
+	}
+	return key, value, nil
+}
+
+// protobuf wire types.
+const (
+	protobufVarint = 0
+	protobufI64    = 1
+	protobufLen    = 2
+	protobufI32    = 5
+)
+
+var (
+	binaryMarshalerType   = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+)
+
+// appendProtobufField appends v as field num to b. Zero scalars are omitted,
+// as in proto3.
+func appendProtobufField(b []byte, num int, v reflect.Value) ([]byte, error) {
+	tag := func(wire int) []byte { return binary.AppendUvarint(b, uint64(num)<<3|uint64(wire)) }
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if !v.Bool() {
+			return b, nil
+		}
+		return append(tag(protobufVarint), 1), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() == 0 {
+			return b, nil
+		}
+		return binary.AppendUvarint(tag(protobufVarint), uint64(v.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() == 0 {
+			return b, nil
+		}
+		return binary.AppendUvarint(tag(protobufVarint), v.Uint()), nil
+	case reflect.Float32:
+		if v.Float() == 0 && !math.Signbit(v.Float()) {
+			return b, nil
+		}
+		return binary.LittleEndian.AppendUint32(tag(protobufI32), math.Float32bits(float32(v.Float()))), nil
+	case reflect.Float64:
+		if v.Float() == 0 && !math.Signbit(v.Float()) {
+			return b, nil
+		}
+		return binary.LittleEndian.AppendUint64(tag(protobufI64), math.Float64bits(v.Float())), nil
+	case reflect.String:
+		if v.Len() == 0 {
+			return b, nil
+		}
+		b = binary.AppendUvarint(tag(protobufLen), uint64(v.Len()))
+		return append(b, v.String()...), nil
+	}
+	if v.Type().Implements(binaryMarshalerType) {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return b, nil
+		}
+		data, err := v.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		b = binary.AppendUvarint(tag(protobufLen), uint64(len(data)))
+		return append(b, data...), nil
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		if v.Len() == 0 {
+			return b, nil
+		}
+		b = binary.AppendUvarint(tag(protobufLen), uint64(v.Len()))
+		return append(b, v.Bytes()...), nil
+	}
+	return nil, fmt.Errorf("skiplist: protobuf cannot encode %s", v.Type())
+}
+
+// splitProtobufField splits the payload of a field with the given wire type
+// off the front of msg.
+func splitProtobufField(msg []byte, wire int) (field, rest []byte, err error) {
+	switch wire {
+	case protobufVarint:
+		_, n := binary.Uvarint(msg)
+		if n <= 0 {
+			break
+		}
+		return msg[:n], msg[n:], nil
+	case protobufI64:
+		if len(msg) < 8 {
+			break
+		}
+		return msg[:8], msg[8:], nil
+	case protobufI32:
+		if len(msg) < 4 {
+			break
+		}
+		return msg[:4], msg[4:], nil
+	case protobufLen:
+		size, n := binary.Uvarint(msg)
+		if n <= 0 || uint64(len(msg)-n) < size {
+			break
+		}
+		return msg[n : n+int(size)], msg[n+int(size):], nil
+	default:
+		return nil, nil, fmt.Errorf("skiplist: unsupported protobuf wire type %d", wire)
+	}
+	return nil, nil, fmt.Errorf("skiplist: truncated protobuf field")
+}
+
+// decodeProtobufField stores the payload of a field into v.
+func decodeProtobufField(v reflect.Value, wire int, field []byte) error {
+	if wire == protobufLen {
+		if v.Kind() == reflect.Pointer && v.Type().Implements(binaryUnmarshalerType) {
+			v.Set(reflect.New(v.Type().Elem()))
+			return v.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(field)
+		}
+		if u, ok := v.Addr().Interface().(encoding.BinaryUnmarshaler); ok {
+			return u.UnmarshalBinary(field)
+		}
+	}
+
+	mismatch := func() error {
+		return fmt.Errorf("skiplist: protobuf wire type %d cannot be decoded into %s", wire, v.Type())
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if wire != protobufVarint {
+			return mismatch()
+		}
+		u, _ := binary.Uvarint(field)
+		v.SetBool(u != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if wire != protobufVarint {
+			return mismatch()
+		}
+		u, _ := binary.Uvarint(field)
+		if v.OverflowInt(int64(u)) {
+			return fmt.Errorf("skiplist: protobuf value %d overflows %s", int64(u), v.Type())
+		}
+		v.SetInt(int64(u))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if wire != protobufVarint {
+			return mismatch()
+		}
+		u, _ := binary.Uvarint(field)
+		if v.OverflowUint(u) {
+			return fmt.Errorf("skiplist: protobuf value %d overflows %s", u, v.Type())
+		}
+		v.SetUint(u)
+	case reflect.Float32:
+		if wire != protobufI32 {
+			return mismatch()
+		}
+		v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(field))))
+	case reflect.Float64:
+		if wire != protobufI64 {
+			return mismatch()
+		}
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(field)))
+	case reflect.String:
+		if wire != protobufLen {
+			return mismatch()
+		}
+		v.SetString(string(field))
+	case reflect.Slice:
+		if wire != protobufLen || v.Type().Elem().Kind() != reflect.Uint8 {
+			return mismatch()
+		}
+		v.SetBytes(append([]byte(nil), field...))
+	default:
+		return mismatch()
+	}
+	return nil
+}
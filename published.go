@@ -0,0 +1,62 @@
+package skiplist
+
+// PublishedStats is the summary of a skiplist that WithPublishedStats
+// publishes at the end of each write: its length, smallest and largest keys
+// (zero values when Len is 0) and version, all from the same write.
+// PublishedStats คือสรุปสถานะของ skiplist ที่เผยแพร่หลังการเขียนแต่ละครั้ง
+type PublishedStats[K any] struct {
+	Len      int
+	Min, Max K
+	Version  uint64
+}
+
+// WithPublishedStats makes every write publish a PublishedStats through an
+// atomic pointer before releasing the write lock, so that Published reads
+// the length, bounds and version without taking any lock, e.g. for a
+// monitoring endpoint polled under heavy write load. Each write that changes
+// the list then pays one O(log n) descent to the largest key and one small
+// allocation.
+// WithPublishedStats เผยแพร่จำนวนรายการ key ต่ำสุด/สูงสุด และ version หลังการเขียนทุกครั้ง
+// เพื่อให้ Published อ่านได้โดยไม่ต้องใช้ lock
+func WithPublishedStats[K any, V any]() Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.mutex.onUnlock = sl.publish
+	}
+}
+
+// Published returns the length, smallest and largest keys and version of
+// the skiplist. With WithPublishedStats, it reads the values published by
+// the last write without taking any lock; otherwise it reads them under the
+// read lock. Either way the values are consistent with each other.
+// Published คืนค่าจำนวนรายการ key ต่ำสุด/สูงสุด และ version โดยไม่ใช้ lock เมื่อเปิดใช้ WithPublishedStats
+func (sl *SkipList[K, V]) Published() PublishedStats[K] {
+	if p := sl.published.Load(); p != nil {
+		return *p
+	}
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+	if sl.mutex.onUnlock != nil {
+		// Nothing has been written yet.
+		return PublishedStats[K]{Version: sl.version}
+	}
+	return sl.publishedStats()
+}
+
+// publish publishes the stats of the list if a write changed its version.
+// It is called with the write lock held, before it is released.
+func (sl *SkipList[K, V]) publish() {
+	if p := sl.published.Load(); p != nil && p.Version == sl.version {
+		return
+	}
+	st := sl.publishedStats()
+	sl.published.Store(&st)
+}
+
+// publishedStats returns the current PublishedStats. The caller must hold a lock.
+func (sl *SkipList[K, V]) publishedStats() PublishedStats[K] {
+	st := PublishedStats[K]{Len: sl.length, Version: sl.version}
+	if sl.length > 0 {
+		st.Min, st.Max = sl.header.forward[0].key, sl.last().key
+	}
+	return st
+}
@@ -0,0 +1,56 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPublished(t *testing.T) {
+	for _, opts := range [][]Option[int, int]{nil, {WithPublishedStats[int, int]()}} {
+		sl := New(opts...)
+		if st := sl.Published(); st.Len != 0 {
+			t.Errorf("Published() = %+v on an empty list", st)
+		}
+		for _, k := range []int{5, 1, 9} {
+			sl.Insert(k, k)
+		}
+		want := PublishedStats[int]{Len: 3, Min: 1, Max: 9, Version: sl.Version()}
+		if st := sl.Published(); st != want {
+			t.Errorf("Published() = %+v, want %+v", st, want)
+		}
+		sl.PopMax()
+		sl.Search(1) // reads publish nothing new
+		if st := sl.Published(); st.Len != 2 || st.Max != 5 || st.Version != sl.Version() {
+			t.Errorf("Published() = %+v after PopMax", st)
+		}
+		sl.Clear()
+		if st := sl.Published(); st.Len != 0 || st.Min != 0 || st.Max != 0 {
+			t.Errorf("Published() = %+v after Clear", st)
+		}
+	}
+}
+
+func TestPublishedConcurrent(t *testing.T) {
+	sl := New(WithPublishedStats[int, int]())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 2000; i++ {
+			sl.Insert(i, i)
+		}
+	}()
+	// Each published value comes from a single write: the keys are inserted
+	// in order, so Max always equals Len.
+	for last := 0; last < 2000; {
+		st := sl.Published()
+		if st.Len > 0 && (st.Min != 1 || st.Max != st.Len) {
+			t.Fatalf("inconsistent Published() = %+v", st)
+		}
+		if st.Len < last {
+			t.Fatalf("Published() went back from %d to %d entries", last, st.Len)
+		}
+		last = st.Len
+	}
+	wg.Wait()
+}
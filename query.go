@@ -0,0 +1,95 @@
+package skiplist
+
+// QuerySpec describes a range query run by Query: its bounds, the direction
+// of the walk and the window of results to report. The zero value queries
+// every entry in ascending order.
+// QuerySpec กำหนดขอบเขต ทิศทาง และจำนวนผลลัพธ์ของการค้นหาด้วย Query
+type QuerySpec[K any] struct {
+	// Start and End bound the keys of the range when HasStart and HasEnd are
+	// set; a missing bound leaves that side of the range open.
+	Start, End       K
+	HasStart, HasEnd bool
+	// ExcludeStart and ExcludeEnd make the bounds exclusive; they are
+	// inclusive by default, as in RangeQuery.
+	ExcludeStart, ExcludeEnd bool
+	// Reverse walks the range from its end down to its start.
+	Reverse bool
+	// Offset is the number of entries skipped in the walk direction before
+	// the first one reported, and Limit the maximum number of entries
+	// reported (<= 0 means no limit).
+	Offset, Limit int
+}
+
+// Query calls f for the entries selected by q, in ascending key order or in
+// descending order if q.Reverse is set, until f returns false, and returns
+// the number of entries visited. The first entry is found by rank
+// arithmetic, so q.Offset costs O(log n) rather than a walk over the skipped
+// entries. The whole query runs under a single read lock.
+// Query เรียก f สำหรับรายการที่ตรงกับ q ทั้งขอบเขต ทิศทาง offset และ limit ภายใต้ read lock ครั้งเดียว
+func (sl *SkipList[K, V]) Query(q QuerySpec[K], f func(key K, value V) bool) int {
+	defer rethrowCallbackPanic("Query")
+	tr := sl.traceStart(OpRangeQuery)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	var current *node[K, V]
+	if !q.Reverse {
+		lo := 0
+		if q.HasStart {
+			lo = sl.rank(q.Start, q.ExcludeStart)
+		}
+		if lo += q.Offset; lo >= sl.length {
+			return 0
+		}
+		current = sl.getByRank(lo)
+	} else {
+		hi := sl.length - 1
+		if q.HasEnd {
+			hi = sl.rank(q.End, !q.ExcludeEnd) - 1
+		}
+		if hi -= q.Offset; hi < 0 {
+			return 0
+		}
+		current = sl.getByRank(hi)
+	}
+
+	visited := 0
+	for current != nil && current != sl.header {
+		if (q.Limit > 0 && visited >= q.Limit) || !sl.inQuery(&q, current.key) {
+			break
+		}
+		visited++
+		if !f(current.key, current.value) {
+			break
+		}
+		if q.Reverse {
+			current = current.backward
+		} else {
+			current = current.forward[0]
+		}
+	}
+	tr.keys = visited
+	return visited
+}
+
+// inQuery reports whether key is within the bound of q that the walk moves
+// towards; the other bound is enforced by the starting rank.
+func (sl *SkipList[K, V]) inQuery(q *QuerySpec[K], key K) bool {
+	if q.Reverse {
+		if !q.HasStart {
+			return true
+		}
+		c := sl.compare(key, q.Start)
+		return c > 0 || (c == 0 && !q.ExcludeStart)
+	}
+	if !q.HasEnd {
+		return true
+	}
+	c := sl.compare(key, q.End)
+	return c < 0 || (c == 0 && !q.ExcludeEnd)
+}
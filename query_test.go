@@ -0,0 +1,60 @@
+package skiplist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSkipList_Query(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			for k := 10; k <= 100; k += 10 {
+				sl.Insert(k, k*2)
+			}
+
+			tests := []struct {
+				name string
+				q    QuerySpec[int]
+				want []int
+			}{
+				{"all", QuerySpec[int]{}, []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}},
+				{"inclusive", QuerySpec[int]{Start: 30, End: 60, HasStart: true, HasEnd: true}, []int{30, 40, 50, 60}},
+				{"exclusive", QuerySpec[int]{Start: 30, End: 60, HasStart: true, HasEnd: true, ExcludeStart: true, ExcludeEnd: true}, []int{40, 50}},
+				{"between keys", QuerySpec[int]{Start: 25, End: 65, HasStart: true, HasEnd: true, ExcludeStart: true, ExcludeEnd: true}, []int{30, 40, 50, 60}},
+				{"open end", QuerySpec[int]{Start: 75, HasStart: true}, []int{80, 90, 100}},
+				{"reverse", QuerySpec[int]{Start: 30, End: 60, HasStart: true, HasEnd: true, Reverse: true}, []int{60, 50, 40, 30}},
+				{"reverse exclusive", QuerySpec[int]{Start: 30, End: 60, HasStart: true, HasEnd: true, ExcludeStart: true, ExcludeEnd: true, Reverse: true}, []int{50, 40}},
+				{"reverse open start", QuerySpec[int]{End: 35, HasEnd: true, Reverse: true}, []int{30, 20, 10}},
+				{"offset and limit", QuerySpec[int]{Start: 20, HasStart: true, Offset: 2, Limit: 3}, []int{40, 50, 60}},
+				{"reverse offset and limit", QuerySpec[int]{Reverse: true, Offset: 1, Limit: 2}, []int{90, 80}},
+				{"offset past end bound", QuerySpec[int]{Start: 20, End: 40, HasStart: true, HasEnd: true, Offset: 3}, nil},
+				{"offset past list", QuerySpec[int]{Offset: 10}, nil},
+				{"empty range", QuerySpec[int]{Start: 60, End: 30, HasStart: true, HasEnd: true}, nil},
+				{"empty reverse range", QuerySpec[int]{Start: 60, End: 30, HasStart: true, HasEnd: true, Reverse: true}, nil},
+			}
+			for _, tt := range tests {
+				var got []int
+				n := sl.Query(tt.q, func(k, v int) bool {
+					if v != k*2 {
+						t.Errorf("%s: value of %d = %d", tt.name, k, v)
+					}
+					got = append(got, k)
+					return true
+				})
+				if !slices.Equal(got, tt.want) || n != len(tt.want) {
+					t.Errorf("%s: got %v (%d), want %v", tt.name, got, n, tt.want)
+				}
+			}
+
+			var got []int
+			sl.Query(QuerySpec[int]{Reverse: true}, func(k, _ int) bool {
+				got = append(got, k)
+				return len(got) < 2
+			})
+			if !slices.Equal(got, []int{100, 90}) {
+				t.Errorf("Query stopped by f: got %v", got)
+			}
+		})
+	}
+}
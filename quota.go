@@ -0,0 +1,50 @@
+package skiplist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrQuotaExceeded is wrapped by the *QuotaError returned when a new key is
+// inserted in a Namespace that is full.
+var ErrQuotaExceeded = errors.New("skiplist: namespace quota exceeded")
+
+// QuotaError is the error returned by Namespace.TryInsert, and raised as a
+// panic by Namespace.Insert, when a new key would take a namespace over its
+// quota. errors.Is reports it as ErrQuotaExceeded.
+// QuotaError คือ error เมื่อการเพิ่ม key ใหม่จะทำให้ namespace เกินโควตา
+type QuotaError struct {
+	// Quota is the quota of the namespace.
+	Quota int
+	// Len is the number of entries of the namespace.
+	Len int
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("skiplist: namespace quota of %d entries exceeded (%d entries)", e.Quota, e.Len)
+}
+
+// Unwrap returns ErrQuotaExceeded.
+func (e *QuotaError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// SetQuota limits the namespace to n entries, or removes the limit if n is
+// 0 or less. Once the namespace holds n entries, inserting a new key
+// fails with a *QuotaError, so that one tenant cannot grow a shared list
+// without bound; updates and deletes are still allowed. Lowering the quota
+// below Len removes no entry.
+// SetQuota กำหนดจำนวนรายการสูงสุดของ namespace (0 = ไม่จำกัด)
+func (ns *Namespace[K, V]) SetQuota(n int) {
+	ns.sl.mutex.Lock()
+	defer ns.sl.mutex.Unlock()
+	ns.quota = max(n, 0)
+}
+
+// Quota returns the quota set with SetQuota, 0 if there is none.
+// Quota คืนค่าโควตาของ namespace
+func (ns *Namespace[K, V]) Quota() int {
+	ns.sl.mutex.RLock()
+	defer ns.sl.mutex.RUnlock()
+	return ns.quota
+}
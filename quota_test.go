@@ -0,0 +1,66 @@
+package skiplist
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNamespaceQuota(t *testing.T) {
+	sl := NewWithComparator[userEvent, string](compareUserEvent)
+	p := NewPrefixScanner[userEvent, string, int](sl, userBounds)
+	a, b := p.Namespace(1), p.Namespace(2)
+	a.SetQuota(3)
+	for ts := int64(1); ts <= 3; ts++ {
+		if _, err := a.TryInsert(userEvent{1, ts}, "a"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := a.TryInsert(userEvent{1, 4}, "a")
+	var qe *QuotaError
+	if !errors.As(err, &qe) || !errors.Is(err, ErrQuotaExceeded) || qe.Quota != 3 || qe.Len != 3 {
+		t.Fatalf("TryInsert over quota = %v", err)
+	}
+	if _, ok := sl.Search(userEvent{1, 4}); ok || a.Len() != 3 {
+		t.Errorf("rejected insert changed the list: Len() = %d", a.Len())
+	}
+	// Updates are allowed, and other tenants are not limited.
+	if n, err := a.TryInsert(userEvent{1, 2}, "updated"); err != nil || n == nil {
+		t.Errorf("update at quota = %v, %v", n, err)
+	}
+	for ts := int64(1); ts <= 10; ts++ {
+		b.Insert(userEvent{2, ts}, "b")
+	}
+	if b.Len() != 10 || b.Quota() != 0 {
+		t.Errorf("unlimited namespace: Len() = %d, Quota() = %d", b.Len(), b.Quota())
+	}
+
+	// A delete makes room again.
+	if !a.Delete(userEvent{1, 1}) {
+		t.Fatal("Delete failed")
+	}
+	if _, err := a.TryInsert(userEvent{1, 4}, "a"); err != nil {
+		t.Errorf("TryInsert after Delete = %v", err)
+	}
+	func() {
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, ErrQuotaExceeded) {
+				t.Errorf("Insert over quota panicked with %v", err)
+			}
+		}()
+		a.Insert(userEvent{1, 5}, "a")
+	}()
+
+	// Writes through the list are only counted by Recount.
+	sl.Insert(userEvent{1, 9}, "bypass")
+	if a.Len() != 3 || a.Recount() != 4 || a.Len() != 4 {
+		t.Errorf("Recount: Len() = %d", a.Len())
+	}
+	a.SetQuota(0)
+	if _, err := a.TryInsert(userEvent{1, 5}, "a"); err != nil || a.Len() != 5 {
+		t.Errorf("TryInsert without quota = %v, Len() = %d", err, a.Len())
+	}
+	if a.Clear() != 5 || a.Len() != 0 || b.Len() != 10 {
+		t.Errorf("after Clear, Len() = %d, %d", a.Len(), b.Len())
+	}
+}
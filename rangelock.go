@@ -0,0 +1,80 @@
+package skiplist
+
+// RangeLock is a lock on the keys of an interval, obtained from LockRange.
+// RangeLock คือ lock ของช่วง key ที่ได้จาก LockRange
+type RangeLock[K any] struct {
+	table      *rangeLockTable[K]
+	start, end K
+	released   bool
+}
+
+// LockRange locks the keys from start to end (inclusive) and returns the
+// lock, blocking while a lock held on an overlapping interval is not
+// released. Writers that lock disjoint ranges then run their
+// read-modify-write sequences (e.g. a Search followed by an Insert, or a
+// DeleteRange following a scan) concurrently, while those touching a common
+// key are serialized, without holding the lock of the list between their
+// steps.
+//
+// Range locks are advisory and only exclude each other: the methods of the
+// list do not take them. Each step still takes the lock of the list for its
+// own duration, because the links and spans of the upper levels are shared
+// by all the keys below them; range locks make the list lock short-lived
+// rather than per-range. A goroutine must not lock overlapping ranges
+// twice, which deadlocks. LockRange panics if start is after end.
+// LockRange ล็อกช่วง key ตั้งแต่ start ถึง end เพื่อให้ writer ที่ทำงานกับช่วงที่ไม่ทับซ้อนกันทำงานพร้อมกันได้
+func (sl *SkipList[K, V]) LockRange(start, end K) *RangeLock[K] {
+	if sl.compare(start, end) > 0 {
+		panic("skiplist: LockRange start is after end")
+	}
+	t := &sl.rangeLocks
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cond == nil {
+		t.cond = newSyncCond(&t.mu)
+	}
+	for t.overlaps(sl.compare, start, end) {
+		t.cond.Wait()
+	}
+	l := &RangeLock[K]{table: t, start: start, end: end}
+	t.held = append(t.held, l)
+	return l
+}
+
+// Unlock releases the range lock. Unlock is a no-op on a released lock.
+// Unlock ปล่อย lock ของช่วง key
+func (l *RangeLock[K]) Unlock() {
+	t := l.table
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if l.released {
+		return
+	}
+	l.released = true
+	for i, h := range t.held {
+		if h == l {
+			t.held = append(t.held[:i], t.held[i+1:]...)
+			break
+		}
+	}
+	t.cond.Broadcast()
+}
+
+// rangeLockTable holds the range locks of a skiplist. Its zero value has no
+// lock held.
+type rangeLockTable[K any] struct {
+	mu   syncMutex
+	cond *syncCond // created by the first LockRange
+	held []*RangeLock[K]
+}
+
+// overlaps reports whether a held lock overlaps [start, end]. The caller must
+// hold t.mu.
+func (t *rangeLockTable[K]) overlaps(compare Comparator[K], start, end K) bool {
+	for _, h := range t.held {
+		if compare(start, h.end) <= 0 && compare(h.start, end) <= 0 {
+			return true
+		}
+	}
+	return false
+}
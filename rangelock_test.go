@@ -0,0 +1,74 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockRange(t *testing.T) {
+	skipWithoutSync(t)
+	sl := New[int, int]()
+
+	a := sl.LockRange(0, 10)
+	// A disjoint range is not blocked.
+	b := sl.LockRange(11, 20)
+
+	acquired := make(chan struct{})
+	go func() {
+		l := sl.LockRange(5, 15)
+		close(acquired)
+		l.Unlock()
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("overlapping range acquired while held")
+	case <-time.After(20 * time.Millisecond):
+	}
+	a.Unlock()
+	select {
+	case <-acquired:
+		t.Fatal("overlapping range acquired while [11, 20] is held")
+	case <-time.After(20 * time.Millisecond):
+	}
+	b.Unlock()
+	b.Unlock() // no-op
+	<-acquired
+
+	defer func() {
+		if recover() == nil {
+			t.Error("LockRange(2, 1) did not panic")
+		}
+	}()
+	sl.LockRange(2, 1)
+}
+
+func TestLockRangeReadModifyWrite(t *testing.T) {
+	skipWithoutSync(t)
+	sl := New[int, int]()
+	const workers, rounds = 8, 200
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				// Two workers share each counter key.
+				key := w / 2
+				l := sl.LockRange(key, key)
+				v := 0
+				if n, ok := sl.Search(key); ok {
+					v = n.Value()
+				}
+				sl.Insert(key, v+1)
+				l.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+	for key := 0; key < workers/2; key++ {
+		if n, ok := sl.Search(key); !ok || n.Value() != 2*rounds {
+			t.Errorf("counter %d = %v, want %d", key, n, 2*rounds)
+		}
+	}
+}
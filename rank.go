@@ -0,0 +1,327 @@
+package skiplist
+
+import (
+	"cmp"
+	"slices"
+)
+
+// rank returns the number of elements with keys strictly smaller than key, or
+// smaller than or equal to key when inclusive is true.
+// The caller must hold a lock.
+func (sl *SkipList[K, V]) rank(key K, inclusive bool) int {
+	if sl.noRanks {
+		return sl.walkRank(key, inclusive)
+	}
+	rank := 0
+	current := sl.header
+	kp := sl.prefixOf(key)
+
+	for i := sl.level; i >= 0; i-- {
+		for current.forward[i] != nil {
+			c := sl.compareNode(current.forward[i], key, kp)
+			if c > 0 || (c == 0 && !inclusive) {
+				break
+			}
+			rank += current.span[i]
+			current = current.forward[i]
+		}
+	}
+	return rank
+}
+
+// RankLT returns the number of elements with keys strictly smaller than key.
+// It is equivalent to Rank, but its name states the semantics explicitly.
+// RankLT คืนค่าจำนวนรายการที่มี key น้อยกว่า key ที่กำหนด (ไม่รวมตัวมันเอง)
+func (sl *SkipList[K, V]) RankLT(key K) int {
+	sl.mustRanks()
+	tr := sl.traceStart(OpRank)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	return sl.rank(key, false)
+}
+
+// RankLE returns the number of elements with keys smaller than or equal to key.
+// When key is present, RankLE(key) == RankLT(key)+1; otherwise the two are equal.
+// RankLE คืนค่าจำนวนรายการที่มี key น้อยกว่าหรือเท่ากับ key ที่กำหนด
+func (sl *SkipList[K, V]) RankLE(key K) int {
+	sl.mustRanks()
+	tr := sl.traceStart(OpRank)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	return sl.rank(key, true)
+}
+
+// CountLessThan returns the number of elements with keys strictly smaller than key.
+// It is an alias of RankLT.
+// CountLessThan คืนค่าจำนวนรายการที่มี key น้อยกว่า key ที่กำหนด
+func (sl *SkipList[K, V]) CountLessThan(key K) int {
+	return sl.RankLT(key)
+}
+
+// CountGreaterThan returns the number of elements with keys strictly greater than key.
+// It is an alias of RevRank.
+// CountGreaterThan คืนค่าจำนวนรายการที่มี key มากกว่า key ที่กำหนด
+func (sl *SkipList[K, V]) CountGreaterThan(key K) int {
+	return sl.RevRank(key)
+}
+
+// RevRank returns the 0-based rank of key counted from the largest key, as
+// on a leaderboard: the largest key has reverse rank 0. It is the number of
+// elements with keys strictly greater than key, i.e. Len()-1-Rank(key) for a
+// key in the list; for a key not in the list, it is the reverse rank the key
+// would have if it were inserted. The complexity is O(log n).
+// RevRank คืนค่าอันดับ (0-based) ของ key โดยนับจาก key ที่มากที่สุด
+func (sl *SkipList[K, V]) RevRank(key K) int {
+	sl.mustRanks()
+	tr := sl.traceStart(OpRank)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	return sl.length - sl.rank(key, true)
+}
+
+// GetByRevRank returns the node at the given 0-based reverse rank, reverse
+// rank 0 being the largest key. If the rank is out of bounds, it returns nil
+// and false. The complexity is O(log n).
+// GetByRevRank คืนค่าโหนด ณ อันดับที่กำหนดโดยนับจาก key ที่มากที่สุด (0-based)
+func (sl *SkipList[K, V]) GetByRevRank(rank int) (INode[K, V], bool) {
+	sl.mustRanks()
+	tr := sl.traceStart(OpGetByRank)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	if rank < 0 || rank >= sl.length {
+		return nil, false
+	}
+	tr.keys = 1
+	return sl.getByRank(sl.length - 1 - rank), true
+}
+
+// KthInRange returns the k-th smallest (0-based) element whose key lies between
+// start and end (inclusive). It uses rank arithmetic, so the window is never walked
+// and the complexity is O(log n) regardless of the window size; on a list created
+// with WithoutRankTracking the ranks are counted on the bottom level in O(n).
+// It returns nil and false if the range is invalid or holds k or fewer elements.
+// KthInRange คืนค่ารายการลำดับที่ k (0-based) ในช่วง key ตั้งแต่ start ถึง end (รวมทั้งสองค่า)
+// มีความซับซ้อน O(log n) โดยไม่ต้องวนลูปผ่านช่วงข้อมูล (O(n) เมื่อใช้ WithoutRankTracking)
+func (sl *SkipList[K, V]) KthInRange(start, end K, k int) (INode[K, V], bool) {
+	tr := sl.traceStart(OpKthInRange)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	if k < 0 || sl.compare(start, end) > 0 {
+		return nil, false
+	}
+	lo := sl.rank(start, false)
+	hi := sl.rank(end, true)
+	if lo+k >= hi {
+		return nil, false
+	}
+	tr.keys = 1
+	return sl.getByRank(lo + k), true
+}
+
+// Histogram returns the number of elements falling between consecutive bucket
+// boundaries. The result has len(buckets)-1 entries, where entry i counts the keys
+// in the half-open interval [buckets[i], buckets[i+1]). Counts are derived from rank
+// subtraction, so the complexity is O(b log n) for b boundaries, or O(b n) on a
+// list created with WithoutRankTracking.
+// The boundaries must be sorted in ascending order; Histogram panics otherwise.
+// It returns nil if fewer than two boundaries are given.
+// Histogram คืนค่าจำนวนรายการในแต่ละช่วง [buckets[i], buckets[i+1])
+// โดยใช้การลบกันของ rank มีความซับซ้อน O(b log n)
+func (sl *SkipList[K, V]) Histogram(buckets []K) []int {
+	if len(buckets) < 2 {
+		return nil
+	}
+	for i := 1; i < len(buckets); i++ {
+		if sl.compare(buckets[i-1], buckets[i]) > 0 {
+			panic("skiplist: histogram buckets must be sorted in ascending order")
+		}
+	}
+
+	tr := sl.traceStart(OpHistogram)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	counts := make([]int, len(buckets)-1)
+	prev := sl.rank(buckets[0], false)
+	for i := 1; i < len(buckets); i++ {
+		r := sl.rank(buckets[i], false)
+		counts[i-1] = r - prev
+		tr.keys += counts[i-1]
+		prev = r
+	}
+	return counts
+}
+
+// KeySummary is an ordered statistics summary of the keys in a skiplist.
+type KeySummary[K any] struct {
+	Count  int
+	Min    K
+	Max    K
+	Median K // the lower median when Count is even
+}
+
+// Summary returns the number of elements together with the minimum, maximum and
+// median keys, all read under a single read lock so that the values are consistent
+// with each other. It returns false if the skiplist is empty.
+// Summary คืนค่าจำนวนรายการ พร้อม key ที่น้อยที่สุด มากที่สุด และค่ามัธยฐาน
+// โดยอ่านภายใต้ read lock ครั้งเดียว คืนค่า false หาก skiplist ว่างเปล่า
+func (sl *SkipList[K, V]) Summary() (KeySummary[K], bool) {
+	tr := sl.traceStart(OpSummary)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	if sl.length == 0 {
+		return KeySummary[K]{}, false
+	}
+	tr.keys = 3
+	return KeySummary[K]{
+		Count:  sl.length,
+		Min:    sl.header.forward[0].key,
+		Max:    sl.getByRank(sl.length - 1).key,
+		Median: sl.getByRank((sl.length - 1) / 2).key,
+	}, true
+}
+
+// GetByRanks returns the nodes at the given 0-based ranks, result i being the
+// node at ranks[i], or nil if ranks[i] is out of bounds. The ranks are
+// resolved in ascending order in one left-to-right walk under a single read
+// lock: each descent resumes from the nodes where the previous one stopped,
+// so a page of k discontiguous positions costs far less than k calls to
+// GetByRank.
+// GetByRanks คืนค่าโหนด ณ อันดับที่กำหนดหลายอันดับด้วยการไล่จากซ้ายไปขวาเพียงรอบเดียว
+func (sl *SkipList[K, V]) GetByRanks(ranks []int) []INode[K, V] {
+	sl.mustRanks()
+	tr := sl.traceStart(OpGetByRank)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	out := make([]INode[K, V], len(ranks))
+	order := make([]int, len(ranks))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int { return cmp.Compare(ranks[a], ranks[b]) })
+
+	// path[i] is the last node reached at level i and pos[i] its rank; the
+	// header is at rank -1.
+	var path [MaxLevel]*node[K, V]
+	var pos [MaxLevel]int
+	for i := range path {
+		path[i], pos[i] = sl.header, -1
+	}
+	for _, idx := range order {
+		rank := ranks[idx]
+		if rank < 0 || rank >= sl.length {
+			continue
+		}
+		current, traversed := sl.header, -1
+		for i := sl.level; i >= 0; i-- {
+			if pos[i] > traversed {
+				current, traversed = path[i], pos[i]
+			}
+			for current.forward[i] != nil && traversed+current.span[i] <= rank {
+				traversed += current.span[i]
+				current = current.forward[i]
+			}
+			path[i], pos[i] = current, traversed
+		}
+		out[idx] = current
+		tr.keys++
+	}
+	return out
+}
+
+// Move re-keys the entry of from to to under a single write lock, keeping
+// its value, e.g. to record a new score on a leaderboard keyed by score. It
+// reports whether from was present. OnDelete and OnInsert see the move as an
+// insert of to and a delete of from, while OnRankChange reports it once, as
+// (to, rank of from, rank of to). An entry already present at to is replaced
+// and reported as deleted, to OnDelete and as (to, its rank, -1) to
+// OnRankChange, before the moved entry is reported. The entry is written at
+// to before from is unlinked, and a move never evicts (see WithCapacity) since
+// it does not grow the list.
+// It panics, leaving the list unchanged, if to cannot be written: the list is
+// frozen, the key validator rejects to, the entry exceeds the byte limit or a
+// fixed arena is full.
+// Move ย้ายรายการจาก key from ไปยัง key to โดยคงค่า value ไว้ และแจ้ง OnRankChange ครั้งเดียว
+func (sl *SkipList[K, V]) Move(from, to K) bool {
+	tr := sl.traceStart(OpMove)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	n := sl.findGreaterOrEqual(from)
+	if n == nil || sl.compare(n.key, from) != 0 {
+		return false
+	}
+	if err := sl.checkKey(to); err != nil {
+		panic(err)
+	}
+	tr.keys = 2
+	if sl.compare(from, to) == 0 {
+		return true
+	}
+	oldRank, value := -1, n.value
+	if sl.hooks.OnRankChange != nil {
+		oldRank = sl.rank(from, false)
+	}
+	var old V
+	replacedRank := -1
+	m := sl.findGreaterOrEqual(to)
+	replaced := m != nil && sl.compare(m.key, to) == 0
+	if replaced {
+		old = m.value
+		if sl.hooks.OnRankChange != nil {
+			replacedRank = sl.rank(to, false)
+		}
+	}
+	func() {
+		capacity := sl.capacity
+		sl.moving, sl.capacity = true, 0
+		defer func() { sl.moving, sl.capacity = false, capacity }()
+		if _, _, err := sl.tryInsert(to, value); err != nil {
+			panic(err)
+		}
+		if replaced {
+			// The update of to was not reported to OnUpdate: it is the
+			// removal of the replaced entry followed by the insert of the
+			// moved one.
+			if sl.hooks.OnDelete != nil {
+				sl.hooks.onDelete(to, old)
+			}
+			if sl.hooks.OnRankChange != nil {
+				sl.hooks.onRankChange(to, replacedRank, -1)
+			}
+			if sl.hooks.OnInsert != nil {
+				sl.hooks.onInsert(to, value)
+			}
+		}
+		sl.delete(from)
+	}()
+	if sl.hooks.OnRankChange != nil {
+		sl.hooks.onRankChange(to, oldRank, sl.rank(to, false))
+	}
+	return true
+}
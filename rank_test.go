@@ -0,0 +1,322 @@
+package skiplist
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSkipList_RankVariants(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+
+			if sl.RankLT(1) != 0 || sl.RankLE(1) != 0 || sl.CountGreaterThan(1) != 0 {
+				t.Fatal("rank helpers on an empty list should return 0")
+			}
+
+			for _, k := range []int{10, 20, 30, 40, 50} {
+				sl.Insert(k, "v")
+			}
+
+			tests := []struct {
+				key              int
+				lt, le, lessThan int
+				greaterThan      int
+			}{
+				{5, 0, 0, 0, 5},
+				{10, 0, 1, 0, 4},
+				{25, 2, 2, 2, 3},
+				{30, 2, 3, 2, 2},
+				{50, 4, 5, 4, 0},
+				{55, 5, 5, 5, 0},
+			}
+			for _, tt := range tests {
+				if got := sl.RankLT(tt.key); got != tt.lt {
+					t.Errorf("RankLT(%d) = %d, want %d", tt.key, got, tt.lt)
+				}
+				if got := sl.RankLE(tt.key); got != tt.le {
+					t.Errorf("RankLE(%d) = %d, want %d", tt.key, got, tt.le)
+				}
+				if got := sl.CountLessThan(tt.key); got != tt.lessThan {
+					t.Errorf("CountLessThan(%d) = %d, want %d", tt.key, got, tt.lessThan)
+				}
+				if got := sl.CountGreaterThan(tt.key); got != tt.greaterThan {
+					t.Errorf("CountGreaterThan(%d) = %d, want %d", tt.key, got, tt.greaterThan)
+				}
+				if got := sl.Rank(tt.key); got != tt.lt {
+					t.Errorf("Rank(%d) = %d, want %d", tt.key, got, tt.lt)
+				}
+			}
+		})
+	}
+}
+
+func TestSkipList_RevRank(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			if _, ok := sl.GetByRevRank(0); ok || sl.RevRank(1) != 0 {
+				t.Fatal("reverse rank helpers on an empty list should find nothing")
+			}
+			for _, k := range []int{10, 20, 30, 40, 50} {
+				sl.Insert(k, "v")
+			}
+
+			for key, want := range map[int]int{50: 0, 40: 1, 10: 4, 55: 0, 45: 1, 5: 5} {
+				if got := sl.RevRank(key); got != want {
+					t.Errorf("RevRank(%d) = %d, want %d", key, got, want)
+				}
+			}
+			for r := 0; r < sl.Len(); r++ {
+				n, ok := sl.GetByRevRank(r)
+				if !ok || n.Key() != 50-10*r || sl.RevRank(n.Key()) != r {
+					t.Errorf("GetByRevRank(%d) = %v, %v", r, n, ok)
+				}
+			}
+			for _, r := range []int{-1, 5} {
+				if n, ok := sl.GetByRevRank(r); ok || n != nil {
+					t.Errorf("GetByRevRank(%d) = %v, %v, want out of bounds", r, n, ok)
+				}
+			}
+		})
+	}
+}
+
+func TestSkipList_KthInRange(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			if _, ok := sl.KthInRange(0, 100, 0); ok {
+				t.Fatal("KthInRange on an empty list should return false")
+			}
+
+			for k := 10; k <= 100; k += 10 {
+				sl.Insert(k, "v")
+			}
+
+			tests := []struct {
+				start, end, k int
+				want          int
+				ok            bool
+			}{
+				{10, 100, 0, 10, true},
+				{10, 100, 9, 100, true},
+				{10, 100, 10, 0, false},
+				{15, 55, 0, 20, true},
+				{15, 55, 2, 40, true},
+				{15, 55, 3, 50, true},
+				{15, 55, 4, 0, false},
+				{30, 30, 0, 30, true},
+				{31, 39, 0, 0, false},
+				{50, 10, 0, 0, false},
+				{10, 100, -1, 0, false},
+			}
+			for _, tt := range tests {
+				node, ok := sl.KthInRange(tt.start, tt.end, tt.k)
+				if ok != tt.ok {
+					t.Errorf("KthInRange(%d, %d, %d) ok = %v, want %v", tt.start, tt.end, tt.k, ok, tt.ok)
+					continue
+				}
+				if ok && node.Key() != tt.want {
+					t.Errorf("KthInRange(%d, %d, %d) = %d, want %d", tt.start, tt.end, tt.k, node.Key(), tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSkipList_Histogram(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			for k := 0; k < 100; k++ {
+				sl.Insert(k, "v")
+			}
+
+			got := sl.Histogram([]int{-10, 0, 10, 50, 50, 99, 1000})
+			want := []int{0, 10, 40, 0, 49, 1}
+			if len(got) != len(want) {
+				t.Fatalf("Histogram returned %d buckets, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("bucket %d: got %d, want %d", i, got[i], want[i])
+				}
+			}
+
+			if h := sl.Histogram([]int{1}); h != nil {
+				t.Errorf("Histogram with one boundary should return nil, got %v", h)
+			}
+
+			defer func() {
+				if recover() == nil {
+					t.Error("Histogram with unsorted buckets should panic")
+				}
+			}()
+			sl.Histogram([]int{10, 5})
+		})
+	}
+}
+
+func TestSkipList_Summary(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			if _, ok := sl.Summary(); ok {
+				t.Fatal("Summary on an empty list should return false")
+			}
+
+			for _, k := range []int{50, 10, 40, 20, 30} {
+				sl.Insert(k, "v")
+			}
+			s, ok := sl.Summary()
+			if !ok {
+				t.Fatal("Summary returned false for a non-empty list")
+			}
+			if want := (KeySummary[int]{Count: 5, Min: 10, Max: 50, Median: 30}); s != want {
+				t.Errorf("Summary() = %+v, want %+v", s, want)
+			}
+
+			sl.Insert(60, "v")
+			s, _ = sl.Summary()
+			if want := (KeySummary[int]{Count: 6, Min: 10, Max: 60, Median: 30}); s != want {
+				t.Errorf("Summary() with even count = %+v, want %+v", s, want)
+			}
+		})
+	}
+}
+
+func TestCheckSpans(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			if err := sl.CheckSpans(); err != nil {
+				t.Fatalf("CheckSpans on an empty list: %v", err)
+			}
+			for i := 0; i < 100; i++ {
+				sl.Insert(i, i)
+			}
+			if err := sl.CheckSpans(); err != nil {
+				t.Fatalf("CheckSpans on a healthy list: %v", err)
+			}
+			sl.header.span[0]++
+			if err := sl.CheckSpans(); !errors.Is(err, ErrCorrupt) {
+				t.Errorf("CheckSpans with a broken span returned %v, want ErrCorrupt", err)
+			}
+		})
+	}
+}
+
+// TestRankInvariants_Randomized grows and shrinks the list through every
+// mutating operation, so that levels are repeatedly added and removed, and
+// checks the spans and the rank operations against a sorted model.
+func TestRankInvariants_Randomized(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(1, 2))
+			sl := setup.constructor(nil)
+			var model []int
+
+			check := func(step int) {
+				t.Helper()
+				if err := sl.CheckSpans(); err != nil {
+					t.Fatalf("step %d: %v", step, err)
+				}
+				if sl.Len() != len(model) {
+					t.Fatalf("step %d: Len() = %d, want %d", step, sl.Len(), len(model))
+				}
+				for i := 0; i < 5 && len(model) > 0; i++ {
+					rank := r.IntN(len(model))
+					if n, ok := sl.GetByRank(rank); !ok || n.Key() != model[rank] {
+						t.Fatalf("step %d: GetByRank(%d) = %v, %v, want %d", step, rank, n, ok, model[rank])
+					}
+					if got := sl.Rank(model[rank]); got != rank {
+						t.Fatalf("step %d: Rank(%d) = %d, want %d", step, model[rank], got, rank)
+					}
+				}
+			}
+
+			for step := 0; step < 4000; step++ {
+				// Alternate between growing phases, where inserts dominate,
+				// and shrinking phases that drain the list.
+				insertPct := 70
+				if (step/500)%2 == 1 {
+					insertPct = 15
+				}
+				switch op := r.IntN(100); {
+				case op < insertPct:
+					k := r.IntN(2000)
+					sl.Insert(k, k)
+					if i, found := slices.BinarySearch(model, k); !found {
+						model = slices.Insert(model, i, k)
+					}
+				case op%3 == 0:
+					if _, ok := sl.PopMin(); ok {
+						model = model[1:]
+					}
+				case op%3 == 1:
+					if _, ok := sl.PopMax(); ok {
+						model = model[:len(model)-1]
+					}
+				default:
+					k := r.IntN(2000)
+					if len(model) > 0 {
+						k = model[r.IntN(len(model))]
+					}
+					sl.Delete(k)
+					if i, found := slices.BinarySearch(model, k); found {
+						model = slices.Delete(model, i, i+1)
+					}
+				}
+				check(step)
+				if len(model) == 0 && sl.level != 0 {
+					t.Fatalf("step %d: empty list kept level %d", step, sl.level)
+				}
+			}
+		})
+	}
+}
+
+func TestSkipList_GetByRanks(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			if got := sl.GetByRanks([]int{0, 1}); len(got) != 2 || got[0] != nil || got[1] != nil {
+				t.Fatalf("GetByRanks on an empty list = %v, want two nils", got)
+			}
+
+			const n = 500
+			for _, k := range rand.Perm(n) {
+				sl.Insert(k, "v")
+			}
+			ranks := []int{499, 3, -1, 250, 3, 0, n, 17, 498, 251}
+			got := sl.GetByRanks(ranks)
+			if len(got) != len(ranks) {
+				t.Fatalf("GetByRanks returned %d nodes, want %d", len(got), len(ranks))
+			}
+			for i, r := range ranks {
+				if r < 0 || r >= n {
+					if got[i] != nil {
+						t.Errorf("rank %d: got key %d, want nil", r, got[i].Key())
+					}
+					continue
+				}
+				if got[i] == nil || got[i].Key() != r {
+					t.Errorf("rank %d: got %v, want key %d", r, got[i], r)
+				}
+			}
+
+			all := make([]int, n)
+			for i := range all {
+				all[i] = n - 1 - i
+			}
+			for i, nd := range sl.GetByRanks(all) {
+				if nd.Key() != all[i] {
+					t.Fatalf("rank %d: got key %d", all[i], nd.Key())
+				}
+			}
+		})
+	}
+}
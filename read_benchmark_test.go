@@ -0,0 +1,169 @@
+package skiplist
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// The benchmarks below cover the read patterns that depend on the backward
+// pointers and the spans of the list: reverse iteration, Seek-heavy access
+// and rank queries, under both allocators.
+
+// newReadBenchmarkList returns a list filled with benchmarkSize random keys,
+// along with the keys in insertion order.
+func newReadBenchmarkList(setup testSetup[int, int]) (*SkipList[int, int], []int) {
+	sl := setup.constructor(nil)
+	keys := generateRandomKeys(benchmarkSize)
+	for _, key := range keys {
+		sl.Insert(key, key)
+	}
+	return sl, keys
+}
+
+// BenchmarkSkipList_Iterator_Reverse measures a full walk with an iterator
+// created with WithReverse, which locks on each move.
+func BenchmarkSkipList_Iterator_Reverse(b *testing.B) {
+	for _, setup := range getTestSetups[int, int]() {
+		b.Run(setup.name, func(b *testing.B) {
+			sl, _ := newReadBenchmarkList(setup)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				it := sl.NewIterator(WithReverse[int, int]())
+				for it.Next() {
+					_ = it.Key()
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkSkipList_Iterator_Prev measures a full backward walk with Prev
+// from the last element.
+func BenchmarkSkipList_Iterator_Prev(b *testing.B) {
+	for _, setup := range getTestSetups[int, int]() {
+		b.Run(setup.name, func(b *testing.B) {
+			sl, _ := newReadBenchmarkList(setup)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				it := sl.NewIterator()
+				for ok := it.Last(); ok; ok = it.Prev() {
+					_ = it.Key()
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkSkipList_RangeWithIterator_Prev measures a full backward walk
+// under the single read lock of RangeWithIterator.
+func BenchmarkSkipList_RangeWithIterator_Prev(b *testing.B) {
+	for _, setup := range getTestSetups[int, int]() {
+		b.Run(setup.name, func(b *testing.B) {
+			sl, _ := newReadBenchmarkList(setup)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				sl.RangeWithIterator(func(it *Iterator[int, int]) {
+					for ok := it.SeekToLast(); ok; ok = it.Prev() {
+						_ = it.Key()
+					}
+				})
+			}
+		})
+	}
+}
+
+// BenchmarkSkipList_Iterator_Seek measures repositioning one iterator at
+// random keys, as an index lookup driven by an iterator does.
+func BenchmarkSkipList_Iterator_Seek(b *testing.B) {
+	for _, setup := range getTestSetups[int, int]() {
+		b.Run(setup.name, func(b *testing.B) {
+			sl, keys := newReadBenchmarkList(setup)
+			it := sl.NewIterator()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				it.Seek(keys[i%len(keys)])
+			}
+		})
+	}
+}
+
+// BenchmarkSkipList_Iterator_SeekScan measures short scans of 16 entries,
+// forward and backward, from random keys.
+func BenchmarkSkipList_Iterator_SeekScan(b *testing.B) {
+	for _, setup := range getTestSetups[int, int]() {
+		for _, dir := range []struct {
+			name string
+			move func(*Iterator[int, int]) bool
+		}{
+			{"Next", (*Iterator[int, int]).Next},
+			{"Prev", (*Iterator[int, int]).Prev},
+		} {
+			b.Run(setup.name+"/"+dir.name, func(b *testing.B) {
+				sl, keys := newReadBenchmarkList(setup)
+				it := sl.NewIterator()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					it.Seek(keys[i%len(keys)])
+					for j := 0; j < 16 && dir.move(it); j++ {
+						_ = it.Key()
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkSkipList_Rank measures Rank of random existing keys.
+func BenchmarkSkipList_Rank(b *testing.B) {
+	for _, setup := range getTestSetups[int, int]() {
+		b.Run(setup.name, func(b *testing.B) {
+			sl, keys := newReadBenchmarkList(setup)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = sl.Rank(keys[i%len(keys)])
+			}
+		})
+	}
+}
+
+// BenchmarkSkipList_GetByRank measures GetByRank of random ranks.
+func BenchmarkSkipList_GetByRank(b *testing.B) {
+	for _, setup := range getTestSetups[int, int]() {
+		b.Run(setup.name, func(b *testing.B) {
+			sl, _ := newReadBenchmarkList(setup)
+			ranks := make([]int, 1024)
+			for i := range ranks {
+				ranks[i] = rand.IntN(benchmarkSize)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_, _ = sl.GetByRank(ranks[i%len(ranks)])
+			}
+		})
+	}
+}
+
+// BenchmarkSkipList_Iterator_SeekToRank measures positioning an iterator by
+// rank, then reading the previous entry.
+func BenchmarkSkipList_Iterator_SeekToRank(b *testing.B) {
+	for _, setup := range getTestSetups[int, int]() {
+		b.Run(setup.name, func(b *testing.B) {
+			sl, _ := newReadBenchmarkList(setup)
+			it := sl.NewIterator()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if it.SeekToRank(i%(benchmarkSize-1) + 1) {
+					it.Prev()
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package skiplist
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"sort"
+	"unsafe"
+)
+
+// ReadOnlyList is an ordered map served directly from a chunk snapshot file
+// (see SaveChunks) mapped in memory. Opening it does not materialize any
+// node: the keys and values are read in place, and the relink table of the
+// snapshot, which lists the entries in key order, turns a rank into the
+// offset of an entry, so that Search is a binary search over the mapped
+// file. Startup over a large pre-built index is therefore immediate, and
+// the pages are loaded by the operating system as they are read.
+// All methods but Close are safe for concurrent use; the list must not be
+// used once closed. On platforms without memory mapping the file is read in
+// memory instead.
+//
+// ReadOnlyList คือ ordered map แบบอ่านอย่างเดียวที่อ่านข้อมูลโดยตรงจากไฟล์ chunk snapshot ที่ map ไว้ในหน่วยความจำ
+type ReadOnlyList[K any, V any] struct {
+	compare Comparator[K]
+	layout  chunkLayout
+	data    []byte
+	keys    []K
+	values  []V
+	order32 []uint32 // relink table of a snapshot with 4-byte order entries
+	order64 []uint64 // relink table of a snapshot with 8-byte order entries
+}
+
+// OpenReadOnly opens the chunk snapshot at path, written by SaveChunks, for
+// key types that implement cmp.Ordered. It checks the header and size of
+// the file and that the relink table only refers to entries of the file, so
+// that a damaged file cannot make the other methods read out of bounds; use
+// Verify to check the checksum and the order of its contents. It returns
+// an error wrapping ErrChunkSnapshot if the snapshot was written for other
+// key or value types, and one wrapping ErrCorrupt if it is damaged.
+// OpenReadOnly เปิดไฟล์ chunk snapshot แบบอ่านอย่างเดียวโดยไม่ต้องสร้างโหนด
+func OpenReadOnly[K cmp.Ordered, V any](path string) (*ReadOnlyList[K, V], error) {
+	return OpenReadOnlyWithComparator[K, V](path, cmp.Compare[K])
+}
+
+// OpenReadOnlyWithComparator is like OpenReadOnly with a custom comparator,
+// which must order the keys as the comparator of the list that was saved.
+// The comparator function must not be nil.
+// OpenReadOnlyWithComparator เปิดไฟล์ chunk snapshot พร้อมฟังก์ชันเปรียบเทียบที่กำหนดเอง
+func OpenReadOnlyWithComparator[K any, V any](path string, compare Comparator[K]) (*ReadOnlyList[K, V], error) {
+	if compare == nil {
+		panic("skiplist: comparator cannot be nil")
+	}
+	if !plainData[K]() || !plainData[V]() {
+		return nil, ErrChunkSnapshot
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	hdr := make([]byte, chunkHeaderSize)
+	if _, err := f.ReadAt(hdr, 0); err != nil {
+		return nil, fmt.Errorf("%w: not a chunk snapshot", ErrCorrupt)
+	}
+	layout, err := parseChunkHeader[K, V](hdr)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(info.Size()) != layout.size() {
+		return nil, fmt.Errorf("%w: chunk snapshot of %d bytes, want %d", ErrCorrupt, info.Size(), layout.size())
+	}
+	data, err := mapFile(f, int(layout.size()))
+	if err != nil {
+		return nil, err
+	}
+
+	l := &ReadOnlyList[K, V]{compare: compare, layout: layout, data: data}
+	if n := int(layout.count); n > 0 {
+		// The regions start at multiples of chunkAlign bytes from the start
+		// of the mapping, so they are aligned for any type.
+		l.keys = unsafe.Slice((*K)(unsafe.Pointer(&data[layout.keys])), n)
+		l.values = unsafe.Slice((*V)(unsafe.Pointer(&data[layout.values])), n)
+		if layout.width == 4 {
+			l.order32 = unsafe.Slice((*uint32)(unsafe.Pointer(&data[layout.order])), n)
+		} else {
+			l.order64 = unsafe.Slice((*uint64)(unsafe.Pointer(&data[layout.order])), n)
+		}
+		if err := l.checkOrder(); err != nil {
+			unmapFile(data)
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Len returns the number of entries.
+// Len คืนค่าจำนวนรายการ
+func (l *ReadOnlyList[K, V]) Len() int {
+	return len(l.keys)
+}
+
+// entry returns the number of the entry at the given rank.
+func (l *ReadOnlyList[K, V]) entry(rank int) int {
+	if l.order32 != nil {
+		return int(l.order32[rank])
+	}
+	return int(l.order64[rank])
+}
+
+// checkOrder returns an error wrapping ErrCorrupt if the relink table
+// refers to an entry past the end of the file.
+func (l *ReadOnlyList[K, V]) checkOrder() error {
+	n := uint64(len(l.keys))
+	for _, i := range l.order32 {
+		if uint64(i) >= n {
+			return fmt.Errorf("%w: chunk snapshot relinks entry %d of %d", ErrCorrupt, i, n)
+		}
+	}
+	for _, i := range l.order64 {
+		if i >= n {
+			return fmt.Errorf("%w: chunk snapshot relinks entry %d of %d", ErrCorrupt, i, n)
+		}
+	}
+	return nil
+}
+
+// lowerBound returns the rank of the first entry whose key is not smaller
+// than key.
+func (l *ReadOnlyList[K, V]) lowerBound(key K) int {
+	return sort.Search(len(l.keys), func(rank int) bool {
+		return l.compare(l.keys[l.entry(rank)], key) >= 0
+	})
+}
+
+// Search returns the value of key, and whether it exists.
+// Search ค้นหา value ของ key ที่กำหนด
+func (l *ReadOnlyList[K, V]) Search(key K) (V, bool) {
+	if rank := l.lowerBound(key); rank < len(l.keys) {
+		if i := l.entry(rank); l.compare(l.keys[i], key) == 0 {
+			return l.values[i], true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Range iterates over all entries in ascending key order until f returns false.
+// Range วนลูปไปตามรายการทั้งหมดตามลำดับ key จนกว่า f จะคืนค่า false
+func (l *ReadOnlyList[K, V]) Range(f func(key K, value V) bool) {
+	defer rethrowCallbackPanic("Range")
+	for rank := range l.keys {
+		if i := l.entry(rank); !f(l.keys[i], l.values[i]) {
+			return
+		}
+	}
+}
+
+// RangeQuery iterates over entries whose key is between start and end (inclusive)
+// until f returns false.
+// RangeQuery วนลูปไปตามรายการที่ key อยู่ระหว่าง start และ end (รวมทั้งสองค่า)
+func (l *ReadOnlyList[K, V]) RangeQuery(start, end K, f func(key K, value V) bool) {
+	defer rethrowCallbackPanic("RangeQuery")
+	for rank := l.lowerBound(start); rank < len(l.keys); rank++ {
+		i := l.entry(rank)
+		if l.compare(l.keys[i], end) > 0 || !f(l.keys[i], l.values[i]) {
+			return
+		}
+	}
+}
+
+// Verify reads the whole file to check its checksum, and that the relink
+// table lists every entry once in ascending key order. It returns an error
+// wrapping ErrCorrupt if the file is damaged; the other methods may then
+// return wrong results.
+// Verify ตรวจสอบ checksum และลำดับของข้อมูลทั้งไฟล์
+func (l *ReadOnlyList[K, V]) Verify() error {
+	body := l.data[chunkHeaderSize:l.layout.trailer]
+	if crc32.Checksum(body, castagnoli) != binary.NativeEndian.Uint32(l.data[l.layout.trailer:]) {
+		return fmt.Errorf("%w: chunk snapshot checksum mismatch", ErrCorrupt)
+	}
+	for rank := range l.keys {
+		i := l.entry(rank)
+		if rank > 0 && l.compare(l.keys[l.entry(rank-1)], l.keys[i]) >= 0 {
+			return fmt.Errorf("%w: chunk snapshot relinks entries out of order", ErrCorrupt)
+		}
+	}
+	return nil
+}
+
+// Close releases the mapping of the file.
+// Close ปล่อยหน่วยความจำที่ map ไฟล์ไว้
+func (l *ReadOnlyList[K, V]) Close() error {
+	data := l.data
+	l.data, l.keys, l.values, l.order32, l.order64 = nil, nil, nil, nil, nil
+	if data == nil {
+		return nil
+	}
+	return unmapFile(data)
+}
@@ -0,0 +1,142 @@
+package skiplist
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenReadOnly(t *testing.T) {
+	sl := New[int64, chunkPoint](WithArena[int64, chunkPoint](1 << 10))
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		k := r.Int63n(50000) * 2
+		sl.Insert(k, chunkPoint{X: float64(k), Y: -float64(i)})
+	}
+	sl.DeleteRange(1000, 2000)
+
+	path := filepath.Join(t.TempDir(), "list.chunks")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sl.SaveChunks(f); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	ro, err := OpenReadOnly[int64, chunkPoint](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ro.Close()
+	if ro.Len() != sl.Len() {
+		t.Fatalf("Len() = %d, want %d", ro.Len(), sl.Len())
+	}
+	if err := ro.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	for k := int64(-1); k < 100002; k++ {
+		want, wok := sl.Search(k)
+		got, ok := ro.Search(k)
+		if ok != wok || (ok && got != want.Value()) {
+			t.Fatalf("Search(%d) = %v, %v, want %v", k, got, ok, wok)
+		}
+	}
+	it := sl.NewIterator()
+	ro.Range(func(k int64, v chunkPoint) bool {
+		if !it.Next() || it.Key() != k || it.Value() != v {
+			t.Fatalf("Range yielded %d = %v, want %d = %v", k, v, it.Key(), it.Value())
+		}
+		return true
+	})
+	if it.Next() {
+		t.Fatalf("Range stopped before %d", it.Key())
+	}
+	var got []int64
+	ro.RangeQuery(999, 2101, func(k int64, _ chunkPoint) bool {
+		got = append(got, k)
+		return true
+	})
+	var want []int64
+	sl.RangeQuery(999, 2101, func(k int64, _ chunkPoint) bool {
+		want = append(want, k)
+		return true
+	})
+	if len(got) != len(want) || (len(got) > 0 && got[0] != want[0]) {
+		t.Errorf("RangeQuery(999, 2101) = %v, want %v", got, want)
+	}
+	if err := ro.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenReadOnly[int64, int64](path); !errors.Is(err, ErrChunkSnapshot) {
+		t.Errorf("OpenReadOnly with another value type = %v", err)
+	}
+	if _, err := OpenReadOnly[string, int64](path); !errors.Is(err, ErrChunkSnapshot) {
+		t.Errorf("OpenReadOnly with string keys = %v", err)
+	}
+
+	// A damaged key is only found by Verify; a truncated file or a damaged
+	// relink table by Open.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layout, err := parseChunkHeader[int64, chunkPoint](data[:chunkHeaderSize])
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[layout.keys] ^= 0xff
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if ro, err = OpenReadOnly[int64, chunkPoint](path); err != nil {
+		t.Fatal(err)
+	}
+	if err := ro.Verify(); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Verify of a damaged snapshot = %v", err)
+	}
+	ro.Close()
+	if err := os.WriteFile(path, data[:len(data)-1], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenReadOnly[int64, chunkPoint](path); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("OpenReadOnly of a truncated snapshot = %v", err)
+	}
+	for i := range layout.width {
+		data[layout.order+uint64(i)] = 0xff
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenReadOnly[int64, chunkPoint](path); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("OpenReadOnly of a snapshot relinking a missing entry = %v", err)
+	}
+}
+
+func TestOpenReadOnlyEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.chunks")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := New[int, int](WithArena[int, int](1 << 10)).SaveChunks(f); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	ro, err := OpenReadOnly[int, int](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ro.Search(1); ok || ro.Len() != 0 || ro.Verify() != nil {
+		t.Errorf("empty snapshot: Len() = %d, Verify() = %v", ro.Len(), ro.Verify())
+	}
+	if err := ro.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,221 @@
+package skiplist
+
+import "io"
+
+// FrozenSkipList is a read-only skiplist detached by Rotate. It holds the
+// entries the source list had at the time of the rotation, in the memory of
+// the nodes they were inserted in, and is typically flushed to disk with
+// FlushTo while the source list keeps accepting writes.
+//
+// All methods are safe for concurrent use.
+//
+// FrozenSkipList คือ skiplist แบบอ่านอย่างเดียวที่แยกออกมาด้วย Rotate
+// ใช้สำหรับเขียนข้อมูลลงดิสก์ในขณะที่ skiplist ต้นทางยังรับการเขียนใหม่ได้
+type FrozenSkipList[K any, V any] struct {
+	sl    *SkipList[K, V]
+	owner *SkipList[K, V]
+	id    uint64 // ID in the snapshot registry of owner
+}
+
+// Rotate atomically detaches all entries into a FrozenSkipList and leaves sl
+// empty, which is the lifecycle of an LSM-tree memtable: the frozen list is
+// streamed to disk in key order while new writes go to sl. The detach is
+// O(1): the nodes are handed over, not copied.
+//
+// With an arena allocator, the arena of the frozen list returns to sl when
+// the frozen list is released (FlushTo or Release), and the next Rotate
+// reuses its chunks instead of allocating a new arena. Until then sl fills a
+// fresh arena.
+//
+// The entries are moved, not deleted: Rotate does not run the OnDelete hook
+// and does not record deletions in the MVCC history, the LWW timestamps or
+// the change tracking state. The secondary index and the ID index, which
+// index the entries of sl, are emptied. The frozen list uses the comparator,
+// key prefix, codec and tracer of sl.
+//
+// Rotate แยกข้อมูลทั้งหมดออกเป็น FrozenSkipList และทำให้ sl ว่างเปล่าในขั้นตอนเดียว
+// เหมาะสำหรับ memtable ของ LSM-tree ที่ต้องเขียนข้อมูลลงดิสก์ในขณะที่ยังรับการเขียนใหม่
+func (sl *SkipList[K, V]) Rotate() *FrozenSkipList[K, V] {
+	tr := sl.traceStart(OpRotate)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+	sl.mustNotBeFrozen()
+
+	frozen := &SkipList[K, V]{
+		header:               sl.header,
+		level:                sl.level,
+		length:               sl.length,
+		allocator:            sl.allocator,
+		arenaInitialSize:     sl.arenaInitialSize,
+		arenaGrowthFactor:    sl.arenaGrowthFactor,
+		arenaGrowthBytes:     sl.arenaGrowthBytes,
+		arenaGrowthThreshold: sl.arenaGrowthThreshold,
+		arenaNodePadding:     sl.arenaNodePadding,
+		arenaAlignment:       sl.arenaAlignment,
+		arenaHugePages:       sl.arenaHugePages,
+		arenaFixed:           sl.arenaFixed,
+		compare:              sl.compare,
+		tracer:               sl.tracer,
+		version:              sl.version,
+		codec:                sl.codec,
+		byteKeys:             sl.byteKeys,
+		keyPrefix:            sl.keyPrefix,
+		weight:               sl.weight,
+		weights:              sl.weights,
+		merkle:               sl.merkle,
+		hashes:               sl.hashes,
+		backLinks:            sl.backLinks,
+		entryTags:            sl.entryTags,
+		noRanks:              sl.noRanks,
+		secureWipe:           sl.secureWipe,
+		sizeOf:               sl.sizeOf,
+		frozen:               true,
+	}
+	frozen.size.Store(int64(sl.length))
+	frozen.bytes.Store(sl.bytes.Load())
+	tr.keys = sl.length
+
+	sl.version++
+	sl.epoch++
+	wasEmpty := sl.length == 0
+	if sl.secondary != nil {
+		sl.secondary.clear()
+	}
+	clear(sl.ids)
+	sl.header = &node[K, V]{
+		forward: make([]*node[K, V], MaxLevel),
+		span:    make([]int, MaxLevel),
+	}
+	if sl.weight != nil {
+		sl.header.wspan = make([]int, MaxLevel)
+	}
+	if sl.merkle != nil {
+		sl.header.hspan = make([]uint64, MaxLevel)
+	}
+	sl.level = 0
+	sl.setLength(0)
+	sl.bytes.Store(0)
+	sl.weights = 0
+	sl.hashes = 0
+	if sl.spare != nil {
+		sl.allocator, sl.spare = sl.spare, nil
+	} else {
+		sl.allocator = sl.newAllocator()
+	}
+	// Drop references to the detached nodes kept by the update path and hot
+	// caches.
+	clear(sl.updateCache)
+	if sl.hot != nil {
+		sl.hot.reset()
+	}
+	if !wasEmpty && sl.hooks.OnBoundsChange != nil {
+		sl.boundsChanged()
+	}
+	f := &FrozenSkipList[K, V]{sl: frozen, owner: sl}
+	sl.registerFrozen(f)
+	return f
+}
+
+// Len returns the number of entries in the frozen list.
+// Len คืนค่าจำนวนรายการใน frozen list
+func (f *FrozenSkipList[K, V]) Len() int {
+	return f.sl.Len()
+}
+
+// Bytes returns the total size of the frozen entries, see SkipList.Bytes.
+// Bytes คืนค่าขนาดรวมของข้อมูลใน frozen list
+func (f *FrozenSkipList[K, V]) Bytes() int {
+	return f.sl.Bytes()
+}
+
+// Search returns the node of key, if present.
+// Search ค้นหาโหนดจาก key ที่กำหนด
+func (f *FrozenSkipList[K, V]) Search(key K) (INode[K, V], bool) {
+	return f.sl.Search(key)
+}
+
+// Range iterates over all entries in ascending key order until f returns false.
+// Range วนลูปไปตามรายการทั้งหมดตามลำดับ key จนกว่า fn จะคืนค่า false
+func (f *FrozenSkipList[K, V]) Range(fn func(key K, value V) bool) {
+	f.sl.Range(fn)
+}
+
+// NewIterator creates an iterator over the frozen list; see SkipList.NewIterator.
+// NewIterator สร้าง Iterator สำหรับ frozen list
+func (f *FrozenSkipList[K, V]) NewIterator(opts ...IteratorOption[K, V]) *Iterator[K, V] {
+	return f.sl.NewIterator(opts...)
+}
+
+// FlushTo writes the frozen list to w in the snapshot format of Save, so it
+// can be restored with Load, then releases it (see Release). On a write error
+// the frozen list is kept and FlushTo can be retried.
+// FlushTo เขียน frozen list ลงใน w ในรูปแบบเดียวกับ Save แล้วจึงปล่อยหน่วยความจำคืน
+func (f *FrozenSkipList[K, V]) FlushTo(w io.Writer) error {
+	if err := f.sl.Save(w); err != nil {
+		return err
+	}
+	f.Release()
+	return nil
+}
+
+// Release empties the frozen list and, when it was built in an arena that
+// matches the current arena settings of the source list, hands the arena
+// back to the source list for its next Rotate. Nodes, INode handles and
+// iterators obtained from the frozen list must not be used afterwards, since
+// their memory may be reused. Release is a no-op on a released list.
+// Release ทำให้ frozen list ว่างเปล่าและคืน arena ให้ skiplist ต้นทางนำไปใช้ใหม่
+func (f *FrozenSkipList[K, V]) Release() {
+	f.owner.snapshots.remove(f.id)
+	f.sl.releaseFrozen(f.owner)
+}
+
+// releaseFrozen empties fl, a list detached from owner by Rotate, and hands
+// its arena back to owner.
+func (fl *SkipList[K, V]) releaseFrozen(owner *SkipList[K, V]) {
+	fl.mutex.Lock()
+	defer fl.mutex.Unlock()
+
+	alloc, ok := fl.allocator.(*arenaAllocator[K, V])
+	if fl.length == 0 && !ok {
+		return
+	}
+	if fl.secureWipe {
+		wipeValues(fl.header.forward[0])
+	}
+	fl.header = &node[K, V]{
+		forward: make([]*node[K, V], MaxLevel),
+		span:    make([]int, MaxLevel),
+	}
+	fl.level = 0
+	fl.setLength(0)
+	fl.bytes.Store(0)
+	fl.weights = 0
+	fl.hashes = 0
+	fl.allocator = newPoolAllocator[K, V]()
+	fl.version++
+	if !ok {
+		return
+	}
+
+	owner.mutex.Lock()
+	defer owner.mutex.Unlock()
+	if owner.spare == nil && owner.sameArena(fl) {
+		alloc.Reset()
+		owner.spare = alloc
+	}
+}
+
+// sameArena reports whether the arena settings of sl and other are equal, so
+// that an arena built for one of them can serve the other.
+func (sl *SkipList[K, V]) sameArena(other *SkipList[K, V]) bool {
+	return sl.arenaInitialSize == other.arenaInitialSize &&
+		sl.arenaGrowthFactor == other.arenaGrowthFactor &&
+		sl.arenaGrowthBytes == other.arenaGrowthBytes &&
+		sl.arenaGrowthThreshold == other.arenaGrowthThreshold &&
+		sl.arenaNodePadding == other.arenaNodePadding &&
+		sl.arenaAlignment == other.arenaAlignment &&
+		sl.arenaHugePages == other.arenaHugePages &&
+		sl.arenaFixed == other.arenaFixed
+}
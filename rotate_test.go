@@ -0,0 +1,156 @@
+package skiplist
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRotate(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			for i := 0; i < 100; i++ {
+				sl.Insert(i, "v")
+			}
+
+			frozen := sl.Rotate()
+			if sl.Len() != 0 {
+				t.Fatalf("Len() after Rotate = %d, want 0", sl.Len())
+			}
+			if frozen.Len() != 100 {
+				t.Fatalf("frozen Len() = %d, want 100", frozen.Len())
+			}
+
+			// New writes go to the live list only.
+			sl.Insert(1000, "new")
+			sl.Insert(5, "updated")
+			if _, ok := frozen.Search(1000); ok {
+				t.Error("write after Rotate is visible in the frozen list")
+			}
+			if n, ok := frozen.Search(5); !ok || n.Value() != "v" {
+				t.Errorf("frozen Search(5) = %v, %v, want v", n, ok)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("live list: %v", err)
+			}
+			if err := frozen.sl.Validate(); err != nil {
+				t.Fatalf("frozen list: %v", err)
+			}
+
+			want := 0
+			it := frozen.NewIterator()
+			for it.Next() {
+				if it.Key() != want {
+					t.Fatalf("frozen iterator returned %d, want %d", it.Key(), want)
+				}
+				want++
+			}
+			if want != 100 {
+				t.Fatalf("frozen iterator returned %d entries, want 100", want)
+			}
+
+			var buf bytes.Buffer
+			if err := frozen.FlushTo(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if frozen.Len() != 0 {
+				t.Errorf("frozen Len() after FlushTo = %d, want 0", frozen.Len())
+			}
+			restored := New[int, string]()
+			if err := restored.Load(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if restored.Len() != 100 {
+				t.Errorf("flushed snapshot holds %d entries, want 100", restored.Len())
+			}
+			frozen.Release() // no-op on a released list
+
+			// The live list is still intact and can be rotated again.
+			if sl.Len() != 2 {
+				t.Errorf("live Len() = %d, want 2", sl.Len())
+			}
+			if frozen := sl.Rotate(); frozen.Len() != 2 {
+				t.Errorf("second Rotate detached %d entries, want 2", frozen.Len())
+			}
+		})
+	}
+}
+
+func TestRotate_ReusesArena(t *testing.T) {
+	sl := New(WithArena[int, int](1 << 16))
+	for i := 0; i < 1000; i++ {
+		sl.Insert(i, i)
+	}
+	first := sl.allocator
+
+	frozen := sl.Rotate()
+	if sl.allocator == first {
+		t.Fatal("Rotate kept the arena of the frozen list")
+	}
+	frozen.Release()
+	if sl.spare != first {
+		t.Fatal("Release did not hand the arena back")
+	}
+
+	sl.Insert(1, 1)
+	sl.Rotate()
+	if sl.allocator != first || sl.spare != nil {
+		t.Error("Rotate did not reuse the released arena")
+	}
+	for i := 0; i < 1000; i++ {
+		sl.Insert(i, -i)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := sl.Search(999); !ok || n.Value() != -999 {
+		t.Errorf("Search(999) = %v, %v after reusing the arena", n, ok)
+	}
+
+	// An arena with settings other than the current ones is not reused.
+	frozen = sl.Rotate()
+	if err := sl.MigrateAllocator(WithArena[int, int](1 << 12)); err != nil {
+		t.Fatal(err)
+	}
+	frozen.Release()
+	if sl.spare != nil {
+		t.Error("Release handed back an arena with stale settings")
+	}
+}
+
+func TestRotate_Hooks(t *testing.T) {
+	var deleted, emptied int
+	sl := New(WithHooks(Hooks[int, int]{
+		OnDelete: func(int, int) { deleted++ },
+		OnBoundsChange: func(_, _ int, empty bool) {
+			if empty {
+				emptied++
+			}
+		},
+	}))
+	sl.Insert(1, 1)
+	sl.Insert(2, 2)
+	sl.Rotate()
+	if deleted != 0 || emptied != 1 {
+		t.Errorf("Rotate ran OnDelete %d times and reported an empty list %d times, want 0 and 1", deleted, emptied)
+	}
+}
+
+func TestRotate_FlushError(t *testing.T) {
+	sl := New[int, int]()
+	sl.Insert(1, 1)
+	frozen := sl.Rotate()
+	if err := frozen.FlushTo(failingWriter{}); !errors.Is(err, errWrite) {
+		t.Fatalf("FlushTo returned %v, want errWrite", err)
+	}
+	if frozen.Len() != 1 {
+		t.Errorf("failed FlushTo released the frozen list")
+	}
+}
+
+var errWrite = errors.New("write failed")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errWrite }
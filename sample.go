@@ -0,0 +1,29 @@
+package skiplist
+
+// SampleLevel calls f, in ascending key order, for every entry whose node is
+// present at level L or above (levels are 0-based, level 0 holding every
+// entry), until f returns false. Node heights are random with P = 1/4, so
+// level L holds about Len()/4^L entries spread evenly over the key space: a
+// cheap pseudo-random sample of the list, or the pivots of a coarser index
+// (e.g. the partition boundaries of a two-tier index) built over it.
+//
+// Only the express lane of level L is walked, so the cost is proportional to
+// the number of entries visited. Nothing is visited if L is negative or above
+// the current highest level; Stats reports how many nodes each level holds.
+//
+// SampleLevel เรียก f สำหรับทุกรายการที่มีโหนดอยู่ในชั้น L ขึ้นไป เรียงตาม key
+// ใช้เป็นตัวอย่างสุ่มแบบประหยัด หรือเป็นจุดแบ่งสำหรับสร้าง index สองชั้น
+func (sl *SkipList[K, V]) SampleLevel(L int, f func(key K, value V) bool) {
+	defer rethrowCallbackPanic("SampleLevel")
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	if L < 0 || L > sl.level {
+		return
+	}
+	for n := sl.header.forward[L]; n != nil; n = n.forward[L] {
+		if !f(n.key, n.value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package skiplist
+
+import "testing"
+
+func TestSampleLevel(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			sl.SampleLevel(0, func(int, int) bool {
+				t.Fatal("SampleLevel visited an entry of an empty list")
+				return false
+			})
+
+			for i := 0; i < 5000; i++ {
+				sl.Insert(i, i*10)
+			}
+			st := sl.Stats()
+			for L := range st.LevelCounts {
+				count, prev := 0, -1
+				sl.SampleLevel(L, func(k, v int) bool {
+					if k <= prev || v != k*10 {
+						t.Fatalf("level %d: got %d=%d after key %d", L, k, v, prev)
+					}
+					if n, _ := sl.Search(k); len(n.(*node[int, int]).forward) <= L {
+						t.Fatalf("level %d: key %d is not on that level", L, k)
+					}
+					prev = k
+					count++
+					return true
+				})
+				if count != st.LevelCounts[L] {
+					t.Errorf("SampleLevel(%d) visited %d entries, want %d", L, count, st.LevelCounts[L])
+				}
+			}
+
+			count := 0
+			sl.SampleLevel(0, func(int, int) bool {
+				count++
+				return count < 3
+			})
+			if count != 3 {
+				t.Errorf("SampleLevel did not stop when f returned false: %d calls", count)
+			}
+			for _, L := range []int{-1, len(st.LevelCounts), MaxLevel} {
+				sl.SampleLevel(L, func(int, int) bool {
+					t.Fatalf("SampleLevel(%d) visited an entry", L)
+					return false
+				})
+			}
+		})
+	}
+}
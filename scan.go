@@ -0,0 +1,65 @@
+package skiplist
+
+import "runtime"
+
+// ScanOption configures a scan by Range, RangeKeys, RangeValues, RangeNodes
+// or RangeQuery.
+// ScanOption คือฟังก์ชันสำหรับกำหนดค่าของการวนลูปด้วย Range และ RangeQuery
+type ScanOption func(*scanConfig)
+
+type scanConfig struct {
+	yieldEvery int
+}
+
+// WithYieldEvery makes a scan release the read lock every n entries and
+// re-acquire it, resuming after the last key visited, so that writers
+// waiting for the lock are not starved by scans lasting seconds. The scan is
+// then no longer a consistent view of the list: entries inserted or deleted
+// after the last key visited while the lock is released are seen as such,
+// and a key updated in the meantime is visited with its new value. n <= 0
+// disables yielding.
+// WithYieldEvery ปล่อย read lock ทุก n รายการระหว่างการวนลูปเพื่อให้ writer ทำงานได้
+// แล้ววนลูปต่อจาก key ล่าสุด
+func WithYieldEvery(n int) ScanOption {
+	return func(c *scanConfig) {
+		c.yieldEvery = n
+	}
+}
+
+// scan calls visit for current and the nodes following it, in ascending key
+// order, until visit returns false or the list ends, yielding the read lock
+// as configured by opts. The caller must hold the read lock, which is held
+// again when scan returns or visit is called.
+func (sl *SkipList[K, V]) scan(tr *opTrace, current *node[K, V], opts []ScanOption, visit func(n *node[K, V]) bool) {
+	var cfg scanConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	for i := 1; current != nil; i++ {
+		if !visit(current) {
+			return
+		}
+		if cfg.yieldEvery > 0 && i%cfg.yieldEvery == 0 {
+			current = sl.yieldAfter(tr, current.key)
+		} else {
+			current = current.forward[0]
+		}
+	}
+}
+
+// yieldAfter releases the read lock, lets waiting writers run, re-acquires
+// the lock and returns the first node with a key greater than key. Nodes
+// must not be kept across the call: they may be deleted and reused.
+func (sl *SkipList[K, V]) yieldAfter(tr *opTrace, key K) *node[K, V] {
+	tr.released()
+	sl.mutex.RUnlock()
+	runtime.Gosched()
+	sl.mutex.RLock()
+	tr.locked()
+
+	n := sl.findGreaterOrEqual(key)
+	if n != nil && sl.compare(n.key, key) == 0 {
+		n = n.forward[0]
+	}
+	return n
+}
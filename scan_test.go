@@ -0,0 +1,105 @@
+package skiplist
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithYieldEvery(t *testing.T) {
+	skipWithoutSync(t)
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			for _, yield := range []bool{false, true} {
+				sl := setup.constructor(nil)
+				for i := 0; i < 100; i += 2 {
+					sl.Insert(i, i)
+				}
+				var opts []ScanOption
+				if yield {
+					opts = append(opts, WithYieldEvery(10))
+				}
+
+				// A writer started during the scan inserts 31 and deletes 40.
+				// It gets the lock at the first yield, and otherwise only
+				// once the scan is over.
+				var keys []int
+				var wg sync.WaitGroup
+				sl.Range(func(k, _ int) bool {
+					keys = append(keys, k)
+					if k == 4 {
+						wg.Add(1)
+						go func() {
+							defer wg.Done()
+							sl.Insert(31, 31)
+							sl.Delete(40)
+						}()
+						time.Sleep(10 * time.Millisecond)
+					}
+					return true
+				}, opts...)
+				wg.Wait()
+				if got := slices.Contains(keys, 31) && !slices.Contains(keys, 40); got != yield {
+					t.Errorf("yield=%v: keys = %v", yield, keys)
+				}
+				for i := 1; i < len(keys); i++ {
+					if keys[i] <= keys[i-1] {
+						t.Fatalf("yield=%v: keys not strictly increasing: %v", yield, keys)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWithYieldEveryRangeQuery(t *testing.T) {
+	skipWithoutSync(t)
+	rec := &recordingTracer{}
+	sl := New[int, int](WithTracer[int, int](rec))
+	for i := 0; i < 100; i++ {
+		sl.Insert(i, i)
+	}
+
+	var keys []int
+	sl.RangeQuery(10, 50, func(k, _ int) bool {
+		keys = append(keys, k)
+		return k < 45
+	}, WithYieldEvery(7))
+	if len(keys) != 36 || keys[0] != 10 || keys[35] != 45 {
+		t.Errorf("RangeQuery keys = %v", keys)
+	}
+	if info := rec.ends[len(rec.ends)-1]; info.Op != OpRangeQuery || info.Keys != 36 {
+		t.Errorf("traced %+v", info)
+	}
+
+	var n int
+	sl.RangeKeys(func(int) bool { n++; return true }, WithYieldEvery(1))
+	sl.RangeValues(func(int) bool { n++; return true }, WithYieldEvery(3))
+	if n != 200 {
+		t.Errorf("RangeKeys and RangeValues visited %d entries, want 200", n)
+	}
+	if err := sl.TryRangeQuery(0, 99, func(int, int) bool { n++; return true }, WithYieldEvery(50)); err != nil || n != 300 {
+		t.Errorf("TryRangeQuery() = %v, visited %d entries", err, n-200)
+	}
+}
+
+func TestWithYieldEveryLockAudit(t *testing.T) {
+	skipWithoutSync(t)
+	var holds []LockHold
+	sl := New[int, int](WithLockAudit[int, int](5*time.Millisecond, func(h LockHold) { holds = append(holds, h) }))
+	for i := 0; i < 20; i++ {
+		sl.Insert(i, i)
+	}
+	slow := func(int, int) bool { time.Sleep(time.Millisecond); return true }
+
+	// Yielding every 2 entries keeps every hold short.
+	sl.Range(slow, WithYieldEvery(2))
+	if len(holds) != 0 {
+		t.Errorf("yielding scan reported: %+v", holds)
+	}
+	sl.Range(slow)
+	if len(holds) != 1 || holds[0].Held < 20*time.Millisecond {
+		t.Errorf("scan reported as %+v", holds)
+	}
+}
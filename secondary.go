@@ -0,0 +1,131 @@
+package skiplist
+
+// secondaryIndex is maintained by the write paths of a SkipList when
+// WithSecondaryIndex is used. It is an interface so that SkipList[K, V] does not
+// need the secondary key type as a type parameter.
+type secondaryIndex[K any, V any] interface {
+	add(key K, value V)
+	remove(key K, value V)
+	replace(key K, old, value V)
+	clear()
+}
+
+// secondaryKey orders index entries by the secondary key, then by the primary
+// key. A non-zero bound turns the entry into a probe that sorts before (-1) or
+// after (+1) every entry with the same secondary key.
+type secondaryKey[S any, K any] struct {
+	sec   S
+	key   K
+	bound int8
+}
+
+// valueIndex is a companion skiplist ordered by a value-derived field.
+type valueIndex[K any, V any, S any] struct {
+	extract func(V) S
+	list    *SkipList[secondaryKey[S, K], V]
+}
+
+// WithSecondaryIndex maintains a second skiplist ordered by extract(value), kept
+// consistent inside every write (Insert, Delete, PopMin, PopMax, Clear, ...).
+// Entries sharing a secondary key are ordered by their primary key. The index is
+// queried with SearchBySecondary and RangeBySecondary. Only one secondary index
+// can be attached to a list; a later WithSecondaryIndex replaces an earlier one.
+//
+// WithSecondaryIndex สร้าง index รองที่เรียงตามค่าที่ได้จาก value
+// และดูแลให้สอดคล้องกันในทุกการเขียนข้อมูล
+func WithSecondaryIndex[K any, V any, S any](extract func(V) S, compare Comparator[S]) Option[K, V] {
+	if extract == nil || compare == nil {
+		panic("skiplist: secondary index extractor and comparator cannot be nil")
+	}
+	return func(sl *SkipList[K, V]) {
+		primary := sl.compare
+		cmpKeys := func(a, b secondaryKey[S, K]) int {
+			if c := compare(a.sec, b.sec); c != 0 {
+				return c
+			}
+			if a.bound != 0 || b.bound != 0 {
+				return int(a.bound) - int(b.bound)
+			}
+			return primary(a.key, b.key)
+		}
+		sl.secondary = &valueIndex[K, V, S]{
+			extract: extract,
+			list:    NewWithComparator[secondaryKey[S, K], V](cmpKeys),
+		}
+	}
+}
+
+// The index is guarded by the write lock of the owning list, so the unlocked
+// internal methods of the companion list are used.
+
+func (x *valueIndex[K, V, S]) add(key K, value V) {
+	x.list.insert(secondaryKey[S, K]{sec: x.extract(value), key: key}, value)
+}
+
+func (x *valueIndex[K, V, S]) remove(key K, value V) {
+	x.list.delete(secondaryKey[S, K]{sec: x.extract(value), key: key})
+}
+
+// replace moves key from the entry of old to the entry of value. Both
+// secondary keys are extracted first, so that a panicking extract leaves the
+// index unchanged.
+func (x *valueIndex[K, V, S]) replace(key K, old, value V) {
+	from, to := x.extract(old), x.extract(value)
+	x.list.delete(secondaryKey[S, K]{sec: from, key: key})
+	x.list.insert(secondaryKey[S, K]{sec: to, key: key}, value)
+}
+
+func (x *valueIndex[K, V, S]) clear() {
+	x.list = NewWithComparator[secondaryKey[S, K], V](x.list.compare)
+}
+
+// rangeIndex calls f for every entry whose secondary key is between start and
+// end (inclusive), in secondary order. The caller must hold the read lock.
+func (x *valueIndex[K, V, S]) rangeIndex(start, end S, f func(key K, value V) bool) {
+	l := x.list
+	hi := secondaryKey[S, K]{sec: end, bound: 1}
+	for n := l.findGreaterOrEqual(secondaryKey[S, K]{sec: start, bound: -1}); n != nil && l.compare(n.key, hi) < 0; n = n.forward[0] {
+		if !f(n.key.key, n.value) {
+			return
+		}
+	}
+}
+
+// indexOf returns the secondary index of sl with secondary key type S, or
+// panics if there is none.
+func indexOf[K any, V any, S any](sl *SkipList[K, V]) *valueIndex[K, V, S] {
+	x, ok := sl.secondary.(*valueIndex[K, V, S])
+	if !ok {
+		panic("skiplist: no secondary index with this key type; use WithSecondaryIndex")
+	}
+	return x
+}
+
+// SearchBySecondary returns the primary keys of all entries whose secondary key
+// equals sec, in primary key order. It panics if sl has no secondary index with
+// secondary key type S.
+// SearchBySecondary คืนค่า key หลักของทุกรายการที่มี key รองเท่ากับ sec
+func SearchBySecondary[K any, V any, S any](sl *SkipList[K, V], sec S) []K {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	var keys []K
+	indexOf[K, V, S](sl).rangeIndex(sec, sec, func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// RangeBySecondary iterates, in secondary key order, over the entries whose
+// secondary key is between start and end (inclusive). The iteration stops if f
+// returns false. It panics if sl has no secondary index with secondary key type S.
+// RangeBySecondary วนลูปตามลำดับ key รอง สำหรับรายการที่มี key รองอยู่ระหว่าง start และ end
+func RangeBySecondary[K any, V any, S any](sl *SkipList[K, V], start, end S, f func(key K, value V) bool) {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	x := indexOf[K, V, S](sl)
+	defer rethrowCallbackPanic("RangeBySecondary")
+	x.rangeIndex(start, end, f)
+}
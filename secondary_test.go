@@ -0,0 +1,75 @@
+package skiplist
+
+import (
+	"cmp"
+	"reflect"
+	"testing"
+)
+
+type player struct {
+	Name  string
+	Score int
+}
+
+func TestSecondaryIndex(t *testing.T) {
+	for _, setup := range getTestSetups[string, player]() {
+		t.Run(setup.name, func(t *testing.T) {
+			byScore := WithSecondaryIndex[string, player](func(p player) int { return p.Score }, cmp.Compare[int])
+			sl := setup.constructor(nil, byScore)
+
+			sl.Insert("carol", player{"carol", 30})
+			sl.Insert("alice", player{"alice", 10})
+			sl.Insert("bob", player{"bob", 20})
+			sl.Insert("dave", player{"dave", 20})
+
+			if got := SearchBySecondary[string, player](sl, 20); !reflect.DeepEqual(got, []string{"bob", "dave"}) {
+				t.Errorf("SearchBySecondary(20) = %v", got)
+			}
+
+			// Updating a value moves the entry within the index.
+			sl.Insert("alice", player{"alice", 25})
+			if got := SearchBySecondary[string, player](sl, 10); len(got) != 0 {
+				t.Errorf("SearchBySecondary(10) after update = %v, want none", got)
+			}
+
+			var order []string
+			RangeBySecondary(sl, 15, 30, func(k string, p player) bool {
+				order = append(order, k)
+				return true
+			})
+			if !reflect.DeepEqual(order, []string{"bob", "dave", "alice", "carol"}) {
+				t.Errorf("RangeBySecondary(15, 30) = %v", order)
+			}
+
+			sl.Delete("bob")
+			sl.PopMax() // removes "dave"
+			if got := SearchBySecondary[string, player](sl, 20); len(got) != 0 {
+				t.Errorf("SearchBySecondary(20) after deletes = %v, want none", got)
+			}
+
+			order = order[:0]
+			RangeBySecondary(sl, 0, 100, func(k string, p player) bool {
+				order = append(order, k)
+				return false
+			})
+			if !reflect.DeepEqual(order, []string{"alice"}) {
+				t.Errorf("RangeBySecondary should stop after the first entry, got %v", order)
+			}
+
+			sl.Clear()
+			if got := SearchBySecondary[string, player](sl, 25); len(got) != 0 {
+				t.Errorf("SearchBySecondary after Clear = %v", got)
+			}
+		})
+	}
+}
+
+func TestSecondaryIndex_Missing(t *testing.T) {
+	sl := New[int, int]()
+	defer func() {
+		if recover() == nil {
+			t.Error("SearchBySecondary without an index should panic")
+		}
+	}()
+	SearchBySecondary[int, int](sl, "x")
+}
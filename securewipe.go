@@ -0,0 +1,31 @@
+package skiplist
+
+// WithSecureWipe makes the list zero the value of every entry it removes,
+// for values holding sensitive data such as tokens or key material. Without
+// it, an arena keeps the values of deleted entries in its chunks until their
+// memory is handed out again, and Clear, Rotate and MigrateAllocator leave
+// the values of the dropped nodes in memory until the garbage collector
+// reuses it. With it, the value of a node is overwritten with the zero value
+// as soon as the node leaves the list: on Delete and the other deletes, on
+// Clear and ClearIncremental, when a FrozenSkipList is released, and when
+// MigrateAllocator drops the old nodes.
+//
+// Only the memory of the node is wiped: the data behind a string, slice,
+// map or pointer value is not (store secrets in fixed-size arrays to keep
+// them inline), and neither are the copies of the value passed to hooks or
+// kept by MVCC history, snapshot iterators or Save.
+// WithSecureWipe ล้างค่า value ของรายการเป็นศูนย์ทันทีที่ถูกนำออกจาก list สำหรับข้อมูลที่เป็นความลับ
+func WithSecureWipe[K any, V any]() Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.secureWipe = true
+	}
+}
+
+// wipeValues zeroes the values of n and of the nodes following it on the
+// bottom level.
+func wipeValues[K any, V any](n *node[K, V]) {
+	var zero V
+	for ; n != nil; n = n.forward[0] {
+		n.value = zero
+	}
+}
@@ -0,0 +1,71 @@
+package skiplist
+
+import "testing"
+
+type secret [16]byte
+
+func TestWithSecureWipe(t *testing.T) {
+	token := secret{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	// nodes returns the nodes of sl, which stay readable after their
+	// removal in this test since it keeps them referenced.
+	nodes := func(sl *SkipList[int, secret]) []*node[int, secret] {
+		var ns []*node[int, secret]
+		for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+			ns = append(ns, n)
+		}
+		return ns
+	}
+	wiped := func(t *testing.T, what string, ns []*node[int, secret]) {
+		t.Helper()
+		for _, n := range ns {
+			if n.value != (secret{}) {
+				t.Fatalf("%s left value %v in memory", what, n.value)
+			}
+		}
+	}
+
+	for _, setup := range getTestSetups[int, secret]() {
+		t.Run(setup.name, func(t *testing.T) {
+			var deleted []secret
+			sl := setup.constructor(nil, WithSecureWipe[int, secret](),
+				WithHooks(Hooks[int, secret]{OnDelete: func(_ int, v secret) { deleted = append(deleted, v) }}))
+			fill := func() {
+				for i := 0; i < 100; i++ {
+					sl.Insert(i, token)
+				}
+			}
+
+			fill()
+			ns := nodes(sl)
+			sl.Delete(5)
+			sl.DeleteRange(10, 19)
+			wiped(t, "Delete", ns[5:6])
+			wiped(t, "DeleteRange", ns[10:20])
+			if len(deleted) != 11 || deleted[0] != token {
+				t.Errorf("OnDelete saw %d values, first %v", len(deleted), deleted[0])
+			}
+			if n, _ := sl.Search(50); n.Value() != token {
+				t.Fatalf("Search(50) = %v", n.Value())
+			}
+
+			ns = nodes(sl)
+			sl.Clear()
+			wiped(t, "Clear", ns)
+
+			fill()
+			ns = nodes(sl)
+			sl.Rotate().Release()
+			wiped(t, "Release", ns)
+
+			fill()
+			ns = nodes(sl)
+			if err := sl.MigrateAllocator(); err != nil {
+				t.Fatal(err)
+			}
+			wiped(t, "MigrateAllocator", ns)
+			if n, ok := sl.Search(99); !ok || n.Value() != token {
+				t.Errorf("Search(99) after MigrateAllocator = %v, %v", n, ok)
+			}
+		})
+	}
+}
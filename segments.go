@@ -0,0 +1,159 @@
+package skiplist
+
+// Integer is the set of integer key types accepted by SegmentSet.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// SegmentSet is a set of integer points stored as run-length segments, e.g.
+// the allocated block ranges of a file or the used ports of a host. Each
+// segment is kept as a single entry of the underlying skiplist, mapping its
+// first point to its last one (both inclusive). InsertRange coalesces
+// overlapping and adjacent ranges, so the segments are always disjoint and
+// separated by at least one point that is not in the set.
+//
+// All methods are safe for concurrent use; each one runs under a single lock
+// of the underlying skiplist.
+//
+// SegmentSet คือเซตของจำนวนเต็มที่เก็บเป็นช่วงต่อเนื่อง (segment)
+// ช่วงที่ซ้อนทับหรือติดกันจะถูกรวมเป็นช่วงเดียวโดยอัตโนมัติ
+type SegmentSet[K Integer] struct {
+	sl *SkipList[K, K]
+}
+
+// NewSegmentSet creates an empty SegmentSet. opts configure the underlying
+// skiplist, whose keys are segment starts and values segment ends.
+// NewSegmentSet สร้าง SegmentSet ใหม่ที่ว่างเปล่า
+func NewSegmentSet[K Integer](opts ...Option[K, K]) *SegmentSet[K] {
+	return &SegmentSet[K]{sl: New(opts...)}
+}
+
+// InsertRange adds every point between start and end, inclusive. Segments that
+// overlap the range or touch it (end+1 or start-1 is their boundary) are merged
+// with it into a single segment. It panics if start is greater than end.
+// InsertRange เพิ่มทุกจุดตั้งแต่ start ถึง end (รวมทั้งสองค่า) และรวมช่วงที่ซ้อนทับหรือติดกัน
+func (s *SegmentSet[K]) InsertRange(start, end K) {
+	if start > end {
+		panic("skiplist: segment start must not be greater than its end")
+	}
+	sl := s.sl
+	tr := sl.traceStart(OpInsert)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	// Absorb the segment starting at or before start if it reaches start-1.
+	// The end+1 == start test cannot overflow when it matters: an end at the
+	// largest value is always >= start.
+	if n := s.floor(start); n != nil && (n.value >= start || n.value+1 == start) {
+		start = n.key
+		end = max(end, n.value)
+		sl.delete(n.key)
+		tr.keys++
+	}
+	// Absorb every following segment that starts at or before end+1.
+	for n := sl.findGreaterOrEqual(start); n != nil && (n.key <= end || n.key == end+1); n = sl.findGreaterOrEqual(start) {
+		end = max(end, n.value)
+		sl.delete(n.key)
+		tr.keys++
+	}
+	sl.insert(start, end)
+	tr.keys++
+}
+
+// ContainsPoint reports whether p lies in one of the segments.
+// ContainsPoint ตรวจสอบว่า p อยู่ในช่วงใดช่วงหนึ่งหรือไม่
+func (s *SegmentSet[K]) ContainsPoint(p K) bool {
+	_, _, ok := s.Segment(p)
+	return ok
+}
+
+// Segment returns the bounds of the segment containing p, if any.
+// Segment คืนค่าขอบเขตของช่วงที่มี p อยู่
+func (s *SegmentSet[K]) Segment(p K) (start, end K, ok bool) {
+	sl := s.sl
+	tr := sl.traceStart(OpSearch)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	if n := s.floor(p); n != nil && n.value >= p {
+		tr.keys = 1
+		return n.key, n.value, true
+	}
+	return start, end, false
+}
+
+// Gaps calls f with the bounds (both inclusive) of every maximal run of points
+// between lo and hi that is not covered by a segment, in ascending order, until
+// f returns false. f is called with the read lock held and must not modify the
+// set.
+// Gaps เรียก f สำหรับทุกช่วงว่างระหว่าง lo ถึง hi ที่ไม่อยู่ในเซต เรียงจากน้อยไปมาก
+func (s *SegmentSet[K]) Gaps(lo, hi K, f func(start, end K) bool) {
+	defer rethrowCallbackPanic("Gaps")
+	if lo > hi {
+		return
+	}
+	sl := s.sl
+	tr := sl.traceStart(OpRangeQuery)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	// next is the first point not yet known to be covered.
+	next := lo
+	if n := s.floor(lo); n != nil && n.value >= lo {
+		if n.value >= hi {
+			return
+		}
+		next = n.value + 1
+	}
+	for n := sl.findGreaterOrEqual(next); n != nil && n.key <= hi; n = n.forward[0] {
+		tr.keys++
+		if !f(next, n.key-1) {
+			return
+		}
+		if n.value >= hi {
+			return
+		}
+		next = n.value + 1
+	}
+	f(next, hi)
+}
+
+// Segments calls f with the bounds of every segment in ascending order, until
+// f returns false.
+// Segments เรียก f สำหรับทุกช่วงในเซต เรียงจากน้อยไปมาก
+func (s *SegmentSet[K]) Segments(f func(start, end K) bool) {
+	s.sl.Range(f)
+}
+
+// Len returns the number of segments.
+// Len คืนค่าจำนวนช่วงในเซต
+func (s *SegmentSet[K]) Len() int {
+	return s.sl.Len()
+}
+
+// floor returns the segment with the largest start that is less than or equal
+// to p, or nil. The caller must hold a lock.
+func (s *SegmentSet[K]) floor(p K) *node[K, K] {
+	sl := s.sl
+	n := sl.findGreaterOrEqual(p)
+	if n != nil && n.key == p {
+		return n
+	}
+	if n == nil {
+		if sl.length == 0 {
+			return nil
+		}
+		return sl.last()
+	}
+	if n.backward == sl.header {
+		return nil
+	}
+	return n.backward
+}
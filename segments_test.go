@@ -0,0 +1,153 @@
+package skiplist
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+type segment[K Integer] struct{ start, end K }
+
+func segmentsOf[K Integer](s *SegmentSet[K]) []segment[K] {
+	var got []segment[K]
+	s.Segments(func(start, end K) bool {
+		got = append(got, segment[K]{start, end})
+		return true
+	})
+	return got
+}
+
+func gapsOf[K Integer](s *SegmentSet[K], lo, hi K) []segment[K] {
+	var got []segment[K]
+	s.Gaps(lo, hi, func(start, end K) bool {
+		got = append(got, segment[K]{start, end})
+		return true
+	})
+	return got
+}
+
+func TestSegmentSet_Coalescing(t *testing.T) {
+	s := NewSegmentSet[int]()
+	s.InsertRange(10, 19)
+	s.InsertRange(30, 39)
+	s.InsertRange(50, 59)
+	if got, want := segmentsOf(s), []segment[int]{{10, 19}, {30, 39}, {50, 59}}; !slices.Equal(got, want) {
+		t.Fatalf("segments = %v, want %v", got, want)
+	}
+
+	s.InsertRange(20, 22) // adjacent to [10, 19]
+	s.InsertRange(29, 29) // adjacent to [30, 39]
+	if got, want := segmentsOf(s), []segment[int]{{10, 22}, {29, 39}, {50, 59}}; !slices.Equal(got, want) {
+		t.Fatalf("segments after adjacent inserts = %v, want %v", got, want)
+	}
+
+	s.InsertRange(15, 55) // overlaps all three
+	if got, want := segmentsOf(s), []segment[int]{{10, 59}}; !slices.Equal(got, want) {
+		t.Fatalf("segments after an overlapping insert = %v, want %v", got, want)
+	}
+	s.InsertRange(12, 14) // already covered
+	if s.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", s.Len())
+	}
+
+	for _, tt := range []struct {
+		p    int
+		want bool
+	}{{9, false}, {10, true}, {35, true}, {59, true}, {60, false}} {
+		if got := s.ContainsPoint(tt.p); got != tt.want {
+			t.Errorf("ContainsPoint(%d) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if start, end, ok := s.Segment(42); !ok || start != 10 || end != 59 {
+		t.Errorf("Segment(42) = %d, %d, %v, want 10, 59, true", start, end, ok)
+	}
+}
+
+func TestSegmentSet_Gaps(t *testing.T) {
+	s := NewSegmentSet[int]()
+	if got, want := gapsOf(s, 0, 9), []segment[int]{{0, 9}}; !slices.Equal(got, want) {
+		t.Errorf("gaps of an empty set = %v, want %v", got, want)
+	}
+	s.InsertRange(10, 19)
+	s.InsertRange(30, 39)
+
+	tests := []struct {
+		lo, hi int
+		want   []segment[int]
+	}{
+		{0, 50, []segment[int]{{0, 9}, {20, 29}, {40, 50}}},
+		{15, 35, []segment[int]{{20, 29}}},
+		{12, 18, nil},
+		{20, 29, []segment[int]{{20, 29}}},
+		{19, 30, []segment[int]{{20, 29}}},
+		{5, 10, []segment[int]{{5, 9}}},
+	}
+	for _, tt := range tests {
+		if got := gapsOf(s, tt.lo, tt.hi); !slices.Equal(got, tt.want) {
+			t.Errorf("Gaps(%d, %d) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+		}
+	}
+
+	calls := 0
+	s.Gaps(0, 50, func(int, int) bool { calls++; return false })
+	if calls != 1 {
+		t.Errorf("Gaps called f %d times after it returned false, want 1", calls)
+	}
+}
+
+func TestSegmentSet_Bounds(t *testing.T) {
+	s := NewSegmentSet[uint8]()
+	s.InsertRange(250, math.MaxUint8)
+	s.InsertRange(0, 3)
+	s.InsertRange(4, 4)
+	if got, want := segmentsOf(s), []segment[uint8]{{0, 4}, {250, 255}}; !slices.Equal(got, want) {
+		t.Fatalf("segments = %v, want %v", got, want)
+	}
+	if got, want := gapsOf(s, 0, math.MaxUint8), []segment[uint8]{{5, 249}}; !slices.Equal(got, want) {
+		t.Errorf("gaps = %v, want %v", got, want)
+	}
+	s.InsertRange(5, 249)
+	if got := gapsOf(s, 0, math.MaxUint8); got != nil {
+		t.Errorf("gaps of a full set = %v, want none", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("InsertRange with start > end did not panic")
+		}
+	}()
+	s.InsertRange(2, 1)
+}
+
+// TestSegmentSet_Randomized checks InsertRange, ContainsPoint and Gaps against
+// a bitmap model.
+func TestSegmentSet_Randomized(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	s := NewSegmentSet[int]()
+	var model [400]bool
+	for step := 0; step < 300; step++ {
+		start := r.IntN(len(model))
+		end := min(start+r.IntN(8), len(model)-1)
+		s.InsertRange(start, end)
+		for p := start; p <= end; p++ {
+			model[p] = true
+		}
+
+		var want []segment[int]
+		for p := 0; p < len(model); p++ {
+			if got := s.ContainsPoint(p); got != model[p] {
+				t.Fatalf("step %d: ContainsPoint(%d) = %v, want %v", step, p, got, model[p])
+			}
+			if !model[p] && (p == 0 || model[p-1]) {
+				want = append(want, segment[int]{p, p})
+			}
+			if !model[p] {
+				want[len(want)-1].end = p
+			}
+		}
+		if got := gapsOf(s, 0, len(model)-1); !slices.Equal(got, want) {
+			t.Fatalf("step %d: gaps = %v, want %v", step, got, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package skiplist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEntryTooLarge is returned, or raised as a panic by Insert, when an entry
+// is larger than the limit set by WithEntryByteLimit.
+var ErrEntryTooLarge = errors.New("skiplist: entry exceeds the byte limit")
+
+// WithSizeFunc makes the list keep the total logical size of its entries, as
+// computed by size from each key and value (e.g. len(key)+len(value) for
+// byte slices), exposed by Bytes. An LSM memtable can then rotate when it
+// holds a given number of bytes rather than entries.
+//
+// size must be deterministic and is called again when a value is replaced or
+// a key is removed. Like the weight of WithWeights, it is called with the
+// write lock held, before the list is modified, and must not call back into
+// the skiplist.
+// WithSizeFunc กำหนดฟังก์ชันคำนวณขนาดของแต่ละรายการ เพื่อเก็บขนาดรวมของข้อมูลไว้ใน Bytes
+func WithSizeFunc[K any, V any](size func(K, V) int) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.sizeOf = size
+	}
+}
+
+// WithEntryByteLimit rejects the entries whose size, as computed by the
+// function of WithSizeFunc, exceeds limit bytes: TryInsert, BulkLoad and
+// Load return an error wrapping ErrEntryTooLarge, and Insert panics with it.
+// The list is left unchanged, and an existing key keeps its previous value.
+// It has no effect without WithSizeFunc or if limit <= 0.
+// WithEntryByteLimit ปฏิเสธรายการที่มีขนาดเกิน limit byte
+func WithEntryByteLimit[K any, V any](limit int) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.entryLimit = limit
+	}
+}
+
+// Bytes returns the total size of the entries as computed by the function
+// of WithSizeFunc, or 0 if none was given. Like Len, it does not take the
+// lock.
+// Bytes คืนค่าขนาดรวมของข้อมูลทั้งหมดตาม WithSizeFunc โดยไม่ต้อง lock
+func (sl *SkipList[K, V]) Bytes() int {
+	return int(sl.bytes.Load())
+}
+
+// entrySize returns the size of an entry about to be written, or an error
+// if it exceeds the limit of WithEntryByteLimit. sl.sizeOf must be set.
+func (sl *SkipList[K, V]) entrySize(key K, value V) (int, error) {
+	n := sl.sizeOf(key, value)
+	if sl.entryLimit > 0 && n > sl.entryLimit {
+		return 0, fmt.Errorf("%w: %d bytes, limit %d", ErrEntryTooLarge, n, sl.entryLimit)
+	}
+	return n, nil
+}
@@ -0,0 +1,80 @@
+package skiplist
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithSizeFunc(t *testing.T) {
+	size := func(k string, v []byte) int { return len(k) + len(v) }
+	for _, setup := range getTestSetups[string, []byte]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil, WithSizeFunc(size))
+			sl.Insert("a", make([]byte, 10))  // 11
+			sl.Insert("bb", make([]byte, 20)) // 22
+			sl.Insert("ccc", nil)             // 3
+			if got := sl.Bytes(); got != 36 {
+				t.Fatalf("Bytes() = %d, want 36", got)
+			}
+			sl.Insert("a", make([]byte, 4)) // 11 -> 5
+			if got := sl.Bytes(); got != 30 {
+				t.Fatalf("Bytes() after update = %d, want 30", got)
+			}
+			sl.Delete("bb")
+			sl.PopMax()
+			if got := sl.Bytes(); got != 5 {
+				t.Fatalf("Bytes() after deletes = %d, want 5", got)
+			}
+
+			keys := []string{"d", "e", "f"}
+			i := 0
+			if _, err := sl.BulkLoad(func() (string, []byte, bool) {
+				if i == len(keys) {
+					return "", nil, false
+				}
+				i++
+				return keys[i-1], make([]byte, 9), true
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if got := sl.Bytes(); got != 35 {
+				t.Fatalf("Bytes() after BulkLoad = %d, want 35", got)
+			}
+
+			frozen := sl.Rotate()
+			if sl.Bytes() != 0 || frozen.Bytes() != 35 {
+				t.Fatalf("after Rotate: Bytes() = %d, frozen %d", sl.Bytes(), frozen.Bytes())
+			}
+			frozen.Release()
+			sl.Insert("x", nil)
+			sl.Clear()
+			if sl.Bytes() != 0 {
+				t.Fatalf("Bytes() after Clear = %d", sl.Bytes())
+			}
+		})
+	}
+}
+
+func TestWithEntryByteLimit(t *testing.T) {
+	sl := New[string, []byte](
+		WithSizeFunc(func(k string, v []byte) int { return len(k) + len(v) }),
+		WithEntryByteLimit[string, []byte](8))
+	if _, err := sl.TryInsert("key", make([]byte, 5)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sl.TryInsert("key", make([]byte, 6)); !errors.Is(err, ErrEntryTooLarge) {
+		t.Fatalf("oversized update: err = %v", err)
+	}
+	if _, err := sl.TryInsert("other", make([]byte, 6)); !errors.Is(err, ErrEntryTooLarge) {
+		t.Fatalf("oversized insert: err = %v", err)
+	}
+	if n, _ := sl.Search("key"); len(n.Value()) != 5 || sl.Len() != 1 || sl.Bytes() != 8 {
+		t.Fatalf("list changed by rejected writes: Len() = %d, Bytes() = %d", sl.Len(), sl.Bytes())
+	}
+	if _, err := sl.BulkLoad(func() (string, []byte, bool) { return "z", make([]byte, 8), true }); !errors.Is(err, ErrEntryTooLarge) {
+		t.Fatalf("BulkLoad: err = %v", err)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
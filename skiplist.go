@@ -7,7 +7,6 @@ package skiplist
 import (
 	"cmp" // Re-add cmp for default comparator
 	"math/rand/v2"
-	"sync"
 	"sync/atomic"
 )
 
@@ -30,6 +29,14 @@ const (
 //   - zero if a == b
 //   - a positive value if a > b
 //
+// It must define a total order: compare(a, a) == 0, compare(a, b) and
+// compare(b, a) have opposite signs, and the order is transitive. Keys for
+// which it returns 0 are the same key. Iteration then visits the keys in
+// strictly ascending order, which only depends on the set of keys, not on
+// the order in which they were inserted nor on the random node heights. A
+// comparator breaking these rules corrupts the order silently; see
+// WithComparatorCheck to detect it.
+//
 // Comparator คือฟังก์ชันสำหรับเปรียบเทียบ key สองตัว
 // ควรคืนค่า:
 //
@@ -43,11 +50,13 @@ type Comparator[K any] func(a, b K) int
 // SkipList คือโครงสร้างหลักของ skiplist
 // ค่า zero value ของ SkipList จะยังไม่พร้อมใช้งาน, ต้องสร้างผ่านฟังก์ชัน New... เท่านั้น
 type SkipList[K any, V any] struct {
+	noCopy               noCopy              // ให้ go vet ตรวจจับการคัดลอก SkipList
 	header               *node[K, V]         // โหนดเริ่มต้น (sentinel node)
 	level                int                 // ชั้นสูงสุดที่มีอยู่ในปัจจุบัน
 	length               int                 // จำนวนรายการทั้งหมดใน skiplist
+	size                 atomic.Int64        // สำเนาของ length สำหรับ Len และ IsEmpty ที่อ่านได้โดยไม่ต้อง lock
 	rand                 *rand.Rand          // ตัวสร้างเลขสุ่มสำหรับกำหนดชั้น
-	mutex                sync.RWMutex        // Mutex สำหรับการทำงานแบบ concurrent-safe
+	mutex                listLock            // Mutex สำหรับการทำงานแบบ concurrent-safe (ดู WithAdaptiveLocking)
 	updateCacheRanks     []int               // แคชสำหรับ rank ที่ใช้ใน Insert
 	updateCache          []INode[K, V]       // แคชสำหรับ update path
 	allocator            nodeAllocator[K, V] // Abstraction สำหรับการจัดสรรหน่วยความจำ
@@ -55,9 +64,71 @@ type SkipList[K any, V any] struct {
 	arenaGrowthFactor    float64             // สัดส่วนการขยาย Arena (ถ้าใช้)
 	arenaGrowthBytes     int                 // ขนาด byte คงที่ในการขยาย Arena (ถ้าใช้)
 	arenaGrowthThreshold float64             // Threshold สำหรับการขยาย Arena ล่วงหน้า (ถ้าใช้)
+	arenaNodePadding     int                 // จำนวน byte ที่เว้นว่างระหว่างโหนดใน Arena (ถ้าใช้)
+	arenaAlignment       int                 // การจัดตำแหน่ง address ของโหนดใน Arena เป็น byte (ถ้าใช้)
+	arenaHugePages       bool                // true เมื่อขอให้ใช้ huge page กับ chunk ขนาดใหญ่ (WithArenaHugePages)
+	arenaFixed           bool                // true เมื่อ Arena มีขนาดคงที่และห้ามขยายเกิน (WithFixedArena)
 	compare              Comparator[K]       // ฟังก์ชันสำหรับเปรียบเทียบ key
+
+	tracer    Tracer                    // ตัวรับ callback สำหรับ tracing (ถ้ามี)
+	version   uint64                    // เพิ่มขึ้นทุกครั้งที่มีการแก้ไขข้อมูล
+	migrating atomic.Bool               // true ระหว่างที่ MigrateAllocator กำลังทำงาน
+	history   versionStore[K, V]        // ประวัติของแต่ละ key เมื่อเปิดใช้ WithMVCC
+	lww       *SkipList[K, lwwStamp]    // timestamp ของแต่ละ key เมื่อเปิดใช้ WithLWW
+	lwwClock  func() uint64             // นาฬิกาสำหรับ timestamp ของ WithLWW
+	lwwTS     uint64                    // timestamp ที่กำหนดโดย operation ปัจจุบัน (0 = ใช้ lwwClock)
+	secondary secondaryIndex[K, V]      // index รองที่เรียงตาม value (ถ้ามี)
+	codec     Codec[K, V]               // codec สำหรับ Save/Load (nil = GobCodec)
+	hooks     Hooks[K, V]               // callback ที่เรียกหลังการแก้ไขข้อมูล
+	changes   *SkipList[K, changeStamp] // version ล่าสุดที่แต่ละ key ถูกแก้ไขเมื่อเปิดใช้ WithChangeTracking
+	byteKeys  bool                      // true เมื่อสร้างด้วย NewBytes (K คือ []byte)
+	keyPrefix func(K) uint64            // ฟังก์ชันคำนวณ prefix ของ key ที่เก็บไว้ในโหนด (ถ้ามี)
+	weight    func(K, V) int            // ฟังก์ชันคำนวณน้ำหนักของแต่ละรายการเมื่อเปิดใช้ WithWeights
+	weights   int                       // ผลรวมน้ำหนักของทุกรายการเมื่อเปิดใช้ WithWeights
+	wranks    []int                     // แคชสำหรับผลรวมน้ำหนักที่ใช้ใน Insert เมื่อเปิดใช้ WithWeights
+	backLinks bool                      // true เมื่อเปิดใช้ WithBidirectionalLevels
+	merkle    func(K, V) uint64         // ฟังก์ชันคำนวณ hash ของแต่ละรายการเมื่อเปิดใช้ WithMerkle
+	hashes    uint64                    // ผลรวม hash ของทุกรายการเมื่อเปิดใช้ WithMerkle
+	hranks    []uint64                  // แคชสำหรับผลรวม hash ที่ใช้ใน Insert เมื่อเปิดใช้ WithMerkle
+	spare     nodeAllocator[K, V]       // arena ที่คืนมาจาก FrozenSkipList เพื่อใช้ใน Rotate ครั้งถัดไป
+	hot       *hotCache[K, V]           // cache ของโหนดที่ถูกค้นหาบ่อยเมื่อเปิดใช้ WithHotCache
+	frozen    bool                      // true เมื่อถูก Freeze ห้ามแก้ไขข้อมูล
+
+	validateKey      func(K) error                     // ฟังก์ชันตรวจสอบ key ก่อนเพิ่มข้อมูล (WithKeyValidator)
+	watermarks       []*usageWatermark                 // ระดับการใช้ Arena ที่ต้องแจ้งเตือน (WithUsageWatermark)
+	lockAudit        *lockAudit                        // การตรวจวัดระยะเวลาที่ถือ lock (WithLockAudit)
+	gen              uint64                            // generation ล่าสุดที่กำหนดให้โหนดใหม่ (ดู Handle)
+	epoch            uint64                            // เพิ่มขึ้นเมื่อ Clear, Rotate หรือ MigrateAllocator ทำให้ Handle เดิมใช้ไม่ได้
+	growth           func(ArenaGrowth)                 // callback เมื่อ Arena ขยาย (WithGrowthObserver)
+	cmpCheck         *comparatorCheck                  // การสุ่มตรวจฟังก์ชันเปรียบเทียบ (WithComparatorCheck)
+	sizeOf           func(K, V) int                    // ฟังก์ชันคำนวณขนาดของแต่ละรายการ (WithSizeFunc)
+	entryLimit       int                               // ขนาดสูงสุดของแต่ละรายการเป็น byte (WithEntryByteLimit)
+	bytes            atomic.Int64                      // ขนาดรวมของทุกรายการตาม sizeOf สำหรับ Bytes
+	batching         bool                              // true ระหว่างการลบหลายรายการที่แจ้งผ่าน OnDeleteRange แทน OnDelete
+	rangeLocks       rangeLockTable[K]                 // lock ของช่วง key ที่ถืออยู่ (LockRange)
+	capacity         int                               // จำนวนรายการสูงสุด (WithCapacity), 0 = ไม่จำกัด
+	eviction         EvictionPolicy[K, V]              // policy เลือกรายการที่จะนำออกเมื่อเต็ม
+	published        atomic.Pointer[PublishedStats[K]] // สรุปสถานะล่าสุดที่เผยแพร่ (WithPublishedStats)
+	moving           bool                              // true ระหว่าง Move ซึ่งแจ้ง OnRankChange เพียงครั้งเดียว
+	entryTags        bool                              // true เมื่อเปิดใช้ WithEntryTags
+	snapshots        snapshotRegistry                  // snapshot ที่ยังไม่ถูกปล่อย (ListSnapshots)
+	noRanks          bool                              // true เมื่อเปิดใช้ WithoutRankTracking (ไม่เก็บ span)
+	levelCompactions int                               // จำนวนครั้งที่ลดจำนวนชั้นหลังการลบจำนวนมาก (CompactLevels)
+	ids              map[uint64]*node[K, V]            // ตาราง ID ของรายการไปยังโหนดเมื่อเปิดใช้ WithIDIndex
+	secureWipe       bool                              // true เมื่อเปิดใช้ WithSecureWipe (ล้าง value ของโหนดที่ถูกนำออก)
+	latency          *latencySampler                   // ระยะเวลาของ operation ที่สุ่มเก็บไว้ (WithLatencySampling)
+	tagWrites        atomic.Uint64                     // จำนวนครั้งที่เรียก SetTag (ให้ MigrateAllocator ตรวจพบ tag ที่เปลี่ยน)
 }
 
+// noCopy makes go vet (copylocks) report a struct copied by value. It is
+// embedded in SkipList, whose copies would share the nodes while duplicating
+// the lock and counters, and in Iterator, whose copies would share the read
+// lock it may hold. Such values must be used through a pointer.
+type noCopy struct{}
+
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
+
 // Option is a function that configures a SkipList.
 // Option คือฟังก์ชันสำหรับกำหนดค่าของ SkipList
 type Option[K any, V any] func(*SkipList[K, V])
@@ -107,6 +178,39 @@ func WithArenaGrowthThreshold[K any, V any](threshold float64) Option[K, V] {
 	}
 }
 
+// WithNodePadding leaves at least bytes unused bytes between two nodes
+// allocated from the arena, e.g. 64 or 128 to keep adjacent nodes on separate
+// cache lines. This avoids false sharing between nodes read by different cores
+// (notably on multi-socket machines) at the cost of memory: each node occupies
+// a whole number of node-sized slots. Together with WithArenaGrowthBytes and
+// WithArena, sizes remain in bytes, so fewer nodes fit per chunk.
+// This option is only effective when used with WithArena.
+// WithNodePadding เว้นช่องว่างระหว่างโหนดใน Arena เพื่อลด false sharing ระหว่าง CPU core
+func WithNodePadding[K any, V any](bytes int) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		if bytes > 0 {
+			sl.arenaNodePadding = bytes
+		}
+	}
+}
+
+// WithFixedArena configures the SkipList to use a memory arena with a hard
+// budget of sizeInBytes. Unlike WithArena, the arena never grows beyond the
+// budget, which is split up front between the node size classes. Once the
+// class of a new node and all larger ones are exhausted, TryInsert and
+// BulkLoad return ErrArenaFull and Insert panics with it. Updates of existing keys still succeed, and deleted
+// nodes are only reclaimed by Clear, so a full list is typically flushed and
+// cleared, like the memtable of an LSM tree.
+// WithFixedArena กำหนดให้ SkipList ใช้ Arena ที่มีขนาดคงที่ เมื่อเต็มจะปฏิเสธการเพิ่มข้อมูลด้วย ErrArenaFull
+func WithFixedArena[K any, V any](sizeInBytes int) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		if sizeInBytes > 0 {
+			sl.arenaInitialSize = sizeInBytes
+			sl.arenaFixed = true
+		}
+	}
+}
+
 // New creates a new skiplist for key types that implement cmp.Ordered (e.g., int, string).
 // It uses cmp.Compare as the default comparator.
 // New สร้าง skiplist ใหม่สำหรับ key type ที่รองรับ `cmp.Ordered` (เช่น int, string)
@@ -152,23 +256,57 @@ func NewWithComparator[K any, V any](compare Comparator[K], opts ...Option[K, V]
 		opt(sl)
 	}
 
+	if sl.noRanks && sl.merkle != nil {
+		panic("skiplist: WithoutRankTracking cannot be combined with WithMerkle")
+	}
 	// After processing options, create the arena if requested.
 	if sl.arenaInitialSize > 0 {
-		var arenaOpts []ArenaOption
-		if sl.arenaGrowthBytes > 0 {
-			arenaOpts = append(arenaOpts, WithGrowthBytes(sl.arenaGrowthBytes))
-		}
-		if sl.arenaGrowthFactor > 1.0 {
-			arenaOpts = append(arenaOpts, WithGrowthFactor(sl.arenaGrowthFactor))
-		}
-		if sl.arenaGrowthThreshold > 0.0 {
-			arenaOpts = append(arenaOpts, WithGrowthThreshold(sl.arenaGrowthThreshold))
-		}
-		sl.allocator = newArenaAllocator[K, V](sl.arenaInitialSize, arenaOpts...)
+		sl.allocator = sl.newAllocator()
+	}
+	if sl.weight != nil {
+		sl.wranks = make([]int, MaxLevel)
+		header.wspan = make([]int, MaxLevel)
+	}
+	if sl.merkle != nil {
+		sl.hranks = make([]uint64, MaxLevel)
+		header.hspan = make([]uint64, MaxLevel)
 	}
 	return sl
 }
 
+// newAllocator creates a node allocator from the arena settings of sl.
+// It returns a pool allocator when no arena was requested.
+func (sl *SkipList[K, V]) newAllocator() nodeAllocator[K, V] {
+	if sl.arenaInitialSize <= 0 {
+		return newPoolAllocator[K, V]()
+	}
+	var arenaOpts []ArenaOption
+	if sl.arenaGrowthBytes > 0 {
+		arenaOpts = append(arenaOpts, WithGrowthBytes(sl.arenaGrowthBytes))
+	}
+	if sl.arenaGrowthFactor > 1.0 {
+		arenaOpts = append(arenaOpts, WithGrowthFactor(sl.arenaGrowthFactor))
+	}
+	if sl.arenaGrowthThreshold > 0.0 {
+		arenaOpts = append(arenaOpts, WithGrowthThreshold(sl.arenaGrowthThreshold))
+	}
+	if sl.arenaNodePadding > 0 {
+		arenaOpts = append(arenaOpts, WithPadding(sl.arenaNodePadding))
+	}
+	if sl.arenaAlignment > 0 {
+		arenaOpts = append(arenaOpts, WithAlignment(sl.arenaAlignment))
+	}
+	if sl.arenaHugePages {
+		arenaOpts = append(arenaOpts, WithHugePages())
+	}
+	if sl.arenaFixed {
+		arenaOpts = append(arenaOpts, WithFixedSize())
+	}
+	a := newArenaAllocator[K, V](sl.arenaInitialSize, arenaOpts...)
+	a.observe = sl.growth
+	return a
+}
+
 // randomLevel สุ่มความสูง (จำนวนชั้น) ของโหนดใหม่
 // โดยใช้วิธี bit-manipulation เพื่อประสิทธิภาพที่สูงขึ้น
 func (sl *SkipList[K, V]) randomLevel() int {
@@ -193,25 +331,28 @@ func (sl *SkipList[K, V]) randomLevel() int {
 // It returns the node and true if the key is found, otherwise it returns nil and false.
 // คืนค่าโหนดและ true หากพบ, มิฉะนั้นคืนค่า nil และ false
 func (sl *SkipList[K, V]) Search(key K) (INode[K, V], bool) {
+	tr := sl.traceStart(OpSearch)
 	sl.mutex.RLock()
+	tr.locked()
 	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
 
-	current := sl.header
-
-	// เริ่มค้นหาจากชั้นบนสุดลงมา
-	for i := sl.level; i >= 0; i-- {
-		// วิ่งไปข้างหน้าในชั้นปัจจุบันจนกว่าโหนดถัดไปจะมี key มากกว่าหรือเท่ากับ key ที่ค้นหา
-		for current.forward[i] != nil && sl.compare(current.forward[i].key, key) < 0 {
-			current = current.forward[i]
+	if sl.hot != nil {
+		if n := sl.hot.lookup(sl, key, sl.prefixOf(key)); n != nil {
+			tr.keys = 1
+			return n, true
 		}
 	}
 
-	// หลังจากลูปสิ้นสุด, current จะอยู่ที่โหนดก่อนหน้าโหนดที่อาจจะตรงกับ key
-	// เราจึงต้องเลื่อนไปข้างหน้าอีกหนึ่งตำแหน่งที่ชั้นล่างสุด (level 0)
-	current = current.forward[0]
+	// ค้นหาจากชั้นบนสุดลงมาจนถึงโหนดแรกที่มี key มากกว่าหรือเท่ากับ key ที่ค้นหา
+	current := sl.findGreaterOrEqual(key)
 
 	// ตรวจสอบว่าโหนดปัจจุบันคือโหนดที่ต้องการหรือไม่
 	if current != nil && sl.compare(current.key, key) == 0 {
+		if sl.hot != nil {
+			sl.hot.admit(current)
+		}
+		tr.keys = 1
 		return current, true
 	}
 
@@ -225,13 +366,65 @@ func (sl *SkipList[K, V]) Search(key K) (INode[K, V], bool) {
 // หาก key มีอยู่แล้ว จะทำการอัปเดต value และคืนค่าโหนดเก่า
 // หากเป็น key ใหม่ จะเพิ่มโหนดใหม่และคืนค่า nil
 func (sl *SkipList[K, V]) Insert(key K, value V) INode[K, V] {
+	tr := sl.traceStart(OpInsert)
 	sl.mutex.Lock()
+	tr.locked()
 	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
 
-	// update เป็น slice ที่เก็บโหนดที่จะต้องอัปเดตตัวชี้ forward
-	// ในแต่ละชั้นเมื่อมีการเพิ่มโหนดใหม่
+	tr.keys = 1
+	if n, existed := sl.insert(key, value); existed {
+		return n
+	}
+	return nil
+}
+
+// TryInsert is like Insert but returns ErrArenaFull, leaving the list
+// unchanged, when a new key does not fit in an arena created with
+// WithFixedArena, ErrFrozen when the list is frozen (see Freeze), and an
+// error wrapping ErrInvalidKey when the key validator rejects key.
+// TryInsert ทำงานเหมือน Insert แต่คืนค่า ErrArenaFull เมื่อ Arena ขนาดคงที่เต็ม
+func (sl *SkipList[K, V]) TryInsert(key K, value V) (INode[K, V], error) {
+	tr := sl.traceStart(OpInsert)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	n, existed, err := sl.tryInsert(key, value)
+	if err != nil {
+		return nil, err
+	}
+	tr.keys = 1
+	if existed {
+		return n, nil
+	}
+	return nil, nil
+}
+
+// insert เป็น helper ภายในที่จัดการตรรกะการเพิ่มหรืออัปเดตโหนด
+// insert adds or updates key and returns the affected node, along with
+// true if the key already existed (in which case only its value was replaced).
+// It panics with ErrArenaFull if a fixed arena has no room for a new node,
+// with ErrFrozen if the list is frozen and with the error of the key
+// validator (see WithKeyValidator) if it rejects key.
+// **หมายเหตุ**: ผู้เรียกต้องถือ write lock (sl.mutex.Lock()) อยู่แล้ว
+func (sl *SkipList[K, V]) insert(key K, value V) (*node[K, V], bool) {
+	n, existed, err := sl.tryInsert(key, value)
+	if err != nil {
+		panic(err)
+	}
+	return n, existed
+}
+
+// insertPath fills the update path of an insert of key (sl.updateCache and
+// the rank caches) and returns the node preceding key on the bottom level.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) insertPath(key K, kp uint64) *node[K, V] {
 	update := sl.updateCache
 	ranks := sl.updateCacheRanks
+	wranks := sl.wranks // nil เมื่อไม่ได้เปิดใช้ WithWeights
+	hranks := sl.hranks // nil เมื่อไม่ได้เปิดใช้ WithMerkle
 	current := sl.header
 
 	// ค้นหาตำแหน่งที่จะเพิ่มโหนดใหม่ พร้อมทั้งบันทึกโหนดที่จะต้องอัปเดต
@@ -243,29 +436,126 @@ func (sl *SkipList[K, V]) Insert(key K, value V) INode[K, V] {
 		} else {
 			ranks[i] = ranks[i+1]
 		}
+		if wranks != nil {
+			if i == sl.level {
+				wranks[i] = 0
+			} else {
+				wranks[i] = wranks[i+1]
+			}
+		}
+		if hranks != nil {
+			if i == sl.level {
+				hranks[i] = 0
+			} else {
+				hranks[i] = hranks[i+1]
+			}
+		}
 
-		for current.forward[i] != nil && sl.compare(current.forward[i].key, key) < 0 {
-			ranks[i] += current.span[i]
+		for current.forward[i] != nil && sl.compareNode(current.forward[i], key, kp) < 0 {
+			if !sl.noRanks {
+				ranks[i] += current.span[i]
+			}
+			if wranks != nil {
+				wranks[i] += current.wspan[i]
+			}
+			if hranks != nil {
+				hranks[i] += current.hspan[i]
+			}
 			current = current.forward[i]
 		}
 		update[i] = current
 	}
+	return current
+}
+
+// tryInsert implements insert, returning ErrArenaFull without modifying the
+// list when a fixed arena has no room for a new node.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) tryInsert(key K, value V) (*node[K, V], bool, error) {
+	if sl.frozen {
+		return nil, false, ErrFrozen
+	}
+	if err := sl.checkKey(key); err != nil {
+		return nil, false, err
+	}
+	// update เป็น slice ที่เก็บโหนดที่จะต้องอัปเดตตัวชี้ forward
+	// ในแต่ละชั้นเมื่อมีการเพิ่มโหนดใหม่
+	update := sl.updateCache
+	ranks := sl.updateCacheRanks
+	wranks := sl.wranks // nil เมื่อไม่ได้เปิดใช้ WithWeights
+	hranks := sl.hranks // nil เมื่อไม่ได้เปิดใช้ WithMerkle
+	kp := sl.prefixOf(key)
+	current := sl.insertPath(key, kp)
 
 	// rank ของโหนดก่อนหน้าคือ ranks[0]
 	// rank ของโหนดใหม่ (0-based) คือ ranks[0]
 
 	current = current.forward[0]
+	if sl.cmpCheck != nil {
+		if err := sl.checkComparator(key, update[0].(*node[K, V]), current); err != nil {
+			return nil, false, err
+		}
+	}
 
 	// ถ้า key มีอยู่แล้ว ให้อัปเดต value แล้วจบการทำงาน
 	if current != nil && sl.compare(current.key, key) == 0 {
-		old := current
+		var grow int
+		if sl.sizeOf != nil {
+			n, err := sl.entrySize(key, value)
+			if err != nil {
+				return nil, false, err
+			}
+			grow = n - sl.sizeOf(key, current.value)
+		}
+		sl.version++
+		old := current.value
+		if sl.weight != nil {
+			sl.reweigh(update, sl.weightOf(key, old), sl.weightOf(key, value))
+		}
+		if hranks != nil {
+			sl.rehash(update, sl.merkle(key, old), sl.merkle(key, value))
+		}
 		current.value = value
-		return old
+		sl.bytes.Add(int64(grow))
+		sl.onUpdated(key, old, value)
+		return current, true, nil
 	}
 
 	// ถ้า key ยังไม่มีอยู่ ให้สร้างโหนดใหม่
+	// The weight and hash are computed before the structure is modified, so
+	// that a panic in their functions leaves the list unchanged.
+	var w int
+	if sl.weight != nil {
+		w = sl.weightOf(key, value)
+	}
+	var h uint64
+	if hranks != nil {
+		h = sl.merkle(key, value)
+	}
+	var size int
+	if sl.sizeOf != nil {
+		var err error
+		if size, err = sl.entrySize(key, value); err != nil {
+			return nil, false, err
+		}
+	}
 	newLevel := sl.randomLevel()
 
+	// --- จัดสรรโหนดโดยใช้ Allocator ที่กำหนดไว้ ---
+	// การจัดสรรทำก่อนแก้ไขโครงสร้าง เพื่อให้ list ไม่เปลี่ยนแปลงเมื่อ Arena เต็ม
+	newNode := sl.allocator.Get(newLevel)
+	if newNode == nil {
+		return nil, false, ErrArenaFull
+	}
+	if sl.capacity > 0 && sl.length >= sl.capacity {
+		// The node is allocated first, so that an insert failing for lack
+		// of room evicts nothing. Evicting invalidates the update path:
+		// search again.
+		sl.evict()
+		sl.insertPath(key, kp)
+	}
+	sl.version++
+
 	// หากชั้นที่สุ่มได้สูงกว่าชั้นสูงสุดปัจจุบันของ skiplist
 	// ให้อัปเดตชั้นสูงสุดและตั้งค่า update สำหรับชั้นใหม่ๆ ให้ชี้มาจาก header
 	// newLevel is 1-based, sl.level is 0-based
@@ -278,27 +568,25 @@ func (sl *SkipList[K, V]) Insert(key K, value V) INode[K, V] {
 			// span ของ header ในชั้นใหม่นี้จะต้องครอบคลุมโหนดทั้งหมดที่มีอยู่
 			// เพราะ pointer ของมันจะชี้ไปที่ nil (ก่อนที่จะถูกเชื่อมกับโหนดใหม่)
 			// ดังนั้น span ของมันคือจำนวนโหนดทั้งหมดใน list
-			sl.header.span[i] = sl.length
+			if !sl.noRanks {
+				sl.header.span[i] = sl.length
+			}
+			if wranks != nil {
+				wranks[i] = 0
+				sl.header.wspan[i] = sl.weights
+			}
+			if hranks != nil {
+				hranks[i] = 0
+				sl.header.hspan[i] = sl.hashes
+			}
 		}
 		sl.level = newLevel - 1
 	}
 
-	// --- จัดสรรโหนดโดยใช้ Allocator ที่กำหนดไว้ ---
-	newNode := sl.allocator.Get()
-
-	// ตรวจสอบและปรับขนาดของ forward slice ให้เหมาะสมกับ level ใหม่
-	// สำหรับ Arena, `Get` จะคืนโหนดที่ `forward` เป็น nil และต้องสร้างใหม่เสมอ
-	// สำหรับ Pool, `Get` จะคืนโหนดที่อาจมี slice เก่ามาด้วย ซึ่งเราสามารถใช้ซ้ำได้
-	if cap(newNode.forward) < newLevel {
-		newNode.forward = make([]*node[K, V], newLevel)
-		newNode.span = make([]int, newLevel)
-	} else {
-		newNode.forward = newNode.forward[:newLevel]
-		newNode.span = newNode.span[:newLevel]
-	}
-
 	newNode.key = key
+	newNode.prefix = kp
 	newNode.value = value
+	sl.stamp(newNode)
 
 	// เชื่อมโหนดใหม่เข้ากับ skiplist ในแต่ละชั้น
 	// พร้อมทั้งอัปเดตค่า span
@@ -310,6 +598,9 @@ func (sl *SkipList[K, V]) Insert(key K, value V) INode[K, V] {
 		// เชื่อม forward pointer
 		newNode.forward[i] = cupdate.forward[i]
 		cupdate.forward[i] = newNode
+		if sl.noRanks {
+			continue
+		}
 
 		// อัปเดต span
 		// newSpan คือระยะห่างจาก cupdate ไปยัง newNode
@@ -320,9 +611,15 @@ func (sl *SkipList[K, V]) Insert(key K, value V) INode[K, V] {
 
 	// สำหรับชั้นที่สูงกว่า newLevel, เราแค่เพิ่ม span ของโหนดใน update path
 	// เพราะมีโหนดใหม่เพิ่มเข้ามาในเส้นทางนั้น
-	for i := newLevel; i <= sl.level; i++ {
+	for i := newLevel; i <= sl.level && !sl.noRanks; i++ {
 		update[i].(*node[K, V]).span[i]++
 	}
+	if wranks != nil {
+		sl.linkWeights(newNode, update, w)
+	}
+	if hranks != nil {
+		sl.linkHashes(newNode, update, h)
+	}
 
 	// ตั้งค่า backward pointer สำหรับ doubly-linked list ที่ชั้น 0
 	// Set up backward pointer for the doubly-linked list at level 0
@@ -330,9 +627,88 @@ func (sl *SkipList[K, V]) Insert(key K, value V) INode[K, V] {
 	if newNode.forward[0] != nil {
 		newNode.forward[0].backward = newNode
 	}
+	if sl.backLinks {
+		sl.linkBack(newNode, update)
+	}
 
-	sl.length++
-	return nil
+	rank := ranks[0]
+	if sl.noRanks && sl.hooks.OnRankChange != nil && !sl.moving {
+		rank = sl.rank(key, false)
+	}
+	sl.setLength(sl.length + 1)
+	sl.bytes.Add(int64(size))
+	sl.onInserted(key, value)
+	if sl.hooks.OnRankChange != nil && !sl.moving {
+		sl.hooks.onRankChange(key, -1, rank)
+	}
+	if sl.hooks.OnBoundsChange != nil && (newNode.backward == sl.header || newNode.forward[0] == nil) {
+		sl.boundsChanged()
+	}
+	if sl.watermarks != nil {
+		sl.checkWatermarks()
+	}
+	return newNode, false, nil
+}
+
+// onInserted runs the optional bookkeeping attached to a newly inserted key.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) onInserted(key K, value V) {
+	if sl.history != nil {
+		sl.history.record(key, sl.version, value, false)
+	}
+	if sl.lww != nil {
+		sl.stampLWW(key, false)
+	}
+	if sl.secondary != nil {
+		sl.secondary.add(key, value)
+	}
+	if sl.changes != nil {
+		sl.recordChange(key, false)
+	}
+	if sl.hooks.OnInsert != nil {
+		sl.hooks.onInsert(key, value)
+	}
+}
+
+// onUpdated runs the optional bookkeeping attached to a key whose value was
+// replaced. The caller must hold the write lock.
+func (sl *SkipList[K, V]) onUpdated(key K, old, value V) {
+	if sl.secondary != nil {
+		sl.secondary.replace(key, old, value)
+	}
+	if sl.history != nil {
+		sl.history.record(key, sl.version, value, false)
+	}
+	if sl.lww != nil {
+		sl.stampLWW(key, false)
+	}
+	if sl.changes != nil {
+		sl.recordChange(key, false)
+	}
+	if sl.hooks.OnUpdate != nil && !sl.moving {
+		sl.hooks.onUpdate(key, old, value)
+	}
+}
+
+// onDeleted runs the optional bookkeeping attached to a removed key.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) onDeleted(key K, value V) {
+	if sl.history != nil {
+		var zero V
+		sl.history.record(key, sl.version, zero, true)
+	}
+	if sl.lww != nil {
+		sl.stampLWW(key, true)
+	}
+	if sl.secondary != nil {
+		sl.secondary.remove(key, value)
+	}
+	if sl.changes != nil {
+		sl.recordChange(key, true)
+	}
+	if sl.hooks.OnDelete != nil && !sl.batching {
+		sl.hooks.onDelete(key, value)
+	}
 }
 
 // deleteNode เป็น helper ภายในที่จัดการตรรกะการลบโหนด
@@ -344,15 +720,47 @@ func (sl *SkipList[K, V]) deleteNode(nodeToRemove INode[K, V], update []INode[K,
 	if !ok {
 		return
 	}
+	sl.mustNotBeFrozen()
+	key, value := cnodeRemove.key, cnodeRemove.value
+	var w int
+	if sl.weight != nil {
+		w = sl.weightOf(key, value)
+	}
+	var h uint64
+	if sl.merkle != nil {
+		h = sl.merkle(key, value)
+	}
+	var size int
+	if sl.sizeOf != nil {
+		size = sl.sizeOf(key, value)
+	}
+	oldRank := -1
+	if sl.hooks.OnRankChange != nil && !sl.batching && !sl.moving {
+		oldRank = sl.rank(key, false)
+	}
+	sl.version++
+	if sl.ids != nil {
+		delete(sl.ids, cnodeRemove.gen)
+	}
+	cnodeRemove.gen = 0
+	atBound := cnodeRemove.backward == sl.header || cnodeRemove.forward[0] == nil
+	if sl.weight != nil {
+		sl.unlinkWeights(cnodeRemove, update, w)
+	}
+	if sl.merkle != nil {
+		sl.unlinkHashes(cnodeRemove, update, h)
+	}
 
 	for i := 0; i <= sl.level; i++ {
 		cupdate, _ := update[i].(*node[K, V])
 		if cupdate.forward[i] == cnodeRemove {
 			// ถ้าโหนดใน update path ชี้ไปยังโหนดที่จะลบโดยตรง
 			// ให้รวม span ของโหนดที่ถูกลบเข้ามา แล้วลบออก 1 (ตัวโหนดเอง)
-			cupdate.span[i] += cnodeRemove.span[i] - 1
+			if !sl.noRanks {
+				cupdate.span[i] += cnodeRemove.span[i] - 1
+			}
 			cupdate.forward[i] = cnodeRemove.forward[i]
-		} else {
+		} else if !sl.noRanks {
 			// ถ้าโหนดใน update path อยู่ในชั้นที่สูงกว่าโหนดที่จะลบ
 			// และไม่ได้ชี้ไปยังโหนดนั้นโดยตรง (ทางเดินมัน "ข้าม" โหนดที่จะลบไป)
 			// เราแค่ลด span ลง 1 เพราะมีโหนดหายไปจาก list
@@ -372,13 +780,34 @@ func (sl *SkipList[K, V]) deleteNode(nodeToRemove INode[K, V], update []INode[K,
 	if cnodeRemove.forward[0] != nil {
 		cnodeRemove.forward[0].backward = cnodeRemove.backward
 	}
+	if sl.backLinks {
+		unlinkBack(cnodeRemove)
+	}
+
+	if sl.hot != nil {
+		sl.hot.evict(cnodeRemove)
+	}
+
+	if sl.secureWipe {
+		var zero V
+		cnodeRemove.value = zero
+	}
 
 	// คืนโหนดกลับเข้า Allocator
 	// สำหรับ Arena, Put() อาจจะไม่ทำอะไรเลย เพราะหน่วยความจำจะถูกเคลียร์ทีเดียวตอน Reset()
 	// สำหรับ Pool, Put() จะทำการเคลียร์ค่าและคืนโหนดกลับเข้า Pool
 	sl.allocator.Put(cnodeRemove)
 
-	sl.length--
+	sl.setLength(sl.length - 1)
+	sl.bytes.Add(-int64(size))
+	// Hooks run once the node is fully removed, see CallbackPanic.
+	sl.onDeleted(key, value)
+	if oldRank >= 0 {
+		sl.hooks.onRankChange(key, oldRank, -1)
+	}
+	if atBound && sl.hooks.OnBoundsChange != nil && !sl.batching {
+		sl.boundsChanged()
+	}
 }
 
 // Delete ลบ key-value ออกจาก skiplist
@@ -386,15 +815,35 @@ func (sl *SkipList[K, V]) deleteNode(nodeToRemove INode[K, V], update []INode[K,
 // It returns true if the key was found and removed, otherwise false.
 // คืนค่า true หากลบสำเร็จ, false หากไม่พบ key
 func (sl *SkipList[K, V]) Delete(key K) bool {
+	tr := sl.traceStart(OpDelete)
 	sl.mutex.Lock()
+	tr.locked()
 	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	if sl.delete(key) {
+		tr.keys = 1
+		return true
+	}
+	if sl.lww != nil {
+		// A delete is a write in LWW mode even if the key is absent locally.
+		sl.stampLWW(key, true)
+	}
+	return false
+}
 
+// delete ค้นหาและลบโหนดที่มี key ตรงกับที่กำหนด
+// delete removes key and reports whether it was present.
+// **หมายเหตุ**: ผู้เรียกต้องถือ write lock (sl.mutex.Lock()) อยู่แล้ว
+func (sl *SkipList[K, V]) delete(key K) bool {
+	sl.mustNotBeFrozen()
 	update := sl.updateCache
 	current := sl.header
+	kp := sl.prefixOf(key)
 
 	// ค้นหาโหนดที่จะลบ พร้อมทั้งบันทึกโหนดที่จะต้องอัปเดต
 	for i := sl.level; i >= 0; i-- {
-		for current.forward[i] != nil && sl.compare(current.forward[i].key, key) < 0 {
+		for current.forward[i] != nil && sl.compareNode(current.forward[i], key, kp) < 0 {
 			current = current.forward[i]
 		}
 		update[i] = current
@@ -422,12 +871,43 @@ func (sl *SkipList[K, V]) Delete(key K) bool {
 // จากโหนดเก่าได้. มีประโยชน์ในการคืนหน่วยความจำหลังจากที่ skiplist ไม่ได้ใช้งานแล้ว
 // หรือก่อนที่จะนำไปใช้กับข้อมูลชุดใหม่
 func (sl *SkipList[K, V]) Clear() {
+	tr := sl.traceStart(OpClear)
 	sl.mutex.Lock()
+	tr.locked()
 	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	tr.keys = sl.length
+	sl.clear()
+}
+
+// clear เป็น helper ภายในของ Clear
+// clear removes all items. The caller must hold the write lock.
+func (sl *SkipList[K, V]) clear() {
+	sl.mustNotBeFrozen()
+	sl.version++
+	sl.epoch++
+	wasEmpty := sl.length == 0
+	// The secondary index is dropped as a whole; removing keys from the
+	// emptied index in onDeleted is then a no-op.
+	if sl.secondary != nil {
+		sl.secondary.clear()
+	}
+	clear(sl.ids)
+	// The removed nodes are reported once the list is reset, so that a
+	// panicking hook leaves an empty list. Neither allocator reuses them
+	// before the next insert.
+	first := sl.header.forward[0]
+	count := sl.length
+	var lastKey K
+	if !wasEmpty {
+		lastKey = sl.last().key
+	}
 
 	// Reset the skiplist's structural properties
 	sl.level = 0
-	sl.length = 0
+	sl.setLength(0)
+	sl.bytes.Store(0)
 	for i := range sl.header.forward {
 		sl.header.forward[i] = nil
 	}
@@ -439,7 +919,14 @@ func (sl *SkipList[K, V]) Clear() {
 	for i := range sl.header.span {
 		sl.header.span[i] = 0
 	}
+	clear(sl.header.wspan)
+	sl.weights = 0
+	clear(sl.header.hspan)
+	sl.hashes = 0
 	sl.header.backward = nil
+	if sl.hot != nil {
+		sl.hot.reset()
+	}
 
 	// Reset the allocator.
 	// For Arena, this reclaims all memory.
@@ -449,32 +936,141 @@ func (sl *SkipList[K, V]) Clear() {
 	} else {
 		sl.allocator = newPoolAllocator[K, V]()
 	}
+
+	if sl.secureWipe {
+		// The hooks below still read the removed nodes; a panicking hook
+		// must not skip the wipe.
+		defer wipeValues(first)
+	}
+	batch := sl.hooks.OnDeleteRange != nil
+	if sl.history != nil || sl.lww != nil || sl.changes != nil || (sl.hooks.OnDelete != nil && !batch) {
+		if sl.lww != nil {
+			// Every key is stamped with the same timestamp.
+			sl.lwwTS = sl.lwwClock()
+		}
+		func() {
+			defer func() { sl.lwwTS, sl.batching = 0, false }()
+			sl.batching = batch
+			for n := first; n != nil; n = n.forward[0] {
+				sl.onDeleted(n.key, n.value)
+			}
+		}()
+	}
+	if batch && !wasEmpty {
+		sl.hooks.onDeleteRange(first.key, lastKey, count)
+	}
+	if !wasEmpty && sl.hooks.OnBoundsChange != nil {
+		sl.boundsChanged()
+	}
 }
 
 // Len คืนค่าจำนวนรายการทั้งหมดใน skiplist
-// Len returns the total number of items in the skiplist.
+// Len returns the total number of items in the skiplist. It does not take the
+// lock: the count is kept in an atomic updated by every write, so it can be
+// polled without contending with writers. During a write that changes
+// several entries (Clear, BulkLoad, ...), it may return an intermediate count.
 func (sl *SkipList[K, V]) Len() int {
+	return int(sl.size.Load())
+}
+
+// IsEmpty reports whether the skiplist has no entries. Like Len, it does not
+// take the lock.
+// IsEmpty คืนค่า true ถ้า skiplist ไม่มีข้อมูล โดยไม่ต้อง lock
+func (sl *SkipList[K, V]) IsEmpty() bool {
+	return sl.size.Load() == 0
+}
+
+// setLength sets the number of entries and publishes it to Len.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) setLength(n int) {
+	sl.length = n
+	sl.size.Store(int64(n))
+}
+
+// Version returns a counter that is incremented by every modification of the
+// skiplist (insert, update, delete and Clear). Two equal values returned by
+// Version mean the contents did not change in between.
+// Version คืนค่าตัวนับที่เพิ่มขึ้นทุกครั้งที่มีการแก้ไขข้อมูลใน skiplist
+func (sl *SkipList[K, V]) Version() uint64 {
 	sl.mutex.RLock()
 	defer sl.mutex.RUnlock()
-	return sl.length
+	return sl.version
 }
 
 // Range วนลูปไปตามรายการทั้งหมดใน skiplist ตามลำดับ key
 // Range iterates over all items in the skiplist in ascending key order.
 // The iteration stops if the provided function f returns false.
+// The scan holds the read lock throughout, unless WithYieldEvery is given.
 // และเรียกใช้ฟังก์ชัน f สำหรับแต่ละคู่ key-value
 // การวนลูปจะหยุดลงหากฟังก์ชัน f คืนค่า false
-func (sl *SkipList[K, V]) Range(f func(key K, value V) bool) {
+func (sl *SkipList[K, V]) Range(f func(key K, value V) bool, opts ...ScanOption) {
+	defer rethrowCallbackPanic("Range")
+	tr := sl.traceStart(OpRange)
 	sl.mutex.RLock()
+	tr.locked()
 	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
 
-	current := sl.header.forward[0]
-	for current != nil {
-		if !f(current.key, current.value) {
-			break
-		}
-		current = current.forward[0]
-	}
+	sl.scan(&tr, sl.header.forward[0], opts, func(n *node[K, V]) bool {
+		tr.keys++
+		return f(n.key, n.value)
+	})
+}
+
+// RangeNodes calls f with the node of every entry in ascending key order
+// until f returns false. Unlike Range, the value is not copied unless f calls
+// Value, and f may keep the node as a handle to the entry, e.g. for
+// DeleteNode. A node must not be used once its entry is deleted: pooled nodes
+// are recycled for other entries. sl.Handle(n) returns a Handle that detects
+// the deletion.
+// RangeNodes เรียก f พร้อมโหนดของแต่ละรายการเรียงตามลำดับ key โดยไม่คัดลอก value
+func (sl *SkipList[K, V]) RangeNodes(f func(n INode[K, V]) bool, opts ...ScanOption) {
+	defer rethrowCallbackPanic("RangeNodes")
+	tr := sl.traceStart(OpRange)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	sl.scan(&tr, sl.header.forward[0], opts, func(n *node[K, V]) bool {
+		tr.keys++
+		return f(n)
+	})
+}
+
+// RangeKeys calls f for every key in ascending order until f returns false.
+// It is a fast path of Range for scans that only need the keys: values are
+// never read, so large values are not pulled into the cache.
+// RangeKeys เรียก f สำหรับทุก key เรียงจากน้อยไปมาก โดยไม่อ่าน value
+func (sl *SkipList[K, V]) RangeKeys(f func(key K) bool, opts ...ScanOption) {
+	defer rethrowCallbackPanic("RangeKeys")
+	tr := sl.traceStart(OpRange)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	sl.scan(&tr, sl.header.forward[0], opts, func(n *node[K, V]) bool {
+		tr.keys++
+		return f(n.key)
+	})
+}
+
+// RangeValues calls f for every value in ascending key order until f returns
+// false. It is a fast path of Range for scans that only need the values.
+// RangeValues เรียก f สำหรับทุก value เรียงตามลำดับ key โดยไม่ส่ง key
+func (sl *SkipList[K, V]) RangeValues(f func(value V) bool, opts ...ScanOption) {
+	defer rethrowCallbackPanic("RangeValues")
+	tr := sl.traceStart(OpRange)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	sl.scan(&tr, sl.header.forward[0], opts, func(n *node[K, V]) bool {
+		tr.keys++
+		return f(n.value)
+	})
 }
 
 // RangeWithIterator provides a locked iterator to a callback function.
@@ -487,6 +1083,7 @@ func (sl *SkipList[K, V]) Range(f func(key K, value V) bool) {
 // เพราะจะทำการ RLock เพียงครั้งเดียวตลอดการทำงานของ callback
 // Iterator ที่ได้มาจะสามารถใช้งานได้ภายใน callback เท่านั้น
 func (sl *SkipList[K, V]) RangeWithIterator(f func(it *Iterator[K, V])) {
+	defer rethrowCallbackPanic("RangeWithIterator")
 	sl.mutex.RLock()
 	defer sl.mutex.RUnlock()
 
@@ -496,18 +1093,44 @@ func (sl *SkipList[K, V]) RangeWithIterator(f func(it *Iterator[K, V])) {
 	f(it)
 }
 
+// RangeWithIteratorBounds is RangeWithIterator over the window of keys
+// between start and end (inclusive): the iterator starts before the first
+// element >= start, Next stops after the last element <= end, and Seek,
+// SeekToRank and Prev never leave the window, so the callback can jump
+// around the already-locked view without re-checking the bounds. First,
+// Last, SeekToFirst and SeekToLast still address the whole list.
+// RangeWithIteratorBounds ให้ Iterator ที่ถูก lock และจำกัดอยู่ในช่วง key ตั้งแต่ start ถึง end
+func (sl *SkipList[K, V]) RangeWithIteratorBounds(start, end K, f func(it *Iterator[K, V])) {
+	defer rethrowCallbackPanic("RangeWithIteratorBounds")
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	it := sl.NewIterator(withUnsafe[K, V](), WithEnd[K, V](end))
+	it.start, it.hasStart = start, true
+	if found := it.findGreaterOrEqual(start); found == nil {
+		it.current = nil
+	} else {
+		it.current = found.backward
+	}
+	f(it)
+}
+
 // Min คืนค่า key-value คู่แรก (น้อยที่สุด) ใน skiplist
 // Min returns the first (smallest) key-value pair in the skiplist.
 // It returns the node and true if the list is not empty, otherwise it returns nil and false.
 // จะคืนค่าโหนดและ true หาก list ไม่ว่าง, มิฉะนั้นคืนค่า nil และ false
 func (sl *SkipList[K, V]) Min() (INode[K, V], bool) {
+	tr := sl.traceStart(OpMin)
 	sl.mutex.RLock()
+	tr.locked()
 	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
 
 	if sl.length == 0 {
 		return nil, false
 	}
 
+	tr.keys = 1
 	firstNode := sl.header.forward[0]
 	return firstNode, true
 }
@@ -517,13 +1140,23 @@ func (sl *SkipList[K, V]) Min() (INode[K, V], bool) {
 // It returns the nil for Node and false if the skiplist is empty.
 // จะคืนค่า nil และ false หาก skiplist ว่างเปล่า
 func (sl *SkipList[K, V]) Max() (INode[K, V], bool) {
+	tr := sl.traceStart(OpMax)
 	sl.mutex.RLock()
+	tr.locked()
 	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
 
 	if sl.length == 0 {
 		return nil, false
 	}
 
+	tr.keys = 1
+	return sl.last(), true
+}
+
+// last returns the node with the largest key, or the header if the list is
+// empty. The caller must hold a lock.
+func (sl *SkipList[K, V]) last() *node[K, V] {
 	current := sl.header
 	// วิ่งไปทางขวาสุดในทุกชั้นจากบนลงล่าง
 	for i := sl.level; i >= 0; i-- {
@@ -531,8 +1164,7 @@ func (sl *SkipList[K, V]) Max() (INode[K, V], bool) {
 			current = current.forward[i]
 		}
 	}
-
-	return current, true
+	return current
 }
 
 // findGreaterOrEqual finds the first node with a key >= the given key.
@@ -542,9 +1174,13 @@ func (sl *SkipList[K, V]) Max() (INode[K, V], bool) {
 // คืนค่า nil หากไม่พบโหนดดังกล่าว
 // ผู้เรียกต้องถือ lock อยู่แล้ว
 func (sl *SkipList[K, V]) findGreaterOrEqual(key K) *node[K, V] {
+	if sl.byteKeys {
+		return sl.findGreaterOrEqualBytes(key)
+	}
+	kp := sl.prefixOf(key)
 	current := sl.header
 	for i := sl.level; i >= 0; i-- {
-		for current.forward[i] != nil && sl.compare(current.forward[i].key, key) < 0 {
+		for current.forward[i] != nil && sl.compareNode(current.forward[i], key, kp) < 0 {
 			current = current.forward[i]
 		}
 	}
@@ -555,24 +1191,27 @@ func (sl *SkipList[K, V]) findGreaterOrEqual(key K) *node[K, V] {
 // RangeQuery วนลูปไปตามรายการที่ key อยู่ระหว่าง start และ end (รวมทั้งสองค่า)
 // RangeQuery iterates over items where the key is between start and end (inclusive).
 // The iteration stops if the provided function f returns false.
+// The scan holds the read lock throughout, unless WithYieldEvery is given.
 // และเรียกใช้ฟังก์ชัน f สำหรับแต่ละคู่ key-value
 // การวนลูปจะหยุดลงหากฟังก์ชัน f คืนค่า false
-func (sl *SkipList[K, V]) RangeQuery(start, end K, f func(key K, value V) bool) {
+func (sl *SkipList[K, V]) RangeQuery(start, end K, f func(key K, value V) bool, opts ...ScanOption) {
+	defer rethrowCallbackPanic("RangeQuery")
+	tr := sl.traceStart(OpRangeQuery)
 	sl.mutex.RLock()
+	tr.locked()
 	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
 
 	// 1. ค้นหาโหนดเริ่มต้น (โหนดแรกที่มี key >= start)
-	current := sl.findGreaterOrEqual(start)
-
 	// 2. วนลูปไปข้างหน้าจนกว่า key จะเกินค่า end
-	for current != nil && sl.compare(current.key, end) <= 0 {
-		// เรียกใช้ callback function และหยุดถ้ามันคืนค่า false
-		if !f(current.key, current.value) {
-			break
+	sl.scan(&tr, sl.findGreaterOrEqual(start), opts, func(n *node[K, V]) bool {
+		if sl.compare(n.key, end) > 0 {
+			return false
 		}
-		// ไปยังโหนดถัดไปในชั้นล่างสุด
-		current = current.forward[0]
-	}
+		tr.keys++
+		// เรียกใช้ callback function และหยุดถ้ามันคืนค่า false
+		return f(n.key, n.value)
+	})
 }
 
 // RangeIterator returns an iterator that iterates over items where the key is between
@@ -619,10 +1258,14 @@ func (sl *SkipList[K, V]) RangeIterator(start, end K) *Iterator[K, V] {
 // Predecessor คือโหนดที่มี key มากที่สุดซึ่งน้อยกว่า key ที่กำหนด
 // คืนค่าโหนดและ true หากพบ, มิฉะนั้นคืนค่า nil และ false
 func (sl *SkipList[K, V]) Predecessor(key K) (INode[K, V], bool) {
+	tr := sl.traceStart(OpPredecessor)
 	sl.mutex.RLock()
+	tr.locked()
 	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
 
 	current := sl.header
+	kp := sl.prefixOf(key)
 
 	// ค้นหาโหนดที่อยู่ก่อนหน้า key ที่กำหนด
 	// วิ่งไปข้างหน้าในแต่ละชั้นจนกว่าโหนดถัดไปจะมี key มากกว่าหรือเท่ากับ key ที่ค้นหา
@@ -630,7 +1273,7 @@ func (sl *SkipList[K, V]) Predecessor(key K) (INode[K, V], bool) {
 	for i := sl.level; i >= 0; i-- {
 		// The key difference for Predecessor is the strict inequality '<'.
 		// We stop *before* we reach a node with a key equal to or greater than the target.
-		for current.forward[i] != nil && sl.compare(current.forward[i].key, key) < 0 {
+		for current.forward[i] != nil && sl.compareNode(current.forward[i], key, kp) < 0 {
 			current = current.forward[i]
 		}
 	}
@@ -639,6 +1282,7 @@ func (sl *SkipList[K, V]) Predecessor(key K) (INode[K, V], bool) {
 	// และเป็นโหนดที่ใกล้เคียงที่สุด (มากที่สุด) ที่น้อยกว่า 'key'
 	// ถ้า 'current' ยังคงเป็น header แสดงว่าไม่มีโหนดข้อมูลใดๆ ที่มี key น้อยกว่า 'key'
 	if current != sl.header {
+		tr.keys = 1
 		return current, true
 	}
 
@@ -648,8 +1292,11 @@ func (sl *SkipList[K, V]) Predecessor(key K) (INode[K, V], bool) {
 // CountRange นับจำนวนรายการที่ key อยู่ระหว่าง start และ end (รวมทั้งสองค่า)
 // CountRange counts the number of items where the key is between start and end (inclusive).
 func (sl *SkipList[K, V]) CountRange(start, end K) int {
+	tr := sl.traceStart(OpCountRange)
 	sl.mutex.RLock()
+	tr.locked()
 	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
 
 	// Handle invalid range
 	if sl.compare(start, end) > 0 {
@@ -666,6 +1313,7 @@ func (sl *SkipList[K, V]) CountRange(start, end K) int {
 		current = current.forward[0]
 	}
 
+	tr.keys = count
 	return count
 }
 
@@ -676,10 +1324,14 @@ func (sl *SkipList[K, V]) CountRange(start, end K) int {
 // Successor คือโหนดที่มี key น้อยที่สุดที่มากกว่า key ที่กำหนด
 // คืนค่าโหนดและ true หากพบ, มิฉะนั้นคืนค่า nil และ false
 func (sl *SkipList[K, V]) Successor(key K) (INode[K, V], bool) {
+	tr := sl.traceStart(OpSuccessor)
 	sl.mutex.RLock()
+	tr.locked()
 	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
 
 	current := sl.header
+	kp := sl.prefixOf(key)
 
 	// ค้นหาโหนดที่อยู่ก่อนหน้าหรือเท่ากับ key ที่กำหนด
 	// วิ่งไปข้างหน้าในแต่ละชั้นจนกว่าโหนดถัดไปจะมี key มากกว่า key ที่ค้นหา
@@ -687,7 +1339,7 @@ func (sl *SkipList[K, V]) Successor(key K) (INode[K, V], bool) {
 	for i := sl.level; i >= 0; i-- {
 		// The key difference for Successor is the non-strict inequality '<='.
 		// We advance *past* any node with a key equal to the target.
-		for current.forward[i] != nil && sl.compare(current.forward[i].key, key) <= 0 {
+		for current.forward[i] != nil && sl.compareNode(current.forward[i], key, kp) <= 0 {
 			current = current.forward[i]
 		}
 	}
@@ -695,6 +1347,7 @@ func (sl *SkipList[K, V]) Successor(key K) (INode[K, V], bool) {
 	// หลังจากลูปสิ้นสุด, 'current' คือโหนดที่มี key น้อยกว่าหรือเท่ากับ 'key'
 	// โหนดถัดไปในชั้นล่างสุด (forward[0]) คือ successor ที่เราต้องการ
 	if current != nil && current.forward[0] != nil {
+		tr.keys = 1
 		return current.forward[0], true
 	}
 	return nil, false
@@ -705,12 +1358,16 @@ func (sl *SkipList[K, V]) Successor(key K) (INode[K, V], bool) {
 // Seek ค้นหาโหนดแรกที่มี key เท่ากับหรือมากกว่า key ที่กำหนด
 // คืนค่าโหนดและ true หากพบ, มิฉะนั้นคืนค่า nil และ false
 func (sl *SkipList[K, V]) Seek(key K) (INode[K, V], bool) {
+	tr := sl.traceStart(OpSeek)
 	sl.mutex.RLock()
+	tr.locked()
 	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
 
 	node := sl.findGreaterOrEqual(key)
 
 	if node != nil {
+		tr.keys = 1
 		return node, true
 	}
 
@@ -723,8 +1380,11 @@ func (sl *SkipList[K, V]) Seek(key K) (INode[K, V], bool) {
 // otherwise it returns nil and false.
 // คืนค่าโหนดที่เก็บข้อมูลที่ถูกดึงออกและ true หากมีรายการ, มิฉะนั้นคืนค่า nil และ false
 func (sl *SkipList[K, V]) PopMin() (INode[K, V], bool) {
+	tr := sl.traceStart(OpPopMin)
 	sl.mutex.Lock() // ใช้ Lock เพราะมีการแก้ไขโครงสร้าง
+	tr.locked()
 	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
 
 	if sl.length == 0 {
 		return nil, false
@@ -743,6 +1403,7 @@ func (sl *SkipList[K, V]) PopMin() (INode[K, V], bool) {
 	}
 
 	sl.deleteNode(nodeToRemove, update)
+	tr.keys = 1
 	return &node[K, V]{key: poppedKey, value: poppedValue}, true
 }
 
@@ -755,19 +1416,14 @@ func (sl *SkipList[K, V]) PopMin() (INode[K, V], bool) {
 // หากไม่พบ key จะคืนค่าอันดับที่ควรจะเป็นหากมีการเพิ่ม key นั้นเข้าไป
 // มีความซับซ้อน O(log n)
 func (sl *SkipList[K, V]) Rank(key K) int {
+	sl.mustRanks()
+	tr := sl.traceStart(OpRank)
 	sl.mutex.RLock()
+	tr.locked()
 	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
 
-	rank := 0
-	current := sl.header
-
-	for i := sl.level; i >= 0; i-- {
-		for current.forward[i] != nil && sl.compare(current.forward[i].key, key) < 0 {
-			rank += current.span[i]
-			current = current.forward[i]
-		}
-	}
-	return rank
+	return sl.rank(key, false)
 }
 
 // PopMax ดึง key-value คู่ที่มี key มากที่สุดออกจาก skiplist และลบโหนดนั้นออก
@@ -776,44 +1432,84 @@ func (sl *SkipList[K, V]) Rank(key K) int {
 // otherwise it returns nil and false.
 // คืนค่าโหนดที่เก็บข้อมูลที่ถูกดึงออกและ true หากมีรายการ, มิฉะนั้นคืนค่า nil และ false
 func (sl *SkipList[K, V]) PopMax() (INode[K, V], bool) {
+	tr := sl.traceStart(OpPopMax)
 	sl.mutex.Lock()
+	tr.locked()
 	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
 
 	if sl.length == 0 {
 		return nil, false
 	}
 
-	// --- ขั้นตอนที่ 1: ค้นหาโหนดสุดท้าย (Max Node) เพื่อหา key ---
-	// การทำ 2-pass (หา key ก่อน แล้วค่อยหา update path) อาจมีประสิทธิภาพด้อยกว่าเล็กน้อย
-	// แต่ช่วยให้ตรรกะการลบสอดคล้องกับฟังก์ชัน Delete และลดความซับซ้อน
-	// ทำให้การอัปเดต span ถูกต้องและง่ายต่อการตรวจสอบ
-	lastNode := sl.header
-	for i := sl.level; i >= 0; i-- {
-		for lastNode.forward[i] != nil {
-			lastNode = lastNode.forward[i]
-		}
+	nodeToRemove, update := sl.maxPath()
+
+	// ดึง Key และ Value ออกมาก่อนที่โหนดจะถูกเคลียร์โดย deleteNode
+	poppedKey := nodeToRemove.key
+	poppedValue := nodeToRemove.value
+
+	sl.deleteNode(nodeToRemove, update)
+	tr.keys = 1
+	return &node[K, V]{key: poppedKey, value: poppedValue}, true
+}
+
+// DeleteMin removes the entry with the smallest key and reports whether the
+// list was non-empty. Unlike PopMin, it does not copy the removed entry, which
+// makes it the cheaper choice for trimming loops.
+// DeleteMin ลบรายการที่มี key น้อยที่สุดโดยไม่คืนค่าข้อมูล คืนค่า false หาก skiplist ว่างเปล่า
+func (sl *SkipList[K, V]) DeleteMin() bool {
+	tr := sl.traceStart(OpDeleteMin)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	if sl.length == 0 {
+		return false
+	}
+	update := sl.updateCache
+	for i := 0; i <= sl.level; i++ {
+		update[i] = sl.header
 	}
-	keyToRemove := lastNode.key
+	sl.deleteNode(sl.header.forward[0], update)
+	tr.keys = 1
+	return true
+}
+
+// DeleteMax removes the entry with the largest key and reports whether the
+// list was non-empty. Unlike PopMax, it does not copy the removed entry.
+// DeleteMax ลบรายการที่มี key มากที่สุดโดยไม่คืนค่าข้อมูล คืนค่า false หาก skiplist ว่างเปล่า
+func (sl *SkipList[K, V]) DeleteMax() bool {
+	tr := sl.traceStart(OpDeleteMax)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
 
-	// --- ขั้นตอนที่ 2: ค้นหา update path สำหรับ key ที่จะลบ (เหมือนในฟังก์ชัน Delete) ---
+	if sl.length == 0 {
+		return false
+	}
+	nodeToRemove, update := sl.maxPath()
+	sl.deleteNode(nodeToRemove, update)
+	tr.keys = 1
+	return true
+}
+
+// maxPath returns the last node of a non-empty list and records its update
+// path in sl.updateCache, in a single descent without key comparisons: the
+// last node is the only one whose level-0 successor is nil, so each level is
+// followed up to the node just before it. The caller must hold the write lock.
+// maxPath คืนค่าโหนดสุดท้ายและ update path ของโหนดนั้นด้วยการไล่ลงเพียงรอบเดียว
+func (sl *SkipList[K, V]) maxPath() (*node[K, V], []INode[K, V]) {
 	update := sl.updateCache
 	current := sl.header
 	for i := sl.level; i >= 0; i-- {
-		for current.forward[i] != nil && sl.compare(current.forward[i].key, keyToRemove) < 0 {
-			current = current.forward[i]
+		for next := current.forward[i]; next != nil && next.forward[0] != nil; next = current.forward[i] {
+			current = next
 		}
 		update[i] = current
 	}
-
-	// --- ขั้นตอนที่ 3: ดึงข้อมูลและลบโหนด ---
-	nodeToRemove := current.forward[0]
-
-	// ดึง Key และ Value ออกมาก่อนที่โหนดจะถูกเคลียร์โดย deleteNode
-	poppedKey := nodeToRemove.key
-	poppedValue := nodeToRemove.value
-
-	sl.deleteNode(nodeToRemove, update)
-	return &node[K, V]{key: poppedKey, value: poppedValue}, true
+	return current.forward[0], update
 }
 
 // GetByRank returns the node at the given 0-based rank.
@@ -823,13 +1519,27 @@ func (sl *SkipList[K, V]) PopMax() (INode[K, V], bool) {
 // หากอันดับอยู่นอกขอบเขต (น้อยกว่า 0 หรือมากกว่าหรือเท่ากับ Len()) จะคืนค่า nil และ false
 // มีความซับซ้อน O(log n)
 func (sl *SkipList[K, V]) GetByRank(rank int) (INode[K, V], bool) {
+	sl.mustRanks()
+	tr := sl.traceStart(OpGetByRank)
 	sl.mutex.RLock()
+	tr.locked()
 	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
 
 	if rank < 0 || rank >= sl.length {
 		return nil, false
 	}
 
+	tr.keys = 1
+	return sl.getByRank(rank), true
+}
+
+// getByRank returns the node at the given 0-based rank, which must be in bounds.
+// The caller must hold a lock.
+func (sl *SkipList[K, V]) getByRank(rank int) *node[K, V] {
+	if sl.noRanks {
+		return sl.walkToRank(rank)
+	}
 	var traversed int = -1 // Header is at rank -1
 	current := sl.header
 
@@ -839,5 +1549,5 @@ func (sl *SkipList[K, V]) GetByRank(rank int) (INode[K, V], bool) {
 			current = current.forward[i]
 		}
 	}
-	return current, true
+	return current
 }
@@ -1,6 +1,7 @@
 package skiplist
 
 import (
+	"errors"
 	"testing"
 	"unsafe"
 )
@@ -8,8 +9,8 @@ import (
 // TestArenaGrowth verifies that the memory arena grows automatically when it runs out of space.
 // It creates an arena that can only hold 2 nodes, then inserts 3 to force a growth.
 func TestArenaGrowth_WithFactor(t *testing.T) {
-	// Calculate the approximate size of a single node struct.
-	// The arena only allocates the node struct itself, not its internal slices.
+	// Calculate the approximate size of a single node struct. The arena
+	// allocates a node together with its links, so each node takes a bit more.
 	nodeSize := int(unsafe.Sizeof(node[int, int]{}))
 
 	// Set an initial arena size that can hold exactly 2 nodes.
@@ -129,3 +130,140 @@ func TestArena_ResetAfterGrowth(t *testing.T) {
 		t.Fatalf("Post-reuse: Expected length 5, got %d", sl.Len())
 	}
 }
+
+func TestNodePadding(t *testing.T) {
+	nodeSize := unsafe.Sizeof(node[int, int]{})
+	a := newArenaAllocator[int, int](1<<16, WithPadding(128))
+	n1, n2 := a.Get(1), a.Get(1)
+	if gap := uintptr(unsafe.Pointer(n2)) - uintptr(unsafe.Pointer(n1)); gap < 128+nodeSize {
+		t.Errorf("adjacent nodes are %d bytes apart, want at least %d", gap, 128+nodeSize)
+	}
+
+	sl := New[int, int](WithArena[int, int](1<<12), WithNodePadding[int, int](128))
+	for i := 0; i < 5000; i++ {
+		sl.Insert(i, i)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if st := sl.Stats(); st.ArenaUsed != 5000 || st.ArenaCapacity < 5000 {
+		t.Errorf("Stats() = %+v", st)
+	}
+}
+
+func TestArenaAlignment(t *testing.T) {
+	for _, align := range []int{64, 256} {
+		a := newArenaAllocator[int, int](1<<16, WithAlignment(align))
+		for i := 0; i < 2000; i++ {
+			n := a.Get(1 + i%MaxLevel)
+			if addr := uintptr(unsafe.Pointer(n)); addr%uintptr(align) != 0 {
+				t.Fatalf("node %d at %#x is not %d-byte aligned", i, addr, align)
+			}
+		}
+	}
+
+	// A fixed arena accounts for the alignment slack within its budget.
+	const budget = 64 << 10
+	sl := New(WithFixedArena[int, int](budget), WithArenaAlignment[int, int](64), WithArenaHugePages[int, int]())
+	for n := 0; ; n++ {
+		if _, err := sl.TryInsert(n, n); err != nil {
+			break
+		}
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if used, _ := sl.allocator.(*arenaAllocator[int, int]).nodeUsage(); sl.Len() != used {
+		t.Errorf("Len() = %d with %d blocks used", sl.Len(), used)
+	}
+	if alloc := sl.allocator.(*arenaAllocator[int, int]).allocated; alloc > budget {
+		t.Errorf("aligned arena allocated %d bytes, budget %d", alloc, budget)
+	}
+
+	// Huge pages are a hint that leaves a large arena fully usable.
+	big := New(WithArena[int, int](4<<20), WithArenaHugePages[int, int](), WithArenaAlignment[int, int](64))
+	for i := 0; i < 10000; i++ {
+		big.Insert(i, i)
+	}
+	if err := big.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFixedArena(t *testing.T) {
+	const budget = 64 << 10
+	sl := New(WithFixedArena[int, int](budget))
+
+	var err error
+	n := 0
+	for ; n < budget; n++ {
+		if _, err = sl.TryInsert(n, n); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, ErrArenaFull) {
+		t.Fatalf("TryInsert on a full arena returned %v, want ErrArenaFull", err)
+	}
+	if n == 0 || sl.Len() != n {
+		t.Fatalf("Len() = %d after %d successful inserts", sl.Len(), n)
+	}
+	if st := sl.Stats(); st.ArenaCapacity*int(unsafe.Sizeof(node[int, int]{})) > budget {
+		t.Errorf("arena holds %d nodes, more than fit in %d bytes", st.ArenaCapacity, budget)
+	}
+	version := sl.Version()
+	if err := sl.Validate(); err != nil {
+		t.Fatalf("list changed by a rejected insert: %v", err)
+	}
+
+	// The first rejection only means that the size class of one level is
+	// exhausted; smaller nodes may still fit. Keep inserting until every
+	// class is full, which 64 rejections in a row make all but certain.
+	for i, rejected := 1, 0; rejected < 64; i++ {
+		if _, err := sl.TryInsert(-i, -i); err == nil {
+			n, rejected, version = n+1, 0, sl.Version()
+		} else {
+			rejected++
+		}
+	}
+	if sl.Len() != n || sl.Version() != version {
+		t.Errorf("rejected TryInsert left Len() = %d and version %d, want %d and %d", sl.Len(), sl.Version(), n, version)
+	}
+	if n < budget/256 {
+		t.Errorf("a %d-byte arena held only %d entries", budget, n)
+	}
+
+	// Updating an existing key needs no new node.
+	if old, err := sl.TryInsert(0, 100); err != nil || old == nil {
+		t.Errorf("TryInsert of an existing key = %v, %v", old, err)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r != ErrArenaFull {
+				t.Errorf("Insert on a full arena panicked with %v, want ErrArenaFull", r)
+			}
+		}()
+		sl.Insert(-budget, 0)
+	}()
+
+	// A smaller fixed arena cannot take the entries.
+	if err := sl.MigrateAllocator(WithFixedArena[int, int](budget / 4)); !errors.Is(err, ErrArenaFull) {
+		t.Errorf("MigrateAllocator into a small fixed arena returned %v, want ErrArenaFull", err)
+	}
+	if sl.Len() != n {
+		t.Errorf("failed migration changed Len() to %d, want %d", sl.Len(), n)
+	}
+
+	// Clear reclaims the whole budget.
+	sl.Clear()
+	i := 0
+	loaded, err := sl.BulkLoad(func() (int, int, bool) {
+		i++
+		return i, i, true
+	})
+	if !errors.Is(err, ErrArenaFull) || loaded == 0 || sl.Len() != loaded {
+		t.Errorf("BulkLoad after Clear loaded %d entries and returned %v", loaded, err)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatalf("after a partial BulkLoad: %v", err)
+	}
+}
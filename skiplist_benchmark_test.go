@@ -1,7 +1,10 @@
 package skiplist
 
 import (
+	"bytes"
+	"fmt"
 	"math/rand/v2"
+	"strconv"
 	"testing"
 )
 
@@ -138,22 +141,28 @@ func BenchmarkSkipList_Churn(b *testing.B) {
 	// which is a primary use case for sync.Pool. An arena allocator is not
 	// designed for this pattern, as it doesn't reclaim individual nodes on Put().
 	// Therefore, we only run this for the pool-based allocator.
-	b.Run("WithPool", func(b *testing.B) {
-		keys := generateRandomKeys(benchmarkSize)
-		sl := New[int, int]()
-		b.StopTimer()
-		for _, key := range keys {
-			sl.Insert(key, key)
-		}
+	// WithoutRankTracking skips the span updates of every Insert and Delete.
+	for name, opts := range map[string][]Option[int, int]{
+		"WithPool":            nil,
+		"WithoutRankTracking": {WithoutRankTracking[int, int]()},
+	} {
+		b.Run(name, func(b *testing.B) {
+			keys := generateRandomKeys(benchmarkSize)
+			sl := New[int, int](opts...)
+			b.StopTimer()
+			for _, key := range keys {
+				sl.Insert(key, key)
+			}
 
-		b.StartTimer()
-		for i := 0; i < b.N; i++ {
-			keyToDelete := keys[i%benchmarkSize]
-			sl.Delete(keyToDelete)
-			keyToInsert := keys[(i+1)%benchmarkSize] + benchmarkSize*10
-			sl.Insert(keyToInsert, keyToInsert)
-		}
-	})
+			b.StartTimer()
+			for i := 0; i < b.N; i++ {
+				keyToDelete := keys[i%benchmarkSize]
+				sl.Delete(keyToDelete)
+				keyToInsert := keys[(i+1)%benchmarkSize] + benchmarkSize*10
+				sl.Insert(keyToInsert, keyToInsert)
+			}
+		})
+	}
 }
 
 // BenchmarkSkipList_Range measures the performance of iterating through all elements
@@ -176,6 +185,24 @@ func BenchmarkSkipList_Range(b *testing.B) {
 	}
 }
 
+// BenchmarkSkipList_RangeKeys measures a keys-only scan of all elements,
+// to be compared with BenchmarkSkipList_Range.
+func BenchmarkSkipList_RangeKeys(b *testing.B) {
+	for _, setup := range getTestSetups[int, int]() {
+		b.Run(setup.name, func(b *testing.B) {
+			sl := setup.constructor(nil)
+			for _, key := range generateRandomKeys(benchmarkSize) {
+				sl.Insert(key, key)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sl.RangeKeys(func(key int) bool { return true })
+			}
+		})
+	}
+}
+
 // BenchmarkSkipList_Iterator_Safe measures the performance of iterating through all elements
 // using the standard, thread-safe iterator, which acquires a lock on each operation.
 func BenchmarkSkipList_Iterator_Safe(b *testing.B) {
@@ -259,3 +286,130 @@ func BenchmarkSkipList_Clear(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkSkipList_Search_Parallel_NodePadding compares concurrent searches on
+// arena-allocated nodes with and without padding between adjacent nodes.
+func BenchmarkSkipList_Search_Parallel_NodePadding(b *testing.B) {
+	for _, padding := range []int{0, 64, 128} {
+		b.Run("padding="+strconv.Itoa(padding), func(b *testing.B) {
+			keys := generateRandomKeys(benchmarkSize)
+			sl := New[int, int](WithArena[int, int](1<<20), WithNodePadding[int, int](padding))
+			for _, k := range keys {
+				sl.Insert(k, k)
+			}
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := rand.IntN(benchmarkSize)
+				for pb.Next() {
+					sl.Search(keys[i%benchmarkSize])
+					i++
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkSkipList_Search_Bytes compares searches for []byte keys sharing a
+// long common prefix through the generic comparator and through NewBytes.
+// The list is kept small so that comparisons, not cache misses, dominate.
+func BenchmarkSkipList_Search_Bytes(b *testing.B) {
+	keys := make([][]byte, 1000)
+	for i, k := range generateRandomKeys(1000) {
+		keys[i] = []byte(fmt.Sprintf("tenant/0042/objects/%012d", k))
+	}
+	constructors := []struct {
+		name    string
+		newList func() *SkipList[[]byte, int]
+	}{
+		{"Comparator", func() *SkipList[[]byte, int] { return NewWithComparator[[]byte, int](bytes.Compare) }},
+		{"NewBytes", func() *SkipList[[]byte, int] { return NewBytes[int]() }},
+	}
+	for _, c := range constructors {
+		b.Run(c.name, func(b *testing.B) {
+			sl := c.newList()
+			for i, k := range keys {
+				sl.Insert(k, i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sl.Search(keys[i%1000])
+			}
+		})
+	}
+}
+
+// BenchmarkSkipList_Search_KeyPrefix compares searches for long string keys
+// with and without cached key prefixes.
+func BenchmarkSkipList_Search_KeyPrefix(b *testing.B) {
+	keys := make([]string, benchmarkSize)
+	for i, k := range generateRandomKeys(benchmarkSize) {
+		keys[i] = fmt.Sprintf("%016x/some/long/object/path", uint64(k)*0x9e3779b97f4a7c15)
+	}
+	variants := []struct {
+		name string
+		opts []Option[string, int]
+	}{
+		{"NoPrefix", nil},
+		{"WithKeyPrefix", []Option[string, int]{WithKeyPrefix[string, int](StringKeyPrefix)}},
+	}
+	for _, v := range variants {
+		b.Run(v.name, func(b *testing.B) {
+			sl := New[string, int](v.opts...)
+			for i, k := range keys {
+				sl.Insert(k, i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sl.Search(keys[i%benchmarkSize])
+			}
+		})
+	}
+}
+
+// BenchmarkSkipList_Search_Zipf measures searches whose keys follow a Zipfian
+// distribution, as in caches and hot-key workloads, with and without the hot
+// node cache.
+func BenchmarkSkipList_Search_Zipf(b *testing.B) {
+	const size = 1 << 20
+	variants := []struct {
+		name string
+		opts []Option[int, int]
+	}{
+		{"NoHotCache", nil},
+		{"WithHotCache", []Option[int, int]{WithHotCache[int, int](16)}},
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	zipf := rand.NewZipf(r, 1.5, 1, size-1)
+	lookups := make([]int, 1<<16)
+	for i := range lookups {
+		// Scatter the hot keys over the list.
+		lookups[i] = int(zipf.Uint64()*0x9e3779b97f4a7c15) & (size - 1)
+	}
+	for _, v := range variants {
+		b.Run(v.name, func(b *testing.B) {
+			sl := New[int, int](v.opts...)
+			for i := 0; i < size; i++ {
+				sl.Insert(i, i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sl.Search(lookups[i&(len(lookups)-1)])
+			}
+		})
+	}
+}
+
+// BenchmarkSkipList_Insert_Allocs reports the heap allocations made by Insert
+// for new keys. Run with -benchmem; TestInsertAllocs guards the same numbers.
+func BenchmarkSkipList_Insert_Allocs(b *testing.B) {
+	for _, setup := range getTestSetups[int, int]() {
+		b.Run(setup.name, func(b *testing.B) {
+			sl := setup.constructor(nil)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sl.Insert(i, i)
+			}
+		})
+	}
+}
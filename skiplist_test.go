@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"fmt"
 	"math/rand/v2"
+	"slices"
 	"sync"
 	"testing"
 )
@@ -189,6 +190,68 @@ func TestSkipList(t *testing.T) {
 	}
 }
 
+func TestSkipList_RangeKeysValues(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			sl.RangeKeys(func(int) bool { t.Fatal("RangeKeys called f on an empty list"); return true })
+			sl.Insert(20, "b")
+			sl.Insert(10, "a")
+			sl.Insert(30, "c")
+
+			var keys []int
+			sl.RangeKeys(func(key int) bool {
+				keys = append(keys, key)
+				return true
+			})
+			if !slices.Equal(keys, []int{10, 20, 30}) {
+				t.Errorf("RangeKeys visited %v, want [10 20 30]", keys)
+			}
+
+			var values []string
+			sl.RangeValues(func(value string) bool {
+				values = append(values, value)
+				return value != "b"
+			})
+			if !slices.Equal(values, []string{"a", "b"}) {
+				t.Errorf("RangeValues with break visited %v, want [a b]", values)
+			}
+		})
+	}
+}
+
+func TestSkipList_RangeNodes(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			sl.RangeNodes(func(INode[int, string]) bool { t.Fatal("RangeNodes called f on an empty list"); return true })
+			for i := 1; i <= 5; i++ {
+				sl.Insert(i*10, fmt.Sprint(i))
+			}
+
+			var nodes []INode[int, string]
+			sl.RangeNodes(func(n INode[int, string]) bool {
+				nodes = append(nodes, n)
+				return n.Key() < 40
+			})
+			if len(nodes) != 4 || nodes[0].Key() != 10 || nodes[3].Key() != 40 {
+				t.Fatalf("RangeNodes with break visited %d nodes", len(nodes))
+			}
+			// The handles are the nodes returned by Search.
+			for _, n := range nodes {
+				if found, ok := sl.Search(n.Key()); !ok || found != n {
+					t.Errorf("Search(%d) = %v, want the node passed to RangeNodes", n.Key(), found)
+				}
+			}
+			// Updates are visible through the handles.
+			sl.Insert(20, "x")
+			if nodes[1].Value() != "x" {
+				t.Errorf("handle of 20 has value %q after an update", nodes[1].Value())
+			}
+		})
+	}
+}
+
 func TestSkipList_Clear(t *testing.T) {
 	for _, setup := range getTestSetups[int, string]() {
 		t.Run(setup.name, func(t *testing.T) {
@@ -895,6 +958,47 @@ func TestSkipList_PopMax(t *testing.T) {
 	}
 }
 
+func TestSkipList_DeleteMinMax(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			// Range hashes make Validate check the update paths of deletes.
+			sl := setup.constructor(nil, WithMerkle[int, string](func(k int, _ string) uint64 { return mix64(uint64(k)) }))
+
+			// Test on empty list
+			if sl.DeleteMin() || sl.DeleteMax() {
+				t.Error("DeleteMin/DeleteMax on empty list should return false")
+			}
+
+			for i := 0; i < 200; i++ {
+				sl.Insert(i, fmt.Sprint(i))
+			}
+			for lo, hi := 0, 199; lo < hi; lo, hi = lo+1, hi-1 {
+				if !sl.DeleteMin() {
+					t.Fatalf("DeleteMin with %d entries left returned false", sl.Len())
+				}
+				if !sl.DeleteMax() {
+					t.Fatalf("DeleteMax with %d entries left returned false", sl.Len())
+				}
+				if minNode, _ := sl.Min(); sl.Len() > 0 && minNode.Key() != lo+1 {
+					t.Fatalf("Min() after DeleteMin = %d, want %d", minNode.Key(), lo+1)
+				}
+				if maxNode, _ := sl.Max(); sl.Len() > 0 && maxNode.Key() != hi-1 {
+					t.Fatalf("Max() after DeleteMax = %d, want %d", maxNode.Key(), hi-1)
+				}
+				if err := sl.Validate(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if sl.Len() != 0 {
+				t.Errorf("Expected length 0, got %d", sl.Len())
+			}
+			if sl.DeleteMin() || sl.DeleteMax() {
+				t.Error("DeleteMin/DeleteMax on emptied list should return false")
+			}
+		})
+	}
+}
+
 func TestSkipList_CountRange(t *testing.T) {
 	for _, setup := range getTestSetups[int, string]() {
 		t.Run(setup.name, func(t *testing.T) {
@@ -1515,3 +1619,93 @@ func TestSkipList_Simulate(t *testing.T) {
 		})
 	}
 }
+
+// TestInsertAllocs guards the number of heap allocations made by Insert for a
+// new key: a node and its links (forward and span) share one allocation, which
+// an arena amortizes over a whole chunk.
+func TestInsertAllocs(t *testing.T) {
+	limits := map[string]float64{"WithPool": 1, "WithArena": 0}
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			key := 0
+			allocs := testing.AllocsPerRun(1000, func() {
+				sl.Insert(key, key)
+				key++
+			})
+			if allocs > limits[setup.name] {
+				t.Errorf("Insert made %v allocations per new key, want at most %v", allocs, limits[setup.name])
+			}
+		})
+	}
+}
+
+func TestNoCopy(t *testing.T) {
+	// go vet's copylocks check reports copies of any struct holding a field
+	// whose pointer implements sync.Locker.
+	var _ sync.Locker = (*noCopy)(nil)
+	var sl SkipList[int, int]
+	var it Iterator[int, int]
+	for _, l := range []sync.Locker{&sl.noCopy, &it.noCopy} {
+		l.Lock()
+		l.Unlock()
+	}
+}
+
+func TestSkipList_LenLockFree(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			if !sl.IsEmpty() || sl.Len() != 0 {
+				t.Fatalf("new list: IsEmpty() = %v, Len() = %d", sl.IsEmpty(), sl.Len())
+			}
+
+			// Len and IsEmpty are served while the write lock is held.
+			sl.mutex.Lock()
+			_ = sl.Len()
+			_ = sl.IsEmpty()
+			sl.mutex.Unlock()
+
+			var wg sync.WaitGroup
+			stop := make(chan struct{})
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+						if n := sl.Len(); n < 0 || n > 1000 {
+							t.Errorf("Len() = %d", n)
+							return
+						}
+					}
+				}
+			}()
+			for i := 0; i < 1000; i++ {
+				sl.Insert(i, i)
+			}
+			for i := 0; i < 500; i++ {
+				sl.Delete(i)
+			}
+			close(stop)
+			wg.Wait()
+
+			if sl.Len() != 500 || sl.IsEmpty() {
+				t.Errorf("Len() = %d, IsEmpty() = %v, want 500, false", sl.Len(), sl.IsEmpty())
+			}
+			frozen := sl.Rotate()
+			if frozen.Len() != 500 || !sl.IsEmpty() {
+				t.Errorf("after Rotate: frozen.Len() = %d, IsEmpty() = %v", frozen.Len(), sl.IsEmpty())
+			}
+			frozen.Release()
+			if frozen.Len() != 0 {
+				t.Errorf("after Release: frozen.Len() = %d", frozen.Len())
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Package skiplisthttp exposes a read-only JSON admin API for a live
+// SkipList, in the spirit of net/http/pprof: mount it on an existing mux and
+// inspect production lists with curl.
+//
+//	mux := http.NewServeMux()
+//	skiplisthttp.Mount(mux, "/debug/skiplist/", sl, skiplisthttp.Config[string, int]{
+//		ParseKey: func(s string) (string, error) { return s, nil },
+//	})
+//
+// Endpoints, relative to the prefix:
+//
+//	GET stats                          Stats of the list
+//	GET top?n=10[&reverse=1]           the first (or last) n entries
+//	GET range?start=a&end=b[&limit=n]  entries with start <= key <= end
+//	GET validate                       result of Validate (HTTP 500 if broken)
+//
+// Keys and values are rendered with encoding/json. The top and range
+// endpoints hold the list's read lock only while copying at most MaxItems
+// entries; stats and validate walk the whole list under the read lock.
+package skiplisthttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/INLOpen/skiplist"
+)
+
+// DefaultMaxItems is the default cap on the number of entries one request returns.
+const DefaultMaxItems = 1000
+
+// Config configures the admin endpoints of one list.
+type Config[K any, V any] struct {
+	// ParseKey converts the start and end query parameters of the range
+	// endpoint into keys. If nil, the range endpoint responds 501.
+	ParseKey func(s string) (K, error)
+	// MaxItems caps the number of entries returned by top and range.
+	// Zero means DefaultMaxItems.
+	MaxItems int
+}
+
+// Entry is the JSON form of a key-value pair.
+type Entry[K any, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// Mount registers the admin endpoints on mux under prefix, which must end
+// with a slash (e.g. "/debug/skiplist/").
+func Mount[K any, V any](mux *http.ServeMux, prefix string, sl *skiplist.SkipList[K, V], cfg Config[K, V]) {
+	if !strings.HasSuffix(prefix, "/") {
+		panic("skiplisthttp: prefix must end with a slash")
+	}
+	mux.Handle(prefix, http.StripPrefix(strings.TrimSuffix(prefix, "/"), Handler(sl, cfg)))
+}
+
+// Handler returns an http.Handler serving the admin endpoints at /stats,
+// /top, /range and /validate.
+func Handler[K any, V any](sl *skiplist.SkipList[K, V], cfg Config[K, V]) http.Handler {
+	if cfg.MaxItems <= 0 {
+		cfg.MaxItems = DefaultMaxItems
+	}
+	h := &handler[K, V]{sl: sl, cfg: cfg}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /stats", h.stats)
+	mux.HandleFunc("GET /top", h.top)
+	mux.HandleFunc("GET /range", h.rangeQuery)
+	mux.HandleFunc("GET /validate", h.validate)
+	return mux
+}
+
+type handler[K any, V any] struct {
+	sl  *skiplist.SkipList[K, V]
+	cfg Config[K, V]
+}
+
+func (h *handler[K, V]) stats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.sl.Stats())
+}
+
+func (h *handler[K, V]) top(w http.ResponseWriter, r *http.Request) {
+	n, err := h.limit(r, "n", 10)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	reverse, _ := strconv.ParseBool(r.URL.Query().Get("reverse"))
+	entries := make([]Entry[K, V], 0, n)
+	h.sl.RangeWithIterator(func(it *skiplist.Iterator[K, V]) {
+		var ok bool
+		if reverse {
+			for ok = it.Last(); ok && len(entries) < n; ok = it.Prev() {
+				entries = append(entries, Entry[K, V]{it.Key(), it.Value()})
+			}
+			return
+		}
+		for ok = it.First(); ok && len(entries) < n; ok = it.Next() {
+			entries = append(entries, Entry[K, V]{it.Key(), it.Value()})
+		}
+	})
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (h *handler[K, V]) rangeQuery(w http.ResponseWriter, r *http.Request) {
+	if h.cfg.ParseKey == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("range queries need Config.ParseKey"))
+		return
+	}
+	q := r.URL.Query()
+	start, err := h.cfg.ParseKey(q.Get("start"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	end, err := h.cfg.ParseKey(q.Get("end"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit, err := h.limit(r, "limit", h.cfg.MaxItems)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	entries := make([]Entry[K, V], 0)
+	h.sl.RangeQuery(start, end, func(k K, v V) bool {
+		entries = append(entries, Entry[K, V]{k, v})
+		return len(entries) < limit
+	})
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (h *handler[K, V]) validate(w http.ResponseWriter, r *http.Request) {
+	if err := h.sl.Validate(); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]any{"ok": false, "error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+// limit parses a positive count query parameter, capped at MaxItems.
+func (h *handler[K, V]) limit(r *http.Request, name string, def int) (int, error) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return min(def, h.cfg.MaxItems), nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, s)
+	}
+	return min(n, h.cfg.MaxItems), nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	body, _ := json.Marshal(map[string]string{"error": err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
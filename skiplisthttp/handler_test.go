@@ -0,0 +1,79 @@
+package skiplisthttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/INLOpen/skiplist"
+)
+
+func get(t *testing.T, srv *httptest.Server, path string, want int, v any) {
+	t.Helper()
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != want {
+		t.Fatalf("GET %s: status %d, want %d", path, resp.StatusCode, want)
+	}
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	sl := skiplist.New[int, string]()
+	for i := 1; i <= 50; i++ {
+		sl.Insert(i, "v"+strconv.Itoa(i))
+	}
+	mux := http.NewServeMux()
+	Mount(mux, "/debug/skiplist/", sl, Config[int, string]{ParseKey: strconv.Atoi, MaxItems: 20})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var st skiplist.Stats
+	get(t, srv, "/debug/skiplist/stats", http.StatusOK, &st)
+	if st.Len != 50 || st.LevelCounts[0] != 50 {
+		t.Errorf("stats = %+v", st)
+	}
+
+	var entries []Entry[int, string]
+	get(t, srv, "/debug/skiplist/top?n=3", http.StatusOK, &entries)
+	if !reflect.DeepEqual(entries, []Entry[int, string]{{1, "v1"}, {2, "v2"}, {3, "v3"}}) {
+		t.Errorf("top = %v", entries)
+	}
+	get(t, srv, "/debug/skiplist/top?n=2&reverse=1", http.StatusOK, &entries)
+	if !reflect.DeepEqual(entries, []Entry[int, string]{{50, "v50"}, {49, "v49"}}) {
+		t.Errorf("top reverse = %v", entries)
+	}
+
+	get(t, srv, "/debug/skiplist/range?start=10&end=12", http.StatusOK, &entries)
+	if len(entries) != 3 || entries[0].Key != 10 || entries[2].Key != 12 {
+		t.Errorf("range = %v", entries)
+	}
+	get(t, srv, "/debug/skiplist/range?start=1&end=50", http.StatusOK, &entries)
+	if len(entries) != 20 {
+		t.Errorf("range returned %d entries, want MaxItems = 20", len(entries))
+	}
+	get(t, srv, "/debug/skiplist/range?start=x&end=2", http.StatusBadRequest, nil)
+	get(t, srv, "/debug/skiplist/top?n=-1", http.StatusBadRequest, nil)
+
+	var result struct{ OK bool }
+	get(t, srv, "/debug/skiplist/validate", http.StatusOK, &result)
+	if !result.OK {
+		t.Error("validate reported a healthy list as broken")
+	}
+}
+
+func TestHandler_NoParseKey(t *testing.T) {
+	srv := httptest.NewServer(Handler(skiplist.New[int, int](), Config[int, int]{}))
+	defer srv.Close()
+	get(t, srv, "/range?start=1&end=2", http.StatusNotImplemented, nil)
+}
@@ -0,0 +1,95 @@
+package skiplist
+
+import "slices"
+
+// SliceView is a sorted-slice copy of the entries of a skiplist, for
+// read-mostly consumers that need random access by index (binary search,
+// plotting, pagination by offset). The copy is rebuilt lazily, on the first
+// access after the skiplist's Version changed, so a view over a list that is
+// rarely written costs one O(n) export per change instead of one per read.
+//
+// Rebuilds allocate new slices (copy-on-write): the slices returned by Keys,
+// Values and Snapshot are never modified afterwards and stay valid, but they
+// are shared and must not be modified by the caller. Each method refreshes
+// the view on its own, so use Snapshot when several reads must observe the
+// same version.
+//
+// All methods are safe for concurrent use.
+//
+// SliceView คือสำเนาของข้อมูลใน skiplist ในรูปแบบ slice ที่เรียงลำดับแล้ว
+// จะสร้างใหม่เมื่อ Version ของ skiplist เปลี่ยนและมีการอ่านครั้งถัดไปเท่านั้น
+type SliceView[K any, V any] struct {
+	sl *SkipList[K, V]
+
+	mu      syncMutex
+	built   bool
+	version uint64
+	keys    []K
+	values  []V
+}
+
+// AsSortedSlice returns a SliceView of the entries of sl.
+// AsSortedSlice คืนค่า SliceView ของข้อมูลใน sl
+func (sl *SkipList[K, V]) AsSortedSlice() *SliceView[K, V] {
+	return &SliceView[K, V]{sl: sl}
+}
+
+// Snapshot returns the keys and values, in key order, of the current version
+// of the skiplist. The two slices have the same length.
+// Snapshot คืนค่า keys และ values ของเวอร์ชันปัจจุบัน เรียงตามลำดับ key
+func (v *SliceView[K, V]) Snapshot() ([]K, []V) {
+	sl := v.sl
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.built && sl.Version() == v.version {
+		return v.keys, v.values
+	}
+
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+	keys := make([]K, 0, sl.length)
+	values := make([]V, 0, sl.length)
+	for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+		keys = append(keys, n.key)
+		values = append(values, n.value)
+	}
+	v.keys, v.values, v.version, v.built = keys, values, sl.version, true
+	return keys, values
+}
+
+// Keys returns the keys in ascending order.
+// Keys คืนค่า keys ทั้งหมดเรียงจากน้อยไปมาก
+func (v *SliceView[K, V]) Keys() []K {
+	keys, _ := v.Snapshot()
+	return keys
+}
+
+// Values returns the values in the order of their keys.
+// Values คืนค่า values ทั้งหมดตามลำดับของ key
+func (v *SliceView[K, V]) Values() []V {
+	_, values := v.Snapshot()
+	return values
+}
+
+// Len returns the number of entries.
+// Len คืนค่าจำนวนรายการ
+func (v *SliceView[K, V]) Len() int {
+	keys, _ := v.Snapshot()
+	return len(keys)
+}
+
+// At returns the entry at index i in key order. It panics if i is out of
+// range.
+// At คืนค่ารายการที่ตำแหน่ง i ตามลำดับ key
+func (v *SliceView[K, V]) At(i int) (K, V) {
+	keys, values := v.Snapshot()
+	return keys[i], values[i]
+}
+
+// Search returns the index of the first key greater than or equal to key,
+// and whether that key is equal to key, by binary search.
+// Search ค้นหาตำแหน่งของ key แรกที่มากกว่าหรือเท่ากับ key ด้วย binary search
+func (v *SliceView[K, V]) Search(key K) (int, bool) {
+	keys, _ := v.Snapshot()
+	return slices.BinarySearchFunc(keys, key, v.sl.compare)
+}
@@ -0,0 +1,53 @@
+package skiplist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSliceView(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			view := sl.AsSortedSlice()
+			if view.Len() != 0 {
+				t.Fatalf("Len() of an empty view = %d", view.Len())
+			}
+
+			for _, k := range []int{30, 10, 20} {
+				sl.Insert(k, "v")
+			}
+			keys := view.Keys()
+			if want := []int{10, 20, 30}; !slices.Equal(keys, want) {
+				t.Fatalf("Keys() = %v, want %v", keys, want)
+			}
+			if k, v := view.At(1); k != 20 || v != "v" {
+				t.Errorf("At(1) = %d, %q", k, v)
+			}
+			if i, found := view.Search(20); i != 1 || !found {
+				t.Errorf("Search(20) = %d, %v, want 1, true", i, found)
+			}
+			if i, found := view.Search(25); i != 2 || found {
+				t.Errorf("Search(25) = %d, %v, want 2, false", i, found)
+			}
+
+			// Reads without writes reuse the cached slices.
+			if again := view.Keys(); &again[0] != &keys[0] {
+				t.Error("view was rebuilt without a write")
+			}
+
+			sl.Insert(15, "w")
+			sl.Delete(30)
+			if got, want := view.Keys(), []int{10, 15, 20}; !slices.Equal(got, want) {
+				t.Errorf("Keys() after writes = %v, want %v", got, want)
+			}
+			if values := view.Values(); !slices.Equal(values, []string{"v", "w", "v"}) {
+				t.Errorf("Values() = %v", values)
+			}
+			// Slices handed out before the rebuild are left untouched.
+			if want := []int{10, 20, 30}; !slices.Equal(keys, want) {
+				t.Errorf("old Keys() slice changed to %v", keys)
+			}
+		})
+	}
+}
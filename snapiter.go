@@ -0,0 +1,137 @@
+package skiplist
+
+import "slices"
+
+// SnapshotIterator iterates over a copy of the entries of a skiplist taken by
+// SnapshotIterator. Its methods never take the lock of the list: writers are
+// only blocked while the copy is made, and the iteration never observes
+// changes made after it, however long it lasts (report generation, exports).
+// It is positioned before the first entry; a call to Next is required.
+//
+// A SnapshotIterator is not safe for concurrent use by several goroutines.
+//
+// SnapshotIterator วนลูปบนสำเนาของข้อมูลใน skiplist จึงไม่ขวาง writer
+// และไม่เห็นการเปลี่ยนแปลงที่เกิดขึ้นหลังจากสร้าง
+type SnapshotIterator[K any, V any] struct {
+	compare Comparator[K]
+	keys    []K
+	values  []V
+	version uint64
+	pos     int // -1 before the first entry, len(keys) after the last
+
+	registry *snapshotRegistry // registry of the source list (see ListSnapshots)
+	id       uint64
+}
+
+// SnapshotIterator copies the entries of the skiplist under a single read
+// lock, in O(n) time and memory, and returns an iterator over the copy.
+// SnapshotIterator คัดลอกข้อมูลภายใต้ read lock ครั้งเดียวแล้วคืนค่า iterator บนสำเนานั้น
+func (sl *SkipList[K, V]) SnapshotIterator() *SnapshotIterator[K, V] {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	it := &SnapshotIterator[K, V]{
+		compare: sl.compare,
+		keys:    make([]K, 0, sl.length),
+		values:  make([]V, 0, sl.length),
+		version: sl.version,
+		pos:     -1,
+	}
+	for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+		it.keys = append(it.keys, n.key)
+		it.values = append(it.values, n.value)
+	}
+	sl.registerIterator(it)
+	return it
+}
+
+// Release drops the copy of the entries, leaving the iterator empty, and
+// removes the snapshot from ListSnapshots. Release is a no-op on a released
+// iterator.
+// Release ปล่อยสำเนาของข้อมูลและนำ snapshot ออกจาก ListSnapshots
+func (it *SnapshotIterator[K, V]) Release() {
+	it.registry.remove(it.id)
+	it.keys, it.values, it.pos = nil, nil, -1
+}
+
+// Len returns the number of entries of the snapshot.
+// Len คืนค่าจำนวนรายการใน snapshot
+func (it *SnapshotIterator[K, V]) Len() int {
+	return len(it.keys)
+}
+
+// Version returns the Version of the skiplist the snapshot was taken at.
+// Version คืนค่า Version ของ skiplist ณ เวลาที่สร้าง snapshot
+func (it *SnapshotIterator[K, V]) Version() uint64 {
+	return it.version
+}
+
+// Next moves to the next entry and reports whether there is one.
+// Next เลื่อนไปยังรายการถัดไป และคืนค่า false เมื่อไม่มีรายการเหลือ
+func (it *SnapshotIterator[K, V]) Next() bool {
+	if it.pos < len(it.keys) {
+		it.pos++
+	}
+	return it.pos < len(it.keys)
+}
+
+// Prev moves to the previous entry and reports whether there is one.
+// Prev เลื่อนไปยังรายการก่อนหน้า และคืนค่า false เมื่อไม่มีรายการเหลือ
+func (it *SnapshotIterator[K, V]) Prev() bool {
+	if it.pos >= 0 {
+		it.pos--
+	}
+	return it.pos >= 0
+}
+
+// First moves to the first entry and reports whether there is one.
+// First เลื่อนไปยังรายการแรก
+func (it *SnapshotIterator[K, V]) First() bool {
+	it.pos = 0
+	return it.valid()
+}
+
+// Last moves to the last entry and reports whether there is one.
+// Last เลื่อนไปยังรายการสุดท้าย
+func (it *SnapshotIterator[K, V]) Last() bool {
+	it.pos = len(it.keys) - 1
+	return it.valid()
+}
+
+// Seek moves to the first entry with a key greater than or equal to key and
+// reports whether there is one.
+// Seek เลื่อนไปยังรายการแรกที่มี key มากกว่าหรือเท่ากับ key
+func (it *SnapshotIterator[K, V]) Seek(key K) bool {
+	it.pos, _ = slices.BinarySearchFunc(it.keys, key, it.compare)
+	return it.valid()
+}
+
+// Reset moves the iterator back before the first entry.
+// Reset เลื่อน Iterator กลับไปก่อนรายการแรก
+func (it *SnapshotIterator[K, V]) Reset() {
+	it.pos = -1
+}
+
+// Key returns the key of the current entry. It panics if the iterator is not
+// positioned on an entry.
+// Key คืนค่า key ของรายการปัจจุบัน
+func (it *SnapshotIterator[K, V]) Key() K {
+	if !it.valid() {
+		panic("skiplist: Key() called on exhausted or invalid iterator")
+	}
+	return it.keys[it.pos]
+}
+
+// Value returns the value of the current entry. It panics if the iterator is
+// not positioned on an entry.
+// Value คืนค่า value ของรายการปัจจุบัน
+func (it *SnapshotIterator[K, V]) Value() V {
+	if !it.valid() {
+		panic("skiplist: Value() called on exhausted or invalid iterator")
+	}
+	return it.values[it.pos]
+}
+
+func (it *SnapshotIterator[K, V]) valid() bool {
+	return it.pos >= 0 && it.pos < len(it.keys)
+}
@@ -0,0 +1,75 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSnapshotIterator(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			empty := sl.SnapshotIterator()
+			if empty.Next() || empty.First() || empty.Last() || empty.Seek(0) {
+				t.Error("iterator over an empty snapshot moved")
+			}
+			for i := 0; i < 100; i++ {
+				sl.Insert(i*2, i)
+			}
+			it := sl.SnapshotIterator()
+			if it.Len() != 100 || it.Version() != sl.Version() {
+				t.Fatalf("Len() = %d, Version() = %d", it.Len(), it.Version())
+			}
+
+			// Writes made after the snapshot are not observed, and the
+			// iteration does not block them.
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sl.Clear()
+				sl.Insert(1, -1)
+			}()
+			n := 0
+			for it.Next() {
+				if it.Key() != n*2 || it.Value() != n {
+					t.Fatalf("entry %d = %d:%d", n, it.Key(), it.Value())
+				}
+				n++
+				if n == 50 {
+					wg.Wait()
+				}
+			}
+			if n != 100 {
+				t.Errorf("visited %d entries, want 100", n)
+			}
+			if it.Next() {
+				t.Error("Next() after the end = true")
+			}
+
+			if !it.Prev() || it.Key() != 198 {
+				t.Error("Prev() after the end did not move to the last entry")
+			}
+			if !it.Seek(51) || it.Key() != 52 || !it.Seek(52) || it.Key() != 52 {
+				t.Error("Seek did not find the ceiling entry")
+			}
+			if it.Seek(199) {
+				t.Error("Seek(199) = true")
+			}
+			if !it.First() || it.Key() != 0 || it.Prev() {
+				t.Error("First/Prev misbehaved")
+			}
+			if !it.Last() || it.Key() != 198 {
+				t.Error("Last() did not move to the last entry")
+			}
+			it.Reset()
+			if !it.Next() || it.Key() != 0 {
+				t.Error("Next() after Reset() did not move to the first entry")
+			}
+			it.Reset()
+			if catchPanic(func() { it.Key() }) == nil {
+				t.Error("Key() before Next() did not panic")
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package skiplist
+
+import (
+	"runtime"
+	"slices"
+	"time"
+	"unsafe"
+)
+
+// SnapshotInfo describes a live snapshot of a skiplist, as listed by
+// ListSnapshots.
+// SnapshotInfo คือข้อมูลของ snapshot ที่ยังไม่ถูกปล่อยของ skiplist
+type SnapshotInfo struct {
+	// ID is the generation stamp of the snapshot: snapshots of a list are
+	// numbered from 1 in the order they were taken.
+	ID uint64 `json:"id"`
+	// Kind is "frozen" for a FrozenSkipList detached by Rotate, or
+	// "iterator" for a SnapshotIterator.
+	Kind    string    `json:"kind"`
+	Version uint64    `json:"version"` // Version of the list when the snapshot was taken
+	Created time.Time `json:"created"`
+	Len     int       `json:"len"`
+	// Bytes estimates the memory the snapshot keeps reachable: the arena
+	// chunks or nodes of a frozen list, or the copied keys and values of an
+	// iterator, plus the entry sizes of WithSizeFunc, if any.
+	Bytes int `json:"bytes"`
+}
+
+// snapshotRegistry records the live snapshots of a list. It has its own
+// mutex so that snapshots are listed and released without the list lock.
+type snapshotRegistry struct {
+	mu   syncMutex
+	next uint64
+	live []registeredSnapshot
+}
+
+type registeredSnapshot struct {
+	info    SnapshotInfo
+	release func() // nil for snapshots only their holder can release
+}
+
+// add registers a snapshot and returns its ID.
+func (r *snapshotRegistry) add(info SnapshotInfo, release func()) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.next++
+	info.ID = r.next
+	info.Created = time.Now()
+	r.live = append(r.live, registeredSnapshot{info: info, release: release})
+	return info.ID
+}
+
+// remove unregisters the snapshot of id and returns it, if it was live.
+func (r *snapshotRegistry) remove(id uint64) (registeredSnapshot, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := slices.IndexFunc(r.live, func(s registeredSnapshot) bool { return s.info.ID == id })
+	if i < 0 {
+		return registeredSnapshot{}, false
+	}
+	s := r.live[i]
+	r.live = slices.Delete(r.live, i, i+1)
+	return s, true
+}
+
+// ListSnapshots returns the snapshots of the list that have not been
+// released, oldest first, with the memory they retain, so that an operator
+// can find the forgotten snapshot pinning gigabytes. The FrozenSkipLists of
+// Rotate stay listed until FlushTo or Release, the SnapshotIterators until
+// Release. A snapshot dropped without being released is listed until the
+// garbage collector reclaims it. Copies such as Immutable, SnapshotAt or
+// AsSortedSlice share nothing with the list and are not listed.
+// ListSnapshots คืนค่ารายการ snapshot ที่ยังไม่ถูกปล่อยพร้อมหน่วยความจำที่แต่ละตัวถือไว้
+func (sl *SkipList[K, V]) ListSnapshots() []SnapshotInfo {
+	r := &sl.snapshots
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	infos := make([]SnapshotInfo, len(r.live))
+	for i, s := range r.live {
+		infos[i] = s.info
+	}
+	return infos
+}
+
+// ReleaseSnapshot releases the frozen list of id, as its Release method
+// does, and reports whether it did. It returns false for an unknown or
+// released ID and for iterators, which are not safe for concurrent use and
+// can only be released by their holder.
+// ReleaseSnapshot ปล่อย frozen list ตาม ID ที่ได้จาก ListSnapshots
+func (sl *SkipList[K, V]) ReleaseSnapshot(id uint64) bool {
+	r := &sl.snapshots
+	r.mu.Lock()
+	i := slices.IndexFunc(r.live, func(s registeredSnapshot) bool { return s.info.ID == id && s.release != nil })
+	if i < 0 {
+		r.mu.Unlock()
+		return false
+	}
+	s := r.live[i]
+	r.live = slices.Delete(r.live, i, i+1)
+	r.mu.Unlock()
+
+	s.release()
+	return true
+}
+
+// registerFrozen registers the frozen list f detached from sl. The registry only refers to the list inside f, so that a frozen
+// list dropped without Release is still garbage collected: the finalizer of
+// f unregisters it. The caller must hold the write lock of sl.
+func (sl *SkipList[K, V]) registerFrozen(f *FrozenSkipList[K, V]) {
+	fl := f.sl
+	bytes := fl.length * int(unsafe.Sizeof(nodeBlock1[K, V]{}))
+	if a, ok := fl.allocator.(*arenaAllocator[K, V]); ok {
+		bytes = a.allocated
+	}
+	f.id = sl.snapshots.add(SnapshotInfo{
+		Kind:    "frozen",
+		Version: fl.version,
+		Len:     fl.length,
+		Bytes:   bytes + int(fl.bytes.Load()),
+	}, func() { fl.releaseFrozen(sl) })
+	runtime.SetFinalizer(f, func(f *FrozenSkipList[K, V]) { f.owner.snapshots.remove(f.id) })
+}
+
+// registerIterator registers the snapshot iterator it taken from sl, which
+// only its holder can release. The caller must hold a lock of sl.
+func (sl *SkipList[K, V]) registerIterator(it *SnapshotIterator[K, V]) {
+	var k K
+	var v V
+	it.registry = &sl.snapshots
+	it.id = sl.snapshots.add(SnapshotInfo{
+		Kind:    "iterator",
+		Version: it.version,
+		Len:     len(it.keys),
+		Bytes:   cap(it.keys)*int(unsafe.Sizeof(k)) + cap(it.values)*int(unsafe.Sizeof(v)) + int(sl.bytes.Load()),
+	}, nil)
+	runtime.SetFinalizer(it, func(it *SnapshotIterator[K, V]) { it.registry.remove(it.id) })
+}
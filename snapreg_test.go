@@ -0,0 +1,59 @@
+package skiplist
+
+import (
+	"runtime"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestListSnapshots(t *testing.T) {
+	sl := New[int, int](WithArena[int, int](1 << 16))
+	for i := 0; i < 1000; i++ {
+		sl.Insert(i, i)
+	}
+	it := sl.SnapshotIterator()
+	frozen := sl.Rotate()
+	sl.Insert(1, 1)
+
+	snaps := sl.ListSnapshots()
+	if len(snaps) != 2 {
+		t.Fatalf("ListSnapshots() = %+v", snaps)
+	}
+	// The iterator copies 1000 keys and values.
+	if s := snaps[0]; s.ID != 1 || s.Kind != "iterator" || s.Len != 1000 || s.Bytes < 1000*int(unsafe.Sizeof(0)*2) {
+		t.Errorf("iterator snapshot = %+v", s)
+	}
+	if s := snaps[1]; s.ID != 2 || s.Kind != "frozen" || s.Len != 1000 || s.Bytes < 1<<16 || s.Created.IsZero() {
+		t.Errorf("frozen snapshot = %+v", s)
+	}
+
+	if sl.ReleaseSnapshot(1) {
+		t.Error("ReleaseSnapshot released an iterator")
+	}
+	if !sl.ReleaseSnapshot(2) || sl.ReleaseSnapshot(2) {
+		t.Error("ReleaseSnapshot of the frozen list did not report a single release")
+	}
+	if frozen.Len() != 0 || sl.spare == nil {
+		t.Errorf("after ReleaseSnapshot, frozen Len() = %d, spare arena = %v", frozen.Len(), sl.spare != nil)
+	}
+	it.Release()
+	if it.Len() != 0 || it.Next() {
+		t.Error("released iterator still holds entries")
+	}
+	if snaps := sl.ListSnapshots(); len(snaps) != 0 {
+		t.Errorf("ListSnapshots() after release = %+v", snaps)
+	}
+
+	// A snapshot dropped without Release is unlisted once collected.
+	sl.SnapshotIterator()
+	sl.Rotate()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(sl.ListSnapshots()) > 0 && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if snaps := sl.ListSnapshots(); len(snaps) != 0 {
+		t.Errorf("dropped snapshots still listed: %+v", snaps)
+	}
+}
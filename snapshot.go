@@ -0,0 +1,524 @@
+package skiplist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// ErrUnsorted is returned by BulkLoad and Load when the input keys are not in
+// strictly ascending order (or not greater than the keys already in the list).
+var ErrUnsorted = errors.New("skiplist: bulk load input is not sorted in strictly ascending key order")
+
+// BulkLoad appends the entries produced by next to the end of the skiplist.
+// next is called until it returns false. Keys must be produced in strictly
+// ascending order and be greater than every key already in the list; this lets
+// each entry be linked in O(1) without searching, which makes loading n sorted
+// entries O(n) instead of O(n log n).
+//
+// BulkLoad returns the number of entries appended. If a key is out of order it
+// stops and returns ErrUnsorted; the entries appended before it are kept.
+// The write lock is held for the whole load.
+//
+// BulkLoad เพิ่มรายการที่เรียงลำดับแล้วต่อท้าย skiplist โดยไม่ต้องค้นหาตำแหน่ง
+// key ต้องเรียงจากน้อยไปมากและมากกว่า key ทั้งหมดที่มีอยู่แล้ว
+func (sl *SkipList[K, V]) BulkLoad(next func() (key K, value V, ok bool)) (int, error) {
+	defer rethrowCallbackPanic("BulkLoad")
+	tr := sl.traceStart(OpBulkLoad)
+	sl.mutex.Lock()
+	tr.locked()
+	defer sl.mutex.Unlock()
+	defer sl.traceEnd(&tr)
+
+	n, err := sl.bulkAppend(next)
+	tr.keys = n
+	return n, err
+}
+
+// bulkAppend implements BulkLoad. The caller must hold the write lock.
+func (sl *SkipList[K, V]) bulkAppend(next func() (K, V, bool)) (int, error) {
+	if sl.frozen {
+		return 0, ErrFrozen
+	}
+	// last[i] is the last node with a pointer at level i, and lastPos[i] its
+	// 1-based position (the header is at position 0).
+	// lastWeight[i] and lastHash[i] are the total weight and hash up to and
+	// including last[i] when WithWeights and WithMerkle are used.
+	var last [MaxLevel]*node[K, V]
+	var lastPos, lastWeight [MaxLevel]int
+	var lastHash [MaxLevel]uint64
+	current, pos, weight, hash := sl.header, 0, 0, uint64(0)
+	for i := sl.level; i >= 0; i-- {
+		for current.forward[i] != nil {
+			pos += current.span[i]
+			if sl.weight != nil {
+				weight += current.wspan[i]
+			}
+			if sl.merkle != nil {
+				hash += current.hspan[i]
+			}
+			current = current.forward[i]
+		}
+		last[i], lastPos[i], lastWeight[i], lastHash[i] = current, pos, weight, hash
+	}
+	for i := sl.level + 1; i < MaxLevel; i++ {
+		last[i] = sl.header
+	}
+	tail := current
+
+	count := 0
+	if sl.hooks.OnBoundsChange != nil {
+		// Appending only moves the upper bound (and sets the lower one of an
+		// empty list), so the final bounds are reported once.
+		defer func() {
+			if count > 0 {
+				sl.boundsChanged()
+			}
+		}()
+	}
+	for {
+		key, value, ok := next()
+		if !ok {
+			return count, nil
+		}
+		if err := sl.checkKey(key); err != nil {
+			return count, err
+		}
+		if tail != sl.header && sl.compare(tail.key, key) >= 0 {
+			return count, ErrUnsorted
+		}
+		// Computed before the structure is modified, see CallbackPanic.
+		var w int
+		if sl.weight != nil {
+			w = sl.weightOf(key, value)
+		}
+		var h uint64
+		if sl.merkle != nil {
+			h = sl.merkle(key, value)
+		}
+		var size int
+		if sl.sizeOf != nil {
+			var err error
+			if size, err = sl.entrySize(key, value); err != nil {
+				return count, err
+			}
+		}
+
+		level := sl.randomLevel()
+		n := sl.allocator.Get(level)
+		if n == nil {
+			return count, ErrArenaFull
+		}
+		if level-1 > sl.level {
+			sl.level = level - 1
+		}
+		n.key = key
+		n.prefix = sl.prefixOf(key)
+		n.value = value
+		sl.stamp(n)
+		if sl.weight != nil {
+			n.sizeWSpan()
+			sl.weights += w
+		}
+		if sl.merkle != nil {
+			n.sizeHSpan()
+			sl.hashes += h
+		}
+		if sl.backLinks {
+			n.sizeBack()
+		}
+		sl.setLength(sl.length + 1)
+		sl.bytes.Add(int64(size))
+		sl.version++
+		for i := 0; i < level; i++ {
+			last[i].forward[i] = n
+			if !sl.noRanks {
+				last[i].span[i] = sl.length - lastPos[i]
+			}
+			if sl.backLinks {
+				n.back[i] = last[i]
+			}
+			if sl.weight != nil {
+				last[i].wspan[i] = sl.weights - lastWeight[i]
+				lastWeight[i] = sl.weights
+			}
+			if sl.merkle != nil {
+				last[i].hspan[i] = sl.hashes - lastHash[i]
+				lastHash[i] = sl.hashes
+			}
+			last[i], lastPos[i] = n, sl.length
+		}
+		n.backward = tail
+		tail = n
+		sl.onInserted(key, value)
+		if sl.hooks.OnRankChange != nil {
+			sl.hooks.onRankChange(key, -1, sl.length-1)
+		}
+		count++
+		if sl.watermarks != nil {
+			sl.checkWatermarks()
+		}
+	}
+}
+
+// Save writes a snapshot of the skiplist to w using the configured codec
+// (see WithCodec; encoding/gob by default, so K and V must then be
+// gob-encodable). The snapshot is taken under a single read lock and can be
+// restored with Load.
+//
+// The codec output is framed into blocks, each protected by a CRC-32C, and
+// followed by a footer recording the entry count, the smallest and largest
+// keys and a checksum of the whole stream, so that Load detects damaged data.
+//
+// Save เขียน snapshot ของ skiplist ลงใน w โดยใช้ codec ที่กำหนดไว้
+// ข้อมูลถูกแบ่งเป็น block ที่มี CRC-32C และมี footer สำหรับตรวจสอบความถูกต้อง
+func (sl *SkipList[K, V]) Save(w io.Writer) error {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	codec := sl.snapshotCodec()
+	fw := newFrameWriter(w, frameMagic)
+	enc := codec.NewEncoder(fw)
+	if err := enc.WriteHeader(sl.length); err != nil {
+		return err
+	}
+	for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+		if err := enc.WriteEntry(n.key, n.value); err != nil {
+			return err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	// The bounds are encoded with the same codec as entries with zero values.
+	var bounds bytes.Buffer
+	benc := codec.NewEncoder(&bounds)
+	var zero V
+	var err error
+	if sl.length == 0 {
+		err = benc.WriteHeader(0)
+	} else {
+		err = errors.Join(
+			benc.WriteHeader(2),
+			benc.WriteEntry(sl.header.forward[0].key, zero),
+			benc.WriteEntry(sl.getByRank(sl.length-1).key, zero),
+		)
+	}
+	if err == nil {
+		err = benc.Close()
+	}
+	if err != nil {
+		return err
+	}
+	return fw.close(sl.length, bounds.Bytes())
+}
+
+// Load replaces the contents of the skiplist with a snapshot written by Save
+// with the same codec. The entries are bulk loaded in O(n), then the block
+// checksums, the footer and the resulting structure (see Validate) are
+// verified; damaged snapshots are reported with an error wrapping ErrCorrupt.
+// On any error the list is left empty; input that does not start with the
+// header of a snapshot is rejected with an error wrapping ErrCorrupt.
+// Load also restores the snapshots written by SaveChunks, which do not use
+// the codec.
+//
+// Load แทนที่ข้อมูลทั้งหมดใน skiplist ด้วย snapshot ที่เขียนโดย Save
+// และตรวจสอบ checksum รวมถึงโครงสร้างก่อนคืนค่า หากเกิด error รายการจะว่างเปล่า
+func (sl *SkipList[K, V]) Load(r io.Reader) error {
+	codec := sl.snapshotCodec()
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(len(frameMagic))
+	chunked := string(magic) == chunkMagic
+	framed := string(magic) == frameMagic
+	if framed {
+		br.Discard(len(frameMagic))
+	}
+	fr := &frameReader{r: br}
+
+	sl.mutex.Lock()
+	defer sl.mutex.Unlock()
+	if sl.frozen {
+		return ErrFrozen
+	}
+	sl.clear()
+
+	var err error
+	switch {
+	case chunked:
+		err = sl.loadChunks(br)
+	case framed:
+		err = sl.loadEntries(codec.NewDecoder(fr))
+		if err == nil {
+			err = sl.verifyFooter(codec, fr)
+		}
+	default:
+		return fmt.Errorf("%w: not a snapshot", ErrCorrupt)
+	}
+	if err == nil {
+		err = sl.validate()
+	}
+	if err != nil {
+		sl.clear()
+	}
+	return err
+}
+
+// loadEntries bulk loads a snapshot from dec. The caller must hold the write lock.
+func (sl *SkipList[K, V]) loadEntries(dec EntryDecoder[K, V]) error {
+	count, err := dec.ReadHeader()
+	if err != nil {
+		return err
+	}
+
+	var decErr error
+	_, err = sl.bulkAppend(func() (K, V, bool) {
+		var key K
+		var value V
+		if count == 0 {
+			return key, value, false
+		}
+		if key, value, decErr = dec.ReadEntry(); decErr != nil {
+			return key, value, false
+		}
+		count--
+		return key, value, true
+	})
+	if decErr == io.EOF {
+		// A codec without a count signals the end of the stream with io.EOF.
+		decErr = nil
+		if count > 0 {
+			decErr = io.ErrUnexpectedEOF
+		}
+	}
+	if decErr != nil {
+		return decErr
+	}
+	return err
+}
+
+// verifyFooter checks the loaded entries against the footer of a framed
+// snapshot. The caller must hold the write lock.
+func (sl *SkipList[K, V]) verifyFooter(codec Codec[K, V], fr *frameReader) error {
+	footer, err := fr.finish()
+	if err != nil {
+		return err
+	}
+	if footer.count != uint64(sl.length) {
+		return fmt.Errorf("%w: snapshot records %d entries but %d were loaded", ErrCorrupt, footer.count, sl.length)
+	}
+
+	dec := codec.NewDecoder(bytes.NewReader(footer.bounds))
+	n, err := dec.ReadHeader()
+	if err != nil {
+		return fmt.Errorf("%w: unreadable snapshot bounds: %v", ErrCorrupt, err)
+	}
+	var bounds []K
+	for ; n != 0; n-- {
+		key, _, err := dec.ReadEntry()
+		if err == io.EOF && n < 0 {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: unreadable snapshot bounds: %v", ErrCorrupt, err)
+		}
+		bounds = append(bounds, key)
+	}
+	switch {
+	case sl.length == 0 && len(bounds) == 0:
+		return nil
+	case sl.length == 0 || len(bounds) != 2:
+		return fmt.Errorf("%w: snapshot bounds do not match the loaded entries", ErrCorrupt)
+	case sl.compare(bounds[0], sl.header.forward[0].key) != 0 || sl.compare(bounds[1], sl.getByRank(sl.length-1).key) != 0:
+		return fmt.Errorf("%w: snapshot bounds do not match the loaded entries", ErrCorrupt)
+	}
+	return nil
+}
+
+// Framed snapshot layout:
+//
+//	magic
+//	block*      uint32 length (> 0), uint32 CRC-32C of payload, payload
+//	terminator  uint32 0
+//	footer      uint32 length, uint32 CRC-32C of payload, payload
+//
+// The footer payload is uvarint count, uvarint len(bounds), bounds (the
+// smallest and largest keys in codec format) and the uint32 CRC-32C of all
+// block payloads. Integers are big-endian.
+const (
+	frameMagic        = "SLSNAP\x00\x02"
+	frameBlockSize    = 64 << 10
+	frameMaxBlockSize = 16 << 20
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// frameWriter splits everything written to it into checksummed blocks.
+type frameWriter struct {
+	w   io.Writer
+	buf []byte
+	sum uint32
+	err error
+}
+
+func newFrameWriter(w io.Writer, magic string) *frameWriter {
+	fw := &frameWriter{w: w}
+	_, fw.err = io.WriteString(w, magic)
+	return fw
+}
+
+func (fw *frameWriter) Write(p []byte) (int, error) {
+	if fw.err != nil {
+		return 0, fw.err
+	}
+	fw.buf = append(fw.buf, p...)
+	for len(fw.buf) >= frameBlockSize && fw.err == nil {
+		fw.writeBlock(fw.buf[:frameBlockSize])
+		fw.buf = fw.buf[:copy(fw.buf, fw.buf[frameBlockSize:])]
+	}
+	if fw.err != nil {
+		return 0, fw.err
+	}
+	return len(p), nil
+}
+
+func (fw *frameWriter) writeBlock(payload []byte) {
+	var hdr [8]byte
+	binary.BigEndian.PutUint32(hdr[:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(hdr[4:], crc32.Checksum(payload, castagnoli))
+	if _, fw.err = fw.w.Write(hdr[:]); fw.err == nil {
+		_, fw.err = fw.w.Write(payload)
+	}
+	fw.sum = crc32.Update(fw.sum, castagnoli, payload)
+}
+
+// close flushes the last block and writes the terminator and the footer.
+func (fw *frameWriter) close(count int, bounds []byte) error {
+	if len(fw.buf) > 0 && fw.err == nil {
+		fw.writeBlock(fw.buf)
+	}
+	if fw.err != nil {
+		return fw.err
+	}
+	footer := binary.AppendUvarint(nil, uint64(count))
+	footer = binary.AppendUvarint(footer, uint64(len(bounds)))
+	footer = append(footer, bounds...)
+	footer = binary.BigEndian.AppendUint32(footer, fw.sum)
+
+	out := binary.BigEndian.AppendUint32(nil, 0)
+	out = binary.BigEndian.AppendUint32(out, uint32(len(footer)))
+	out = binary.BigEndian.AppendUint32(out, crc32.Checksum(footer, castagnoli))
+	out = append(out, footer...)
+	_, err := fw.w.Write(out)
+	return err
+}
+
+// snapshotFooter is the decoded footer of a framed snapshot.
+type snapshotFooter struct {
+	count  uint64
+	bounds []byte
+}
+
+// frameReader reads the block payloads of a framed snapshot, verifying each
+// block checksum, and reports io.EOF at the terminator.
+type frameReader struct {
+	r     *bufio.Reader
+	block []byte
+	off   int
+	sum   uint32
+	done  bool
+}
+
+func (fr *frameReader) Read(p []byte) (int, error) {
+	for fr.off == len(fr.block) {
+		if fr.done {
+			return 0, io.EOF
+		}
+		if err := fr.readBlock(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, fr.block[fr.off:])
+	fr.off += n
+	return n, nil
+}
+
+// readFrame reads one length-prefixed, checksummed payload. A zero length
+// returns a nil payload.
+func (fr *frameReader) readFrame() ([]byte, error) {
+	var hdr [4]byte
+	if _, err := io.ReadFull(fr.r, hdr[:]); err != nil {
+		return nil, truncated(err)
+	}
+	size := binary.BigEndian.Uint32(hdr[:])
+	if size == 0 {
+		return nil, nil
+	}
+	if size > frameMaxBlockSize {
+		return nil, fmt.Errorf("%w: snapshot block of %d bytes", ErrCorrupt, size)
+	}
+	if _, err := io.ReadFull(fr.r, hdr[:]); err != nil {
+		return nil, truncated(err)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(fr.r, payload); err != nil {
+		return nil, truncated(err)
+	}
+	if crc32.Checksum(payload, castagnoli) != binary.BigEndian.Uint32(hdr[:]) {
+		return nil, fmt.Errorf("%w: snapshot block checksum mismatch", ErrCorrupt)
+	}
+	return payload, nil
+}
+
+func (fr *frameReader) readBlock() error {
+	payload, err := fr.readFrame()
+	if err != nil {
+		return err
+	}
+	if payload == nil {
+		fr.done = true
+	}
+	fr.block, fr.off = payload, 0
+	fr.sum = crc32.Update(fr.sum, castagnoli, payload)
+	return nil
+}
+
+// finish skips any unread blocks and reads and checks the footer.
+func (fr *frameReader) finish() (snapshotFooter, error) {
+	for !fr.done {
+		if err := fr.readBlock(); err != nil {
+			return snapshotFooter{}, err
+		}
+	}
+	payload, err := fr.readFrame()
+	if err != nil {
+		return snapshotFooter{}, err
+	}
+	bad := fmt.Errorf("%w: malformed snapshot footer", ErrCorrupt)
+	count, n := binary.Uvarint(payload)
+	if n <= 0 {
+		return snapshotFooter{}, bad
+	}
+	payload = payload[n:]
+	size, n := binary.Uvarint(payload)
+	if n <= 0 || uint64(len(payload)-n) != size+4 {
+		return snapshotFooter{}, bad
+	}
+	payload = payload[n:]
+	if binary.BigEndian.Uint32(payload[size:]) != fr.sum {
+		return snapshotFooter{}, fmt.Errorf("%w: snapshot checksum mismatch", ErrCorrupt)
+	}
+	return snapshotFooter{count: count, bounds: payload[:size]}, nil
+}
+
+// truncated maps io.EOF in the middle of a snapshot to io.ErrUnexpectedEOF.
+func truncated(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,185 @@
+package skiplist
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+)
+
+func TestBulkLoad(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			sl.Insert(-5, -5)
+
+			i := 0
+			n, err := sl.BulkLoad(func() (int, int, bool) {
+				if i == 3000 {
+					return 0, 0, false
+				}
+				i++
+				return i, i * 10, true
+			})
+			if err != nil || n != 3000 {
+				t.Fatalf("BulkLoad = %d, %v", n, err)
+			}
+			if sl.Len() != 3001 {
+				t.Fatalf("Len() = %d, want 3001", sl.Len())
+			}
+			for k := 1; k <= 3000; k++ {
+				if r := sl.Rank(k); r != k {
+					t.Fatalf("Rank(%d) = %d, want %d", k, r, k)
+				}
+				if node, ok := sl.GetByRank(k); !ok || node.Key() != k {
+					t.Fatalf("GetByRank(%d) = %v, %v", k, node, ok)
+				}
+			}
+
+			// The list stays fully functional after a bulk load.
+			sl.Insert(1500, 0)
+			sl.Delete(2000)
+			sl.Insert(5000, 0)
+			if r := sl.Rank(5000); r != 3000 {
+				t.Errorf("Rank(5000) = %d, want 3000", r)
+			}
+			it := sl.NewIterator(WithReverse[int, int]())
+			count := 0
+			for it.Next() {
+				count++
+			}
+			if count != sl.Len() {
+				t.Errorf("reverse iteration visited %d entries, want %d", count, sl.Len())
+			}
+
+			keys := []int{6000, 6001, 6001}
+			_, err = sl.BulkLoad(func() (int, int, bool) {
+				if len(keys) == 0 {
+					return 0, 0, false
+				}
+				k := keys[0]
+				keys = keys[1:]
+				return k, 0, true
+			})
+			if !errors.Is(err, ErrUnsorted) {
+				t.Errorf("BulkLoad with a duplicate key returned %v, want ErrUnsorted", err)
+			}
+		})
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	for _, setup := range getTestSetups[string, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			src := setup.constructor(nil)
+			for i := 0; i < 500; i++ {
+				src.Insert("key-"+strconv.Itoa(i), i)
+			}
+			var buf bytes.Buffer
+			if err := src.Save(&buf); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+
+			dst := setup.constructor(nil)
+			dst.Insert("stale", -1)
+			if err := dst.Load(bytes.NewReader(buf.Bytes())); err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if dst.Len() != 500 {
+				t.Fatalf("Len() after Load = %d, want 500", dst.Len())
+			}
+			if _, ok := dst.Search("stale"); ok {
+				t.Error("Load should replace existing contents")
+			}
+			src.Range(func(k string, v int) bool {
+				if n, ok := dst.Search(k); !ok || n.Value() != v {
+					t.Fatalf("Search(%q) after Load = %v, %v", k, n, ok)
+				}
+				return true
+			})
+
+			truncated := buf.Bytes()[:buf.Len()/2]
+			if err := dst.Load(bytes.NewReader(truncated)); err == nil {
+				t.Error("Load of a truncated snapshot should fail")
+			}
+		})
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	sl := New[int, string]()
+	sl.Insert(2, "two, with comma")
+	sl.Insert(1, "one")
+
+	var buf bytes.Buffer
+	err := sl.ExportCSV(&buf, func(k int, v string) []string {
+		return []string{strconv.Itoa(k), v}
+	})
+	if err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+	want := "1,one\n2,\"two, with comma\"\n"
+	if buf.String() != want {
+		t.Errorf("ExportCSV wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	src := New[int, string]()
+	for i := 0; i < 20000; i++ {
+		src.Insert(i, strconv.Itoa(i))
+	}
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	snap := buf.Bytes()
+
+	tests := []struct {
+		name string
+		data func() []byte
+	}{
+		{"FlippedBit", func() []byte {
+			b := bytes.Clone(snap)
+			b[len(b)/2] ^= 0x10
+			return b
+		}},
+		{"FlippedFooterBit", func() []byte {
+			b := bytes.Clone(snap)
+			b[len(b)-6] ^= 0x01
+			return b
+		}},
+		{"Truncated", func() []byte { return snap[:len(snap)-3] }},
+		{"MissingFooter", func() []byte { return snap[:len(snap)-20] }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := New[int, string]()
+			dst.Insert(-1, "old")
+			err := dst.Load(bytes.NewReader(tt.data()))
+			if err == nil {
+				t.Fatal("Load of a damaged snapshot should fail")
+			}
+			if !errors.Is(err, ErrCorrupt) && !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("Load returned %v, want ErrCorrupt or io.ErrUnexpectedEOF", err)
+			}
+			if dst.Len() != 0 {
+				t.Errorf("Len() after failed Load = %d, want 0", dst.Len())
+			}
+		})
+	}
+}
+
+func TestLoad_Unframed(t *testing.T) {
+	// A bare codec stream has no frame header and is rejected.
+	raw := encodeEntries[int, string](t, GobCodec[int, string]{}, []int{1, 2}, []string{"a", "b"})
+	sl := New[int, string]()
+	sl.Insert(3, "c")
+	if err := sl.Load(bytes.NewReader(raw)); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("Load of an unframed stream returned %v, want ErrCorrupt", err)
+	}
+	if sl.Len() != 0 {
+		t.Errorf("Len() after failed Load = %d, want 0", sl.Len())
+	}
+}
@@ -0,0 +1,92 @@
+package skiplist
+
+// Stats is a point-in-time summary of the shape and memory use of a skiplist.
+// Stats คือข้อมูลสรุปโครงสร้างและการใช้หน่วยความจำของ skiplist ณ เวลาหนึ่ง
+type Stats struct {
+	Len     int    `json:"len"`
+	Levels  int    `json:"levels"`  // number of levels in use
+	Version uint64 `json:"version"` // see Version
+	// LevelCounts[i] is the number of nodes linked at level i.
+	LevelCounts []int `json:"level_counts"`
+	// Allocator is "pool" or "arena".
+	Allocator string `json:"allocator"`
+	// Locking is "rwmutex", or "mutex" until a list created with
+	// WithAdaptiveLocking switches to its read-write lock.
+	Locking string `json:"locking"`
+	// ArenaChunks and ArenaCapacity (in nodes) describe the arena, if any.
+	// ArenaUsed counts the nodes handed out since the last reset, including
+	// nodes of deleted entries, which an arena only reclaims on Clear.
+	ArenaChunks   int `json:"arena_chunks,omitempty"`
+	ArenaCapacity int `json:"arena_capacity,omitempty"`
+	ArenaUsed     int `json:"arena_used,omitempty"`
+	// LevelCompactions counts the times the list was lowered to the height
+	// expected for its length after mass deletions (see CompactLevels).
+	LevelCompactions int `json:"level_compactions"`
+	// Latency summarizes, by operation name (see Op.String), the latencies
+	// sampled with WithLatencySampling. It is nil without the option.
+	Latency map[string]LatencySummary `json:"latency,omitempty"`
+}
+
+// Stats returns a summary of the skiplist. It walks every node once under the
+// read lock, so it is O(n).
+// Stats คืนค่าข้อมูลสรุปของ skiplist โดยใช้เวลา O(n)
+func (sl *SkipList[K, V]) Stats() Stats {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	st := Stats{
+		Len:         sl.length,
+		Levels:      sl.level + 1,
+		Version:     sl.version,
+		LevelCounts: make([]int, sl.level+1),
+		Allocator:   sl.allocatorName(),
+		Locking:     sl.mutex.mode(),
+
+		LevelCompactions: sl.levelCompactions,
+	}
+	if sl.latency != nil {
+		st.Latency = sl.latency.summaries()
+	}
+	for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+		for i := range n.forward {
+			st.LevelCounts[i]++
+		}
+	}
+	if a, ok := sl.allocator.(*arenaAllocator[K, V]); ok {
+		for _, slab := range a.slabs {
+			chunks, capacity, used := slab.usage()
+			st.ArenaChunks += chunks
+			st.ArenaCapacity += capacity
+			st.ArenaUsed += used
+		}
+	}
+	return st
+}
+
+// Cap returns the number of entries that can still be inserted into an
+// arena-backed list before the arena allocates a new chunk (or, for a
+// WithFixedArena list, before it is full), e.g. to decide when to flush a
+// memtable. Nodes of different heights are carved from different size
+// classes, so the figure is an estimate: an insert may grow the arena while
+// other classes still have room. Deleted entries are only reclaimed by Clear.
+// Cap returns -1 for pool-backed lists, which have no capacity limit.
+// Cap คืนค่าจำนวนรายการที่ยังเพิ่มได้ก่อนที่ Arena จะต้องขยาย (-1 ถ้าไม่ได้ใช้ Arena)
+func (sl *SkipList[K, V]) Cap() int {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	a, ok := sl.allocator.(*arenaAllocator[K, V])
+	if !ok {
+		return -1
+	}
+	_, free := a.nodeUsage()
+	return free
+}
+
+// allocatorName returns "pool" or "arena". The caller must hold a lock.
+func (sl *SkipList[K, V]) allocatorName() string {
+	if _, ok := sl.allocator.(*arenaAllocator[K, V]); ok {
+		return "arena"
+	}
+	return "pool"
+}
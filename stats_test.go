@@ -0,0 +1,70 @@
+package skiplist
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			for i := 0; i < 2000; i++ {
+				sl.Insert(i, i)
+			}
+			sl.Delete(7)
+
+			st := sl.Stats()
+			if st.Len != 1999 || st.Version != sl.Version() {
+				t.Errorf("Stats() = %+v", st)
+			}
+			if len(st.LevelCounts) != st.Levels || st.LevelCounts[0] != 1999 {
+				t.Errorf("LevelCounts = %v, Levels = %d", st.LevelCounts, st.Levels)
+			}
+			for i := 1; i < len(st.LevelCounts); i++ {
+				if st.LevelCounts[i] > st.LevelCounts[i-1] || st.LevelCounts[i] == 0 {
+					t.Errorf("LevelCounts not decreasing: %v", st.LevelCounts)
+				}
+			}
+
+			if setup.name == "WithArena" {
+				if st.Allocator != "arena" || st.ArenaUsed != 2000 || st.ArenaCapacity < st.ArenaUsed || st.ArenaChunks == 0 {
+					t.Errorf("arena stats = %+v", st)
+				}
+			} else if st.Allocator != "pool" || st.ArenaChunks != 0 {
+				t.Errorf("pool stats = %+v", st)
+			}
+		})
+	}
+}
+
+func TestCap(t *testing.T) {
+	if c := New[int, int]().Cap(); c != -1 {
+		t.Errorf("pool-backed Cap() = %d, want -1", c)
+	}
+
+	sl := New[int, int](WithFixedArena[int, int](1 << 20))
+	initial := sl.Cap()
+	if initial <= 0 {
+		t.Fatalf("empty fixed arena: Cap() = %d", initial)
+	}
+	c := initial
+	inserted := 0
+	for i := 0; ; i++ {
+		if _, err := sl.TryInsert(i, i); err != nil {
+			break
+		}
+		inserted++
+		if got := sl.Cap(); got != c-1 {
+			t.Fatalf("Cap() after %d inserts = %d, want %d", inserted, got, c-1)
+		}
+		c--
+	}
+	// Tall nodes cannot use the room left in the classes of short ones, so
+	// the arena fills up before Cap reaches 0.
+	if c < 0 || c >= initial {
+		t.Errorf("full arena: Cap() = %d after %d inserts", c, inserted)
+	}
+
+	sl.Clear()
+	if got := sl.Cap(); got != initial {
+		t.Errorf("Cap() after Clear = %d, want %d", got, initial)
+	}
+}
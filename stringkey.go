@@ -0,0 +1,34 @@
+package skiplist
+
+// SearchString is Search for lists with []byte keys, such as those created
+// by NewBytes, looking the key up by a string without converting it: the
+// comparator sees a read-only []byte view of s, so the lookup does not
+// allocate. Comparators must not modify or retain the keys they are given.
+// Built with the purego tag, the view is a copy and the lookup allocates.
+// It panics if K is not []byte.
+// SearchString ค้นหา key ที่เป็น []byte ด้วย string โดยไม่ต้องแปลงหรือจองหน่วยความจำ
+func (sl *SkipList[K, V]) SearchString(s string) (INode[K, V], bool) {
+	return sl.Search(stringKey[K](s))
+}
+
+// GetString is like SearchString but returns the value directly, or the zero
+// value and false when the key is absent.
+// GetString ทำงานเหมือน SearchString แต่คืนค่า value โดยตรง
+func (sl *SkipList[K, V]) GetString(s string) (V, bool) {
+	if n, ok := sl.SearchString(s); ok {
+		return n.Value(), true
+	}
+	var zero V
+	return zero, false
+}
+
+// stringKey returns s as a key of a list with []byte keys.
+func stringKey[K any](s string) K {
+	var key K
+	p, ok := any(&key).(*[]byte)
+	if !ok {
+		panic("skiplist: SearchString requires []byte keys")
+	}
+	*p = stringBytes(s)
+	return key
+}
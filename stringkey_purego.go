@@ -0,0 +1,10 @@
+//go:build purego
+
+package skiplist
+
+const zeroCopyStrings = false
+
+// stringBytes copies s, for builds that avoid package unsafe.
+func stringBytes(s string) []byte {
+	return []byte(s)
+}
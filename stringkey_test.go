@@ -0,0 +1,40 @@
+package skiplist
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestSearchString(t *testing.T) {
+	for _, sl := range []*SkipList[[]byte, int]{NewBytes[int](), NewWithComparator[[]byte, int](bytes.Compare)} {
+		for i := 0; i < 100; i += 2 {
+			sl.Insert([]byte(fmt.Sprintf("key/%03d", i)), i)
+		}
+		for i := 0; i < 100; i++ {
+			s := fmt.Sprintf("key/%03d", i)
+			v, ok := sl.GetString(s)
+			if ok != (i%2 == 0) || v != i && ok {
+				t.Fatalf("GetString(%q) = %d, %v", s, v, ok)
+			}
+			if n, ok := sl.SearchString(s); ok && string(n.Key()) != s {
+				t.Fatalf("SearchString(%q) found %q", s, n.Key())
+			}
+		}
+		if _, ok := sl.GetString(""); ok {
+			t.Error("GetString(\"\") found a key")
+		}
+
+		s := "key/042"
+		if allocs := testing.AllocsPerRun(100, func() { sl.GetString(s) }); allocs != 0 && zeroCopyStrings {
+			t.Errorf("GetString allocated %v times per call", allocs)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SearchString on a list with int keys did not panic")
+		}
+	}()
+	New[int, int]().SearchString("1")
+}
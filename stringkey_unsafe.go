@@ -0,0 +1,15 @@
+//go:build !purego
+
+package skiplist
+
+import "unsafe"
+
+// zeroCopyStrings reports whether stringBytes shares the memory of its
+// argument.
+const zeroCopyStrings = true
+
+// stringBytes returns a []byte sharing the memory of s. It must not be
+// modified.
+func stringBytes(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
@@ -0,0 +1,75 @@
+//go:build skiplist_nosync
+
+package skiplist
+
+// The skiplist_nosync build tag strips the mutexes, pools and background
+// goroutines of the package for single-threaded targets such as WASM or
+// TinyGo, where they only cost binary size: locks become no-ops, node pools
+// become plain free lists, RangeParallel scans in the calling goroutine and
+// StartMaintenance panics (run the Step of the tasks from the event loop
+// instead). A list built this way must only be used by one goroutine at a
+// time. The atomic counters remain; on such targets they compile to plain
+// loads and stores.
+
+// concurrent reports whether the package is built with its sync primitives.
+const concurrent = false
+
+type syncMutex struct{}
+
+func (*syncMutex) Lock()         {}
+func (*syncMutex) Unlock()       {}
+func (*syncMutex) TryLock() bool { return true }
+
+type syncRWMutex struct{}
+
+func (*syncRWMutex) Lock()    {}
+func (*syncRWMutex) Unlock()  {}
+func (*syncRWMutex) RLock()   {}
+func (*syncRWMutex) RUnlock() {}
+
+type syncOnce struct{ done bool }
+
+func (o *syncOnce) Do(f func()) {
+	if !o.done {
+		o.done = true
+		f()
+	}
+}
+
+type syncWaitGroup struct{}
+
+func (*syncWaitGroup) Add(int) {}
+func (*syncWaitGroup) Done()   {}
+func (*syncWaitGroup) Wait()   {}
+
+// syncCond only serves LockRange, whose waits can never be woken by another
+// goroutine.
+type syncCond struct{}
+
+func newSyncCond(*syncMutex) *syncCond { return &syncCond{} }
+
+func (*syncCond) Wait() {
+	panic("skiplist: LockRange on an overlapping range would block forever in a skiplist_nosync build")
+}
+
+func (*syncCond) Broadcast() {}
+
+// syncPool is a free list with the Get, Put and New of sync.Pool.
+type syncPool struct {
+	New  func() any
+	free []any
+}
+
+func (p *syncPool) Get() any {
+	if n := len(p.free); n > 0 {
+		x := p.free[n-1]
+		p.free[n-1] = nil
+		p.free = p.free[:n-1]
+		return x
+	}
+	return p.New()
+}
+
+func (p *syncPool) Put(x any) {
+	p.free = append(p.free, x)
+}
@@ -0,0 +1,40 @@
+//go:build skiplist_nosync
+
+package skiplist
+
+import "testing"
+
+func TestNoSyncBuild(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil, WithAdaptiveLocking[int, int]())
+			for i := 0; i < 1000; i++ {
+				sl.Insert(i, i)
+			}
+			for i := 0; i < 1000; i += 2 {
+				sl.Delete(i)
+			}
+			for i := 0; i < 1000; i += 2 {
+				sl.Insert(i, -i) // reuses the freed nodes
+			}
+			sum := 0
+			sl.RangeParallel(4, func(k, v int) bool {
+				sum += k
+				return true
+			})
+			if sum != 999*1000/2 || sl.Len() != 1000 {
+				t.Errorf("RangeParallel sum = %d, Len() = %d", sum, sl.Len())
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("StartMaintenance did not panic")
+		}
+	}()
+	New[int, int]().StartMaintenance(MaintenanceOptions[int, int]{})
+}
@@ -0,0 +1,22 @@
+//go:build !skiplist_nosync
+
+package skiplist
+
+import "sync"
+
+// concurrent reports whether the package is built with its sync primitives.
+// See sync_none.go for the skiplist_nosync build.
+const concurrent = true
+
+type (
+	syncMutex     = sync.Mutex
+	syncRWMutex   = sync.RWMutex
+	syncOnce      = sync.Once
+	syncWaitGroup = sync.WaitGroup
+	syncCond      = sync.Cond
+	syncPool      = sync.Pool
+)
+
+func newSyncCond(l *syncMutex) *syncCond {
+	return sync.NewCond(l)
+}
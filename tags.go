@@ -0,0 +1,76 @@
+package skiplist
+
+// WithEntryTags gives every entry a 64-bit tag, a metadata word stored in its
+// node next to the key and value, for bookkeeping such as dirty flags or
+// external IDs that would otherwise have to be packed into V and rewritten
+// with the whole value. Tags start at zero, are kept when the value of the
+// key is replaced and are dropped with the entry. They are set with SetTag
+// and read with Tag or RangeTagged; they are not part of snapshots.
+// WithEntryTags เพิ่ม tag ขนาด 64 bit ให้แต่ละรายการสำหรับเก็บ metadata โดยไม่ต้องแก้ไข value
+func WithEntryTags[K any, V any]() Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.entryTags = true
+	}
+}
+
+// SetTag sets the tag of the entry of n, a node returned by Search,
+// RangeNodes or another API of this list, and reports whether it did. It
+// returns false if n is not a node of a list or its entry was deleted. It
+// takes only the read lock, so tagging does not block readers.
+// It panics if the skiplist was not created with WithEntryTags.
+// SetTag กำหนด tag ของรายการของโหนด n
+func (sl *SkipList[K, V]) SetTag(n INode[K, V], tag uint64) bool {
+	sl.mustEntryTags()
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	target, ok := n.(*node[K, V])
+	if !ok || target == nil || target.gen == 0 {
+		return false
+	}
+	target.tag.Store(tag)
+	// Tags are written under the read lock, so they do not bump the version:
+	// count them for MigrateAllocator, which copies them between batches.
+	sl.tagWrites.Add(1)
+	return true
+}
+
+// Tag returns the tag of the entry of n, or 0 and false if n is not a node
+// of a list or its entry was deleted.
+// It panics if the skiplist was not created with WithEntryTags.
+// Tag คืนค่า tag ของรายการของโหนด n
+func (sl *SkipList[K, V]) Tag(n INode[K, V]) (uint64, bool) {
+	sl.mustEntryTags()
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+
+	target, ok := n.(*node[K, V])
+	if !ok || target == nil || target.gen == 0 {
+		return 0, false
+	}
+	return target.tag.Load(), true
+}
+
+// RangeTagged is like Range but also passes the tag of every entry to f.
+// It panics if the skiplist was not created with WithEntryTags.
+// RangeTagged ทำงานเหมือน Range แต่ส่ง tag ของแต่ละรายการให้ f ด้วย
+func (sl *SkipList[K, V]) RangeTagged(f func(key K, value V, tag uint64) bool, opts ...ScanOption) {
+	sl.mustEntryTags()
+	defer rethrowCallbackPanic("RangeTagged")
+	tr := sl.traceStart(OpRange)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	sl.scan(&tr, sl.header.forward[0], opts, func(n *node[K, V]) bool {
+		tr.keys++
+		return f(n.key, n.value, n.tag.Load())
+	})
+}
+
+func (sl *SkipList[K, V]) mustEntryTags() {
+	if !sl.entryTags {
+		panic("skiplist: entry tag API used without WithEntryTags")
+	}
+}
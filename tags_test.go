@@ -0,0 +1,61 @@
+package skiplist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEntryTags(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil, WithEntryTags[int, string]())
+			for i := 0; i < 10; i++ {
+				sl.Insert(i, "v")
+			}
+			for i := 0; i < 10; i += 3 {
+				n, _ := sl.Search(i)
+				if !sl.SetTag(n, uint64(100+i)) {
+					t.Fatalf("SetTag(%d) failed", i)
+				}
+			}
+
+			n, _ := sl.Search(3)
+			sl.Insert(3, "w") // an update keeps the tag
+			if tag, ok := sl.Tag(n); !ok || tag != 103 {
+				t.Errorf("Tag(3) after update = %d, %v", tag, ok)
+			}
+			sl.Delete(3)
+			if sl.SetTag(n, 1) {
+				t.Error("SetTag on a deleted entry succeeded")
+			}
+			if _, ok := sl.Tag(n); ok {
+				t.Error("Tag of a deleted entry succeeded")
+			}
+			sl.Insert(3, "x") // a new entry starts untagged, even in a reused node
+
+			if err := sl.MigrateAllocator(WithArena[int, string](1024)); err != nil {
+				t.Fatal(err)
+			}
+			tags := map[int]uint64{}
+			sl.RangeTagged(func(k int, v string, tag uint64) bool {
+				if tag != 0 {
+					tags[k] = tag
+				}
+				return true
+			})
+			if want := map[int]uint64{0: 100, 6: 106, 9: 109}; !reflect.DeepEqual(tags, want) {
+				t.Errorf("tags = %v, want %v", tags, want)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Tag without WithEntryTags did not panic")
+		}
+	}()
+	sl := New[int, int]()
+	sl.Insert(1, 1)
+	n, _ := sl.Search(1)
+	sl.Tag(n)
+}
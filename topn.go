@@ -0,0 +1,55 @@
+package skiplist
+
+// Entry is a key-value pair copied out of a skiplist. Unlike an INode, it
+// stays valid after the entry is updated or deleted.
+// Entry คือคู่ key-value ที่คัดลอกออกมาจาก skiplist ยังใช้งานได้แม้รายการจะถูกแก้ไขหรือลบไปแล้ว
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// MinN returns the n smallest entries, in ascending key order, under a single
+// read lock. It returns fewer entries if the list holds fewer than n, and nil
+// if n <= 0.
+// MinN คืนค่ารายการที่มี key น้อยที่สุด n รายการ เรียงจากน้อยไปมาก
+func (sl *SkipList[K, V]) MinN(n int) []Entry[K, V] {
+	tr := sl.traceStart(OpMin)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	n = min(n, sl.length)
+	if n <= 0 {
+		return nil
+	}
+	out := make([]Entry[K, V], 0, n)
+	for current := sl.header.forward[0]; len(out) < n; current = current.forward[0] {
+		out = append(out, Entry[K, V]{Key: current.key, Value: current.value})
+	}
+	tr.keys = n
+	return out
+}
+
+// MaxN returns the n largest entries, in descending key order, under a single
+// read lock. It returns fewer entries if the list holds fewer than n, and nil
+// if n <= 0.
+// MaxN คืนค่ารายการที่มี key มากที่สุด n รายการ เรียงจากมากไปน้อย
+func (sl *SkipList[K, V]) MaxN(n int) []Entry[K, V] {
+	tr := sl.traceStart(OpMax)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	n = min(n, sl.length)
+	if n <= 0 {
+		return nil
+	}
+	out := make([]Entry[K, V], 0, n)
+	for current := sl.last(); len(out) < n; current = current.backward {
+		out = append(out, Entry[K, V]{Key: current.key, Value: current.value})
+	}
+	tr.keys = n
+	return out
+}
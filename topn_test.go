@@ -0,0 +1,48 @@
+package skiplist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMinNMaxN(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			if got := sl.MinN(3); got != nil {
+				t.Errorf("MinN(3) on an empty list = %v, want nil", got)
+			}
+			if got := sl.MaxN(3); got != nil {
+				t.Errorf("MaxN(3) on an empty list = %v, want nil", got)
+			}
+
+			for _, k := range []int{50, 10, 40, 20, 30} {
+				sl.Insert(k, string(rune('a'+k/10)))
+			}
+			tests := []struct {
+				name string
+				got  []Entry[int, string]
+				want []Entry[int, string]
+			}{
+				{"MinN(2)", sl.MinN(2), []Entry[int, string]{{10, "b"}, {20, "c"}}},
+				{"MaxN(2)", sl.MaxN(2), []Entry[int, string]{{50, "f"}, {40, "e"}}},
+				{"MinN(10)", sl.MinN(10), []Entry[int, string]{{10, "b"}, {20, "c"}, {30, "d"}, {40, "e"}, {50, "f"}}},
+				{"MaxN(10)", sl.MaxN(10), []Entry[int, string]{{50, "f"}, {40, "e"}, {30, "d"}, {20, "c"}, {10, "b"}}},
+				{"MinN(0)", sl.MinN(0), nil},
+				{"MaxN(-1)", sl.MaxN(-1), nil},
+			}
+			for _, tt := range tests {
+				if !slices.Equal(tt.got, tt.want) {
+					t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+				}
+			}
+
+			// The entries are copies.
+			top := sl.MaxN(1)
+			sl.Delete(50)
+			if top[0] != (Entry[int, string]{50, "f"}) {
+				t.Errorf("MaxN(1) entry changed after Delete: %v", top[0])
+			}
+		})
+	}
+}
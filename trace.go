@@ -0,0 +1,193 @@
+package skiplist
+
+import "time"
+
+// Op identifies the kind of operation reported to a Tracer.
+//
+// Operations without an Op of their own report the Op of the operation they
+// are a variant of: the Try, handle, ID, namespace, timestamped and segment
+// forms of Search, Insert and Delete report OpSearch, OpInsert and OpDelete
+// (MergeLWW and InsertRange included); RangeKeys, RangeValues, RangeNodes,
+// RangeTagged and RangeParallel report OpRange; Entries, Query, GroupRange,
+// Gaps and FingerprintRange report OpRangeQuery; MinN and MaxN report OpMin
+// and OpMax; RankLT, RankLE, RevRank and WeightedRank report OpRank;
+// GetByRevRank, GetByRanks and GetByWeightedRank report OpGetByRank; Digest
+// and SplitDigest report OpCountRange; each batch of ClearIncremental
+// reports OpClear; and MoveKey reports OpMove on both lists.
+// Op ระบุประเภทของ operation ที่รายงานไปยัง Tracer
+type Op uint8
+
+const (
+	OpSearch Op = iota
+	OpInsert
+	OpDelete
+	OpClear
+	OpPopMin
+	OpPopMax
+	OpMin
+	OpMax
+	OpPredecessor
+	OpSuccessor
+	OpSeek
+	OpRange
+	OpRangeQuery
+	OpCountRange
+	OpRank
+	OpGetByRank
+	OpDeleteMin
+	OpDeleteMax
+	OpDeleteRange
+	OpRotate
+	OpMove
+	OpBulkLoad
+	OpCompactLevels
+	OpKthInRange
+	OpSummary
+	OpHistogram
+)
+
+var opNames = [...]string{
+	OpSearch:      "Search",
+	OpInsert:      "Insert",
+	OpDelete:      "Delete",
+	OpClear:       "Clear",
+	OpPopMin:      "PopMin",
+	OpPopMax:      "PopMax",
+	OpMin:         "Min",
+	OpMax:         "Max",
+	OpPredecessor: "Predecessor",
+	OpSuccessor:   "Successor",
+	OpSeek:        "Seek",
+	OpRange:       "Range",
+	OpRangeQuery:  "RangeQuery",
+	OpCountRange:  "CountRange",
+	OpRank:        "Rank",
+	OpGetByRank:   "GetByRank",
+
+	OpDeleteMin:     "DeleteMin",
+	OpDeleteMax:     "DeleteMax",
+	OpDeleteRange:   "DeleteRange",
+	OpRotate:        "Rotate",
+	OpMove:          "Move",
+	OpBulkLoad:      "BulkLoad",
+	OpCompactLevels: "CompactLevels",
+	OpKthInRange:    "KthInRange",
+	OpSummary:       "Summary",
+	OpHistogram:     "Histogram",
+}
+
+// String returns the name of the operation, e.g. "Insert".
+func (o Op) String() string {
+	if int(o) < len(opNames) && opNames[o] != "" {
+		return opNames[o]
+	}
+	return "Op(unknown)"
+}
+
+// TraceInfo describes a completed operation.
+type TraceInfo struct {
+	Op Op
+	// Keys is the number of entries the operation returned, visited or modified.
+	Keys int
+	// Duration is the total time from Start until the operation finished,
+	// including the time spent waiting for the lock.
+	Duration time.Duration
+	// LockWait is the time spent waiting to acquire the skiplist's lock.
+	LockWait time.Duration
+}
+
+// Tracer receives start/end callbacks for skiplist operations. It can be used
+// to attach OpenTelemetry spans or feed latency histograms.
+//
+// Start is called before the operation acquires its lock. The value it returns
+// is passed back unchanged to End, which lets implementations correlate the two
+// calls when operations run concurrently. Both methods are called from the
+// goroutine performing the operation; End is called while the lock is still held,
+// so implementations must not call back into the same skiplist.
+type Tracer interface {
+	Start(op Op) any
+	End(span any, info TraceInfo)
+}
+
+// WithTracer attaches a Tracer that is notified of every top-level operation.
+// Iterator methods are not traced individually.
+func WithTracer[K any, V any](t Tracer) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.tracer = t
+	}
+}
+
+// opTrace carries the per-call tracing state, used by WithTracer,
+// WithLockAudit and WithLatencySampling. The zero value is a disabled trace.
+type opTrace struct {
+	span       any
+	start      time.Time
+	lockedAt   time.Time
+	releasedAt time.Time
+	wait       time.Duration
+	held       time.Duration // longest hold of the lock before the last release
+	keys       int
+	op         Op
+	on         bool
+	sampled    bool // measured by WithLatencySampling
+}
+
+// traceStart begins tracing op. It must be called before the lock is acquired.
+func (sl *SkipList[K, V]) traceStart(op Op) opTrace {
+	sampled := sl.latency != nil && sl.latency.sample()
+	if sl.tracer == nil && sl.lockAudit == nil && !sampled {
+		return opTrace{}
+	}
+	t := opTrace{op: op, on: true, sampled: sampled}
+	if sl.tracer != nil {
+		t.span = sl.tracer.Start(op)
+	}
+	t.start = time.Now()
+	return t
+}
+
+// locked records the time spent waiting for the lock.
+func (t *opTrace) locked() {
+	if !t.on {
+		return
+	}
+	now := time.Now()
+	if t.lockedAt.IsZero() {
+		t.wait = now.Sub(t.start)
+	} else {
+		t.wait += now.Sub(t.releasedAt)
+	}
+	t.lockedAt = now
+}
+
+// released records that a scan yielding the lock (see WithYieldEvery)
+// released it; locked must be called again once it is re-acquired.
+func (t *opTrace) released() {
+	if t.on {
+		t.releasedAt = time.Now()
+		t.held = max(t.held, t.releasedAt.Sub(t.lockedAt))
+	}
+}
+
+// traceEnd reports the finished operation to the tracer, to the lock audit
+// and to the latency sampler. It is called while the lock is still held.
+func (sl *SkipList[K, V]) traceEnd(t *opTrace) {
+	if !t.on {
+		return
+	}
+	now := time.Now()
+	if sl.tracer != nil {
+		sl.tracer.End(t.span, TraceInfo{
+			Op:       t.op,
+			Keys:     t.keys,
+			Duration: now.Sub(t.start),
+			LockWait: t.wait,
+		})
+	}
+	if sl.lockAudit != nil {
+		sl.lockAudit.check(t, now)
+	}
+	if t.sampled {
+		sl.latency.record(t.op, now.Sub(t.start))
+	}
+}
@@ -0,0 +1,128 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+)
+
+// recordingTracer collects every TraceInfo it receives.
+type recordingTracer struct {
+	mu     sync.Mutex
+	starts []Op
+	ends   []TraceInfo
+	spans  []any
+}
+
+func (r *recordingTracer) Start(op Op) any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.starts = append(r.starts, op)
+	return len(r.starts)
+}
+
+func (r *recordingTracer) End(span any, info TraceInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span)
+	r.ends = append(r.ends, info)
+}
+
+func TestTracer(t *testing.T) {
+	for _, setup := range getTestSetups[int, string]() {
+		t.Run(setup.name, func(t *testing.T) {
+			rec := &recordingTracer{}
+			sl := setup.constructor(nil, WithTracer[int, string](rec))
+
+			sl.Insert(10, "a")
+			sl.Insert(20, "b")
+			sl.Insert(30, "c")
+			sl.Search(20)
+			sl.Search(25)
+			sl.RangeQuery(10, 20, func(int, string) bool { return true })
+			sl.Delete(10)
+			sl.Clear()
+
+			want := []struct {
+				op   Op
+				keys int
+			}{
+				{OpInsert, 1},
+				{OpInsert, 1},
+				{OpInsert, 1},
+				{OpSearch, 1},
+				{OpSearch, 0},
+				{OpRangeQuery, 2},
+				{OpDelete, 1},
+				{OpClear, 2},
+			}
+
+			if len(rec.starts) != len(want) || len(rec.ends) != len(want) {
+				t.Fatalf("expected %d start/end calls, got %d/%d", len(want), len(rec.starts), len(rec.ends))
+			}
+			for i, w := range want {
+				if rec.starts[i] != w.op {
+					t.Errorf("call %d: Start op = %v, want %v", i, rec.starts[i], w.op)
+				}
+				got := rec.ends[i]
+				if got.Op != w.op || got.Keys != w.keys {
+					t.Errorf("call %d: End = {%v, %d}, want {%v, %d}", i, got.Op, got.Keys, w.op, w.keys)
+				}
+				if rec.spans[i] != i+1 {
+					t.Errorf("call %d: span = %v, want %d", i, rec.spans[i], i+1)
+				}
+				if got.Duration < got.LockWait {
+					t.Errorf("call %d: Duration %v shorter than LockWait %v", i, got.Duration, got.LockWait)
+				}
+			}
+		})
+	}
+}
+
+func TestOpString(t *testing.T) {
+	if OpInsert.String() != "Insert" {
+		t.Errorf("OpInsert.String() = %q", OpInsert.String())
+	}
+	if Op(255).String() != "Op(unknown)" {
+		t.Errorf("Op(255).String() = %q", Op(255).String())
+	}
+	for op := range Op(len(opNames)) {
+		if op.String() == "Op(unknown)" {
+			t.Errorf("Op(%d) has no name", op)
+		}
+	}
+}
+
+func TestTracerOwnOps(t *testing.T) {
+	// Operations with an Op of their own are not reported as the primitive
+	// they are built on.
+	rec := &recordingTracer{}
+	sl := New[int, int](WithTracer[int, int](rec))
+	keys := []int{1, 2, 3, 4, 5}
+	sl.BulkLoad(func() (int, int, bool) {
+		if len(keys) == 0 {
+			return 0, 0, false
+		}
+		k := keys[0]
+		keys = keys[1:]
+		return k, k, true
+	})
+	sl.DeleteMin()
+	sl.DeleteMax()
+	sl.Move(2, 6)
+	sl.KthInRange(0, 10, 1)
+	sl.Summary()
+	sl.Histogram([]int{0, 4, 8})
+	sl.DeleteRange(3, 4)
+	sl.CompactLevels()
+	sl.Rotate()
+
+	want := []Op{OpBulkLoad, OpDeleteMin, OpDeleteMax, OpMove, OpKthInRange, OpSummary, OpHistogram, OpDeleteRange, OpCompactLevels, OpRotate}
+	if len(rec.starts) != len(want) {
+		t.Fatalf("Start ops = %v, want %v", rec.starts, want)
+	}
+	for i, op := range want {
+		if rec.starts[i] != op {
+			t.Errorf("call %d: Start op = %v, want %v", i, rec.starts[i], op)
+		}
+	}
+}
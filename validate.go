@@ -0,0 +1,136 @@
+package skiplist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCorrupt is wrapped by errors reporting a structurally broken skiplist or
+// a damaged snapshot.
+var ErrCorrupt = errors.New("skiplist: corrupt")
+
+// Validate checks the structural invariants of the skiplist: keys strictly
+// ascending, Len matching the number of nodes, backward pointers, every upper
+// level being a subsequence of level 0, and spans matching the distance
+// between linked nodes. It returns nil for a healthy list, or an error wrapping
+// ErrCorrupt that describes the first violation found.
+//
+// Validate is O(n · levels) and holds the read lock for its whole run; it is
+// intended for tests, debugging and verifying loaded data.
+//
+// Validate ตรวจสอบความถูกต้องของโครงสร้าง skiplist และคืนค่า error ที่ห่อ ErrCorrupt หากพบปัญหา
+func (sl *SkipList[K, V]) Validate() error {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+	return sl.validate()
+}
+
+// validate implements Validate. The caller must hold at least the read lock.
+func (sl *SkipList[K, V]) validate() error {
+	corrupt := func(format string, args ...any) error {
+		return fmt.Errorf("%w: "+format, append([]any{ErrCorrupt}, args...)...)
+	}
+
+	if sl.level < 0 || sl.level >= MaxLevel {
+		return corrupt("level %d out of range", sl.level)
+	}
+	for i := sl.level + 1; i < MaxLevel; i++ {
+		if sl.header.forward[i] != nil {
+			return corrupt("header has a pointer at level %d above the list level %d", i, sl.level)
+		}
+	}
+
+	count := 0
+	prev := sl.header
+	for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+		count++
+		if n.backward != prev {
+			return corrupt("wrong backward pointer at position %d", count)
+		}
+		if len(n.forward) == 0 || len(n.forward) > sl.level+1 || len(n.span) < len(n.forward) {
+			return corrupt("node at position %d has invalid level %d", count, len(n.forward))
+		}
+		if prev != sl.header && sl.compare(prev.key, n.key) >= 0 {
+			return corrupt("keys out of order at position %d", count)
+		}
+		if n.prefix != sl.prefixOf(n.key) {
+			return corrupt("stale key prefix at position %d", count)
+		}
+		if count > sl.length {
+			return corrupt("more nodes than Len() = %d", sl.length)
+		}
+		prev = n
+	}
+	if count != sl.length {
+		return corrupt("Len() = %d but the list has %d nodes", sl.length, count)
+	}
+	if size := sl.size.Load(); size != int64(sl.length) {
+		return corrupt("atomic length %d differs from %d", size, sl.length)
+	}
+	return sl.checkSpans()
+}
+
+// CheckSpans recomputes the span of every link from the positions of the
+// nodes on level 0 and compares it with the stored span. Rank, GetByRank and
+// the other rank-based operations are only correct while these spans are; the
+// check also verifies that every upper level is a subsequence of level 0. It
+// returns nil if all spans match, or an error wrapping ErrCorrupt describing
+// the first mismatch. With WithWeights and WithMerkle, the weight and hash
+// spans are checked as well, and with WithBidirectionalLevels the backward
+// pointers of every level.
+//
+// CheckSpans is O(n · levels) and holds the read lock for its whole run. It is
+// a narrower check than Validate, meant for tests guarding the span
+// bookkeeping of Insert and Delete.
+//
+// CheckSpans คำนวณ span ใหม่จากตำแหน่งของโหนดในชั้น 0 และเปรียบเทียบกับค่าที่เก็บไว้
+func (sl *SkipList[K, V]) CheckSpans() error {
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+	return sl.checkSpans()
+}
+
+// checkSpans implements CheckSpans. The caller must hold at least the read lock.
+func (sl *SkipList[K, V]) checkSpans() error {
+	corrupt := func(format string, args ...any) error {
+		return fmt.Errorf("%w: "+format, append([]any{ErrCorrupt}, args...)...)
+	}
+
+	for i := 0; i <= sl.level; i++ {
+		last, lastPos, pos := sl.header, 0, 0
+		lastWeight, weight := 0, 0
+		var lastHash, hash uint64
+		for n := sl.header.forward[0]; n != nil; n = n.forward[0] {
+			pos++
+			if sl.weight != nil {
+				weight += sl.weight(n.key, n.value)
+			}
+			if sl.merkle != nil {
+				hash += sl.merkle(n.key, n.value)
+			}
+			if len(n.forward) <= i {
+				continue
+			}
+			if last.forward[i] != n {
+				return corrupt("level %d does not link the node at position %d", i, pos)
+			}
+			if sl.backLinks && n.back[i] != last {
+				return corrupt("wrong backward pointer at level %d, position %d", i, pos)
+			}
+			if !sl.noRanks && last.span[i] != pos-lastPos {
+				return corrupt("span %d at level %d before position %d, want %d", last.span[i], i, pos, pos-lastPos)
+			}
+			if sl.weight != nil && last.wspan[i] != weight-lastWeight {
+				return corrupt("weight span %d at level %d before position %d, want %d", last.wspan[i], i, pos, weight-lastWeight)
+			}
+			if sl.merkle != nil && last.hspan[i] != hash-lastHash {
+				return corrupt("hash span %x at level %d before position %d, want %x", last.hspan[i], i, pos, hash-lastHash)
+			}
+			last, lastPos, lastWeight, lastHash = n, pos, weight, hash
+		}
+		if last.forward[i] != nil {
+			return corrupt("level %d links a node that is not on level 0", i)
+		}
+	}
+	return nil
+}
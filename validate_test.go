@@ -0,0 +1,51 @@
+package skiplist
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			sl := setup.constructor(nil)
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate on an empty list: %v", err)
+			}
+			for i := 0; i < 1000; i++ {
+				sl.Insert((i*7919)%1000, i)
+			}
+			for i := 0; i < 1000; i += 3 {
+				sl.Delete(i)
+			}
+			sl.PopMin()
+			sl.PopMax()
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate on a healthy list: %v", err)
+			}
+
+			// Break a span above level 0.
+			n := sl.header.forward[0]
+			for len(n.forward) < 2 {
+				n = n.forward[0]
+			}
+			n.span[1]++
+			if err := sl.Validate(); !errors.Is(err, ErrCorrupt) {
+				t.Errorf("Validate with a broken span returned %v, want ErrCorrupt", err)
+			}
+			n.span[1]--
+
+			sl.length++
+			if err := sl.Validate(); !errors.Is(err, ErrCorrupt) {
+				t.Errorf("Validate with a wrong length returned %v, want ErrCorrupt", err)
+			}
+			sl.length--
+
+			first, second := sl.header.forward[0], sl.header.forward[0].forward[0]
+			first.key, second.key = second.key, first.key
+			if err := sl.Validate(); !errors.Is(err, ErrCorrupt) {
+				t.Errorf("Validate with unordered keys returned %v, want ErrCorrupt", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package skiplist
+
+import "fmt"
+
+// WithKeyValidator registers validate, which checks every key written by an
+// insert (Insert, TryInsert, BulkLoad, Load, ApplyDelta, ...) before the list
+// is modified, e.g. to reject empty strings, out-of-range timestamps or NaN
+// in a list shared by several writers. A key it rejects is not written:
+// TryInsert, BulkLoad, Load and ApplyDelta return the error, wrapped with
+// ErrInvalidKey, and Insert panics with it.
+//
+// validate is called with the write lock held and must not call back into
+// the skiplist.
+// WithKeyValidator ลงทะเบียนฟังก์ชันตรวจสอบ key ที่ถูกเรียกก่อนการเพิ่มข้อมูลทุกครั้ง
+func WithKeyValidator[K any, V any](validate func(key K) error) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.validateKey = validate
+	}
+}
+
+// checkKey returns the error of the key validator for key, if any.
+func (sl *SkipList[K, V]) checkKey(key K) error {
+	if sl.validateKey == nil {
+		return nil
+	}
+	if err := sl.validateKey(key); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+	return nil
+}
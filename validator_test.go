@@ -0,0 +1,84 @@
+package skiplist
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+var errEmptyKey = errors.New("empty key")
+
+func TestWithKeyValidator(t *testing.T) {
+	for _, setup := range getTestSetups[string, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			nonEmpty := WithKeyValidator[string, int](func(k string) error {
+				if k == "" {
+					return errEmptyKey
+				}
+				return nil
+			})
+			sl := setup.constructor(nil, nonEmpty)
+
+			if _, err := sl.TryInsert("a", 1); err != nil {
+				t.Fatalf("TryInsert(a) = %v", err)
+			}
+			_, err := sl.TryInsert("", 2)
+			if !errors.Is(err, ErrInvalidKey) || !errors.Is(err, errEmptyKey) {
+				t.Errorf("TryInsert(\"\") = %v, want ErrInvalidKey wrapping errEmptyKey", err)
+			}
+			if r := catchPanic(func() { sl.Insert("", 3) }); r == nil || !errors.Is(r.(error), errEmptyKey) {
+				t.Errorf("Insert(\"\") panicked with %v", r)
+			}
+
+			keys := []string{"b", "", "c"}
+			n, err := sl.BulkLoad(func() (string, int, bool) {
+				if len(keys) == 0 {
+					return "", 0, false
+				}
+				k := keys[0]
+				keys = keys[1:]
+				return k, 0, true
+			})
+			if n != 1 || !errors.Is(err, errEmptyKey) {
+				t.Errorf("BulkLoad() = %d, %v, want 1 and errEmptyKey", n, err)
+			}
+
+			if _, ok := sl.Search(""); ok || sl.Len() != 2 {
+				t.Errorf("invalid key written, Len() = %d", sl.Len())
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestWithKeyValidator_Snapshots(t *testing.T) {
+	src := New[string, int](WithChangeTracking[string, int]())
+	src.Insert("", 1)
+	src.Insert("x", 2)
+	var snap, delta bytes.Buffer
+	if err := src.Save(&snap); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.SaveDelta(&delta, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	reject := WithKeyValidator[string, int](func(k string) error {
+		if k == "" {
+			return errEmptyKey
+		}
+		return nil
+	})
+	dst := New[string, int](reject)
+	if err := dst.Load(&snap); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Load() = %v, want ErrInvalidKey", err)
+	}
+	if err := dst.ApplyDelta(&delta); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("ApplyDelta() = %v, want ErrInvalidKey", err)
+	}
+	if dst.Len() != 0 {
+		t.Errorf("Len() = %d after rejected loads, want 0", dst.Len())
+	}
+}
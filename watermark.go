@@ -0,0 +1,63 @@
+package skiplist
+
+import "fmt"
+
+// WithUsageWatermark calls fn when the usage of the arena crosses fraction
+// of its capacity upwards, e.g. 0.8 to start flushing a memtable to disk
+// before the arena has to grow (or, with WithFixedArena, fills up). used
+// counts the nodes handed out since the arena was last reset, including those
+// of deleted entries, and capacity is used plus Cap. fn fires once per
+// crossing: it is armed again when the usage falls back below fraction, after
+// Clear or Rotate, or when the arena grows. As for Cap, the figures are
+// estimates: nodes of different heights are carved from different size
+// classes, and the class of the tallest nodes may run out, growing (or
+// filling) the arena, well before used reaches capacity. Leave a margin, e.g.
+// a fraction of 0.5 or less.
+//
+// The option may be given several times to watch several thresholds. It has
+// no effect on pool-backed lists. fn runs like a hook (see Hooks), after the
+// insert that crossed the threshold, with the write lock held: it must not
+// call back into the list and should only signal another goroutine. It panics
+// if fraction is not in (0, 1].
+// WithUsageWatermark เรียก fn เมื่อการใช้งาน Arena เกินสัดส่วน fraction ของความจุ
+// เพื่อให้ระบบจัดเก็บข้อมูลเริ่ม flush หรือ rotate ก่อนที่ Arena จะต้องขยาย
+func WithUsageWatermark[K any, V any](fraction float64, fn func(used, capacity int)) Option[K, V] {
+	if !(fraction > 0 && fraction <= 1) {
+		panic(fmt.Sprintf("skiplist: WithUsageWatermark fraction %v is not in (0, 1]", fraction))
+	}
+	return func(sl *SkipList[K, V]) {
+		sl.watermarks = append(sl.watermarks, &usageWatermark{fraction: fraction, fn: fn})
+	}
+}
+
+// usageWatermark is a threshold registered by WithUsageWatermark.
+type usageWatermark struct {
+	fraction float64
+	fn       func(used, capacity int)
+	above    bool // the usage was at or above fraction at the last check
+}
+
+// checkWatermarks calls the watermarks the arena usage just crossed.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) checkWatermarks() {
+	a, ok := sl.allocator.(*arenaAllocator[K, V])
+	if !ok {
+		return
+	}
+	used, free := a.nodeUsage()
+	capacity := used + free
+	for _, wm := range sl.watermarks {
+		above := capacity > 0 && float64(used) >= wm.fraction*float64(capacity)
+		crossed := above && !wm.above
+		// Updated first so that a panicking fn does not fire again.
+		wm.above = above
+		if crossed {
+			wm.call(used, capacity)
+		}
+	}
+}
+
+func (wm *usageWatermark) call(used, capacity int) {
+	defer rethrowCallbackPanic("UsageWatermark")
+	wm.fn(used, capacity)
+}
@@ -0,0 +1,87 @@
+package skiplist
+
+import "testing"
+
+func TestWithUsageWatermark(t *testing.T) {
+	type call struct{ used, capacity int }
+	var low, high []call
+	sl := New[int, int](WithArena[int, int](1<<20),
+		WithUsageWatermark[int, int](0.1, func(used, capacity int) { low = append(low, call{used, capacity}) }),
+		WithUsageWatermark[int, int](0.3, func(used, capacity int) { high = append(high, call{used, capacity}) }))
+
+	// Insert until the arena grows for the first time.
+	capacity := sl.Cap()
+	i := 0
+	for ; sl.Stats().ArenaUsed+sl.Cap() == capacity; i++ {
+		sl.Insert(i, i)
+		if len(low) == 0 && sl.Stats().ArenaUsed*10 >= capacity {
+			t.Fatalf("0.1 watermark not called at %d/%d", sl.Stats().ArenaUsed, capacity)
+		}
+	}
+	if len(low) != 1 || len(high) != 1 {
+		t.Fatalf("before growth: %d and %d calls, want 1 each", len(low), len(high))
+	}
+	for _, c := range append(low, high...) {
+		if c.capacity != capacity || c.used > c.capacity {
+			t.Errorf("called with %+v, capacity %d", c, capacity)
+		}
+	}
+	if float64(high[0].used) < 0.3*float64(capacity) || float64(high[0].used-1) >= 0.3*float64(capacity) {
+		t.Errorf("0.3 watermark called at %d/%d", high[0].used, capacity)
+	}
+
+	// Deletes do not free arena nodes, so the watermarks stay crossed until
+	// Clear resets the arena.
+	sl.Delete(0)
+	sl.Insert(0, 0)
+	n := len(low)
+	sl.Clear()
+	for j := 0; j < i; j++ {
+		sl.Insert(j, j)
+	}
+	if len(low) != n+1 {
+		t.Errorf("after Clear: %d calls of the 0.1 watermark, want %d", len(low), n+1)
+	}
+}
+
+func TestWithUsageWatermarkBulkLoad(t *testing.T) {
+	calls := 0
+	sl := New[int, int](WithFixedArena[int, int](1<<20),
+		WithUsageWatermark[int, int](0.3, func(int, int) { calls++ }))
+	i := 0
+	_, err := sl.BulkLoad(func() (int, int, bool) { i++; return i, i, true })
+	if err != ErrArenaFull {
+		t.Fatalf("BulkLoad() = %v, want ErrArenaFull", err)
+	}
+	if calls != 1 {
+		t.Errorf("%d calls, want 1", calls)
+	}
+}
+
+func TestWithUsageWatermarkPool(t *testing.T) {
+	sl := New[int, int](WithUsageWatermark[int, int](0.01, func(int, int) { t.Error("called for a pool-backed list") }))
+	for i := 0; i < 100; i++ {
+		sl.Insert(i, i)
+	}
+}
+
+func TestWithUsageWatermarkPanics(t *testing.T) {
+	for _, f := range []float64{0, -1, 1.5} {
+		if catchPanic(func() { WithUsageWatermark[int, int](f, func(int, int) {}) }) == nil {
+			t.Errorf("WithUsageWatermark(%v) did not panic", f)
+		}
+	}
+
+	sl := New[int, int](WithArena[int, int](1<<12),
+		WithUsageWatermark[int, int](0.1, func(int, int) { panic(errBoom) }))
+	var r any
+	for i := 0; r == nil; i++ {
+		r = catchPanic(func() { sl.Insert(i, i) })
+	}
+	mustCallbackPanic(t, r, "UsageWatermark")
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	// The watermark does not fire again for the same crossing.
+	sl.Insert(-1, -1)
+}
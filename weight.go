@@ -0,0 +1,157 @@
+package skiplist
+
+// WithWeights gives every entry a weight computed by weight from its key and
+// value. Alongside the count spans used by Rank, each link then also records
+// the total weight of the entries it skips, so that WeightedRank and
+// GetByWeightedRank run in O(log n). This turns the list into an index over
+// variable-sized extents, e.g. a byte-offset index where each entry covers the
+// number of bytes returned by weight.
+//
+// weight must be deterministic and return a non-negative value; Insert panics
+// on a negative weight. It is called again when a value is replaced or a key
+// is removed, and is called with the write lock held, so it must not call back
+// into the skiplist.
+// WithWeights กำหนดน้ำหนักให้แต่ละรายการ เพื่อให้ค้นหาตามผลรวมน้ำหนักได้ใน O(log n)
+func WithWeights[K any, V any](weight func(K, V) int) Option[K, V] {
+	return func(sl *SkipList[K, V]) {
+		sl.weight = weight
+	}
+}
+
+// WeightedRank returns the total weight of the entries with keys strictly
+// smaller than key, i.e. the offset at which the extent of key starts.
+// It panics if the skiplist was not created with WithWeights.
+// WeightedRank คืนค่าผลรวมน้ำหนักของรายการที่มี key น้อยกว่า key ที่กำหนด
+func (sl *SkipList[K, V]) WeightedRank(key K) int {
+	sl.mustWeights()
+	tr := sl.traceStart(OpRank)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	rank := 0
+	current := sl.header
+	kp := sl.prefixOf(key)
+	for i := sl.level; i >= 0; i-- {
+		for current.forward[i] != nil && sl.compareNode(current.forward[i], key, kp) < 0 {
+			rank += current.wspan[i]
+			current = current.forward[i]
+		}
+	}
+	return rank
+}
+
+// GetByWeightedRank returns the entry whose extent covers the offset w, that
+// is the entry for which WeightedRank(key) <= w < WeightedRank(key)+weight.
+// Entries of weight zero cover no offset and are never returned.
+// It returns nil and false if w is negative or not smaller than TotalWeight.
+// It panics if the skiplist was not created with WithWeights.
+// GetByWeightedRank คืนค่ารายการที่ครอบคลุมตำแหน่ง w ตามผลรวมน้ำหนัก
+func (sl *SkipList[K, V]) GetByWeightedRank(w int) (INode[K, V], bool) {
+	sl.mustWeights()
+	tr := sl.traceStart(OpGetByRank)
+	sl.mutex.RLock()
+	tr.locked()
+	defer sl.mutex.RUnlock()
+	defer sl.traceEnd(&tr)
+
+	if w < 0 || w >= sl.weights {
+		return nil, false
+	}
+
+	// traversed is the total weight up to and including current.
+	traversed := 0
+	current := sl.header
+	for i := sl.level; i >= 0; i-- {
+		for current.forward[i] != nil && traversed+current.wspan[i] <= w {
+			traversed += current.wspan[i]
+			current = current.forward[i]
+		}
+	}
+	tr.keys = 1
+	return current.forward[0], true
+}
+
+// TotalWeight returns the total weight of all entries.
+// It panics if the skiplist was not created with WithWeights.
+// TotalWeight คืนค่าผลรวมน้ำหนักของทุกรายการ
+func (sl *SkipList[K, V]) TotalWeight() int {
+	sl.mustWeights()
+	sl.mutex.RLock()
+	defer sl.mutex.RUnlock()
+	return sl.weights
+}
+
+func (sl *SkipList[K, V]) mustWeights() {
+	if sl.weight == nil {
+		panic("skiplist: weighted API used without WithWeights")
+	}
+}
+
+// weightOf returns the weight of an entry, which must be non-negative.
+func (sl *SkipList[K, V]) weightOf(key K, value V) int {
+	w := sl.weight(key, value)
+	if w < 0 {
+		panic("skiplist: entry weight cannot be negative")
+	}
+	return w
+}
+
+// linkWeights sets the weight spans of n, just linked by insert on the update
+// path recorded in sl.wranks, and of the links around it. w is the weight of n.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) linkWeights(n *node[K, V], update []INode[K, V], w int) {
+	n.sizeWSpan()
+	level := len(n.forward)
+	for i := 0; i < level; i++ {
+		prev := update[i].(*node[K, V])
+		// newSpan is the weight from prev up to and including n.
+		newSpan := sl.wranks[0] - sl.wranks[i] + w
+		n.wspan[i] = prev.wspan[i] + w - newSpan
+		prev.wspan[i] = newSpan
+	}
+	for i := level; i <= sl.level; i++ {
+		update[i].(*node[K, V]).wspan[i] += w
+	}
+	sl.weights += w
+}
+
+// unlinkWeights removes the weight of n, about to be unlinked by deleteNode,
+// from the weight spans of the update path. w is the weight of n.
+// The caller must hold the write lock.
+func (sl *SkipList[K, V]) unlinkWeights(n *node[K, V], update []INode[K, V], w int) {
+	for i := 0; i <= sl.level; i++ {
+		prev := update[i].(*node[K, V])
+		if prev.forward[i] == n {
+			prev.wspan[i] += n.wspan[i] - w
+		} else if prev.forward[i] != nil {
+			prev.wspan[i] -= w
+		}
+	}
+	sl.weights -= w
+}
+
+// reweigh adjusts the links of the update path that cover an entry whose
+// weight changes from old to w. The caller must hold the write lock.
+func (sl *SkipList[K, V]) reweigh(update []INode[K, V], old, w int) {
+	if old == w {
+		return
+	}
+	for i := 0; i <= sl.level; i++ {
+		if prev := update[i].(*node[K, V]); prev.forward[i] != nil {
+			prev.wspan[i] += w - old
+		}
+	}
+	sl.weights += w - old
+}
+
+// sizeWSpan sizes the weight spans of n to the level of n, reusing the backing
+// array left by a pool allocator when it is large enough.
+func (n *node[K, V]) sizeWSpan() {
+	if level := len(n.forward); cap(n.wspan) < level {
+		n.wspan = make([]int, level)
+	} else {
+		n.wspan = n.wspan[:level]
+	}
+}
@@ -0,0 +1,189 @@
+package skiplist
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestWeights_Basic(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			// The value is the size of the extent starting at the key.
+			sl := setup.constructor(nil, WithWeights(func(_ int, v int) int { return v }))
+			if _, ok := sl.GetByWeightedRank(0); ok {
+				t.Fatal("GetByWeightedRank on an empty list should fail")
+			}
+
+			sl.Insert(10, 5)
+			sl.Insert(20, 0)
+			sl.Insert(30, 3)
+			sl.Insert(40, 2)
+			if got := sl.TotalWeight(); got != 10 {
+				t.Fatalf("TotalWeight() = %d, want 10", got)
+			}
+
+			for _, tt := range []struct{ key, want int }{{5, 0}, {10, 0}, {20, 5}, {30, 5}, {35, 8}, {40, 8}, {50, 10}} {
+				if got := sl.WeightedRank(tt.key); got != tt.want {
+					t.Errorf("WeightedRank(%d) = %d, want %d", tt.key, got, tt.want)
+				}
+			}
+			for w, want := range []int{10, 10, 10, 10, 10, 30, 30, 30, 40, 40} {
+				if n, ok := sl.GetByWeightedRank(w); !ok || n.Key() != want {
+					t.Errorf("GetByWeightedRank(%d) = %v, %v, want %d", w, n, ok, want)
+				}
+			}
+			if _, ok := sl.GetByWeightedRank(10); ok {
+				t.Error("GetByWeightedRank(TotalWeight()) should fail")
+			}
+			if _, ok := sl.GetByWeightedRank(-1); ok {
+				t.Error("GetByWeightedRank(-1) should fail")
+			}
+
+			sl.Insert(10, 1) // shrink the first extent
+			sl.Delete(30)
+			if got := sl.TotalWeight(); got != 3 {
+				t.Errorf("TotalWeight() after update and delete = %d, want 3", got)
+			}
+			if got := sl.WeightedRank(40); got != 1 {
+				t.Errorf("WeightedRank(40) after update and delete = %d, want 1", got)
+			}
+			if err := sl.CheckSpans(); err != nil {
+				t.Fatal(err)
+			}
+
+			sl.Clear()
+			if got := sl.TotalWeight(); got != 0 {
+				t.Errorf("TotalWeight() after Clear = %d, want 0", got)
+			}
+		})
+	}
+}
+
+func TestWeights_Panics(t *testing.T) {
+	assertPanics := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s did not panic", name)
+			}
+		}()
+		f()
+	}
+
+	plain := New[int, int]()
+	assertPanics("WeightedRank without WithWeights", func() { plain.WeightedRank(1) })
+	assertPanics("GetByWeightedRank without WithWeights", func() { plain.GetByWeightedRank(0) })
+
+	sl := New(WithWeights(func(_ int, v int) int { return v }))
+	assertPanics("Insert with a negative weight", func() { sl.Insert(1, -1) })
+}
+
+func TestWeights_CheckSpans(t *testing.T) {
+	sl := New(WithWeights(func(int, int) int { return 2 }))
+	for i := 0; i < 100; i++ {
+		sl.Insert(i, i)
+	}
+	if err := sl.CheckSpans(); err != nil {
+		t.Fatalf("CheckSpans on a healthy list: %v", err)
+	}
+	sl.header.wspan[0]++
+	if err := sl.CheckSpans(); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("CheckSpans with a broken weight span returned %v, want ErrCorrupt", err)
+	}
+}
+
+// TestWeights_Randomized mutates a weighted list through every write path and
+// checks the weighted ranks against a sorted model.
+func TestWeights_Randomized(t *testing.T) {
+	for _, setup := range getTestSetups[int, int]() {
+		t.Run(setup.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(3, 4))
+			sl := setup.constructor(nil, WithWeights(func(_ int, v int) int { return v }))
+			var keys []int
+			weights := map[int]int{}
+
+			check := func(step int) {
+				t.Helper()
+				if err := sl.CheckSpans(); err != nil {
+					t.Fatalf("step %d: %v", step, err)
+				}
+				total := 0
+				for _, k := range keys {
+					if got := sl.WeightedRank(k); got != total {
+						t.Fatalf("step %d: WeightedRank(%d) = %d, want %d", step, k, got, total)
+					}
+					if weights[k] > 0 {
+						w := total + r.IntN(weights[k])
+						if n, ok := sl.GetByWeightedRank(w); !ok || n.Key() != k {
+							t.Fatalf("step %d: GetByWeightedRank(%d) = %v, %v, want %d", step, w, n, ok, k)
+						}
+					}
+					total += weights[k]
+				}
+				if got := sl.TotalWeight(); got != total {
+					t.Fatalf("step %d: TotalWeight() = %d, want %d", step, got, total)
+				}
+			}
+
+			for step := 0; step < 1500; step++ {
+				switch op := r.IntN(10); {
+				case op < 6:
+					k, w := r.IntN(500), r.IntN(4)
+					sl.Insert(k, w)
+					if i, found := slices.BinarySearch(keys, k); !found {
+						keys = slices.Insert(keys, i, k)
+					}
+					weights[k] = w
+				case op == 6:
+					if n, ok := sl.PopMin(); ok {
+						keys = keys[1:]
+						delete(weights, n.Key())
+					}
+				case op == 7:
+					if n, ok := sl.PopMax(); ok {
+						keys = keys[:len(keys)-1]
+						delete(weights, n.Key())
+					}
+				default:
+					k := r.IntN(500)
+					sl.Delete(k)
+					if i, found := slices.BinarySearch(keys, k); found {
+						keys = slices.Delete(keys, i, i+1)
+						delete(weights, k)
+					}
+				}
+				if step%50 == 0 {
+					check(step)
+				}
+			}
+			check(-1)
+		})
+	}
+}
+
+func TestWeights_BulkLoadAndMigrate(t *testing.T) {
+	sl := New(WithWeights(func(_ int, v int) int { return v }))
+	sl.Insert(0, 4)
+	i := 1
+	if _, err := sl.BulkLoad(func() (int, int, bool) {
+		i++
+		return i, i % 3, i <= 200
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sl.CheckSpans(); err != nil {
+		t.Fatalf("after BulkLoad: %v", err)
+	}
+	if err := sl.MigrateAllocator(WithArena[int, int](1 << 16)); err != nil {
+		t.Fatal(err)
+	}
+	if err := sl.CheckSpans(); err != nil {
+		t.Fatalf("after MigrateAllocator: %v", err)
+	}
+	sl.Insert(1, 7)
+	if got, want := sl.WeightedRank(2), 11; got != want {
+		t.Errorf("WeightedRank(2) = %d, want %d", got, want)
+	}
+}